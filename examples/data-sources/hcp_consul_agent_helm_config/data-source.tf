@@ -1,4 +1,17 @@
 data "hcp_consul_agent_helm_config" "example" {
   cluster_id          = var.cluster_id
   kubernetes_endpoint = var.kubernetes_endpoint
+}
+
+data "hcp_consul_agent_helm_config" "agentless" {
+  cluster_id          = var.cluster_id
+  kubernetes_endpoint = var.kubernetes_endpoint
+  chart_version       = "1.4.0"
+  output_format       = "json"
+
+  override_values = yamlencode({
+    meshGateway = {
+      enabled = true
+    }
+  })
 }
\ No newline at end of file
@@ -0,0 +1,8 @@
+data "hcp_consul_cluster_client_config" "example" {
+  cluster_id = var.cluster_id
+}
+
+resource "local_file" "consul_ca" {
+  content  = data.hcp_consul_cluster_client_config.example.ca_pem
+  filename = "${path.module}/ca.pem"
+}
@@ -1 +1,5 @@
-data "hcp_consul_versions" "default" {}
\ No newline at end of file
+data "hcp_consul_versions" "default" {}
+
+data "hcp_consul_versions" "v1_16" {
+  version_constraint = "~> 1.16.0"
+}
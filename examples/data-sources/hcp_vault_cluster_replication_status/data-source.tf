@@ -0,0 +1,3 @@
+data "hcp_vault_cluster_replication_status" "example" {
+  cluster_id = "vault-secondary"
+}
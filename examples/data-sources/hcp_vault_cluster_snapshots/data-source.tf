@@ -0,0 +1,3 @@
+data "hcp_vault_cluster_snapshots" "example" {
+  cluster_id = "vault-cluster"
+}
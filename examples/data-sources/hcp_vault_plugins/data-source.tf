@@ -0,0 +1,3 @@
+data "hcp_vault_plugins" "example" {
+  cluster_id = "vault-cluster"
+}
@@ -0,0 +1,9 @@
+ephemeral "hcp_vault_cluster_admin_token" "example" {
+  cluster_id = "test-vault-cluster"
+}
+
+provider "vault" {
+  address   = var.vault_address
+  namespace = "admin"
+  token     = ephemeral.hcp_vault_cluster_admin_token.example.token
+}
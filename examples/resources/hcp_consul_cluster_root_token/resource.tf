@@ -1,3 +1,10 @@
 resource "hcp_consul_cluster_root_token" "example" {
-  cluster_id = "consul-cluster"
-}
\ No newline at end of file
+  cluster_id                  = "consul-cluster"
+  rotation_period             = "720h"
+  kubernetes_secret_name      = "consul-bootstrap-token"
+  kubernetes_secret_namespace = "consul"
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
@@ -0,0 +1,10 @@
+resource "hcp_consul_snapshot" "example" {
+  cluster_id    = "consul-cluster"
+  snapshot_name = "my-snapshot"
+}
+
+resource "hcp_consul_snapshot_restore" "example" {
+  cluster_id    = hcp_consul_snapshot.example.cluster_id
+  snapshot_id   = hcp_consul_snapshot.example.snapshot_id
+  take_snapshot = true
+}
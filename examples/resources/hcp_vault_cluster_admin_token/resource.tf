@@ -1,3 +1,4 @@
 resource "hcp_vault_cluster_admin_token" "example" {
-  cluster_id = "test-vault-cluster"
+  cluster_id   = "test-vault-cluster"
+  renew_before = "30m"
 }
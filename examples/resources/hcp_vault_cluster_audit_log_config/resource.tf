@@ -0,0 +1,8 @@
+resource "hcp_vault_cluster_audit_log_config" "example" {
+  cluster_id = "vault-cluster"
+
+  splunk {
+    hec_endpoint = "https://http-input-splunkcloud.com"
+    token        = var.splunk_token
+  }
+}
@@ -0,0 +1,8 @@
+resource "hcp_vault_cluster_metrics_config" "example" {
+  cluster_id = "vault-cluster"
+
+  datadog {
+    api_key = var.datadog_api_key
+    region  = "us1"
+  }
+}
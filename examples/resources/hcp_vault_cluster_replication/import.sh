@@ -0,0 +1,7 @@
+# The import ID identifies the secondary cluster.
+# Using an explicit project ID, the import ID is:
+# {project_id}:{secondary_cluster_id}
+terraform import hcp_vault_cluster_replication.example f709ec73-55d4-46d8-897d-816ebba28778:vault-secondary
+# Using the provider-default project ID, the import ID is:
+# {secondary_cluster_id}
+terraform import hcp_vault_cluster_replication.example vault-secondary
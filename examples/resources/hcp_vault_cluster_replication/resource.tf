@@ -0,0 +1,32 @@
+resource "hcp_hvn" "primary" {
+  hvn_id         = "hvn-primary"
+  cloud_provider = "aws"
+  region         = "us-west-2"
+  cidr_block     = "172.25.16.0/20"
+}
+
+resource "hcp_hvn" "secondary" {
+  hvn_id         = "hvn-secondary"
+  cloud_provider = "aws"
+  region         = "eu-central-1"
+  cidr_block     = "172.26.16.0/20"
+}
+
+resource "hcp_vault_cluster" "primary" {
+  cluster_id = "vault-primary"
+  hvn_id     = hcp_hvn.primary.hvn_id
+  tier       = "plus_small"
+}
+
+resource "hcp_vault_cluster" "secondary" {
+  cluster_id   = "vault-secondary"
+  hvn_id       = hcp_hvn.secondary.hvn_id
+  tier         = hcp_vault_cluster.primary.tier
+  primary_link = hcp_vault_cluster.primary.self_link
+}
+
+resource "hcp_vault_cluster_replication" "example" {
+  primary_link   = hcp_vault_cluster.primary.self_link
+  secondary_link = hcp_vault_cluster.secondary.self_link
+  paths_filter   = ["path/a", "path/b"]
+}
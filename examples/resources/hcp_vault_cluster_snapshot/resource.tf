@@ -0,0 +1,4 @@
+resource "hcp_vault_cluster_snapshot" "example" {
+  cluster_id    = "vault-cluster"
+  snapshot_name = "before-upgrade"
+}
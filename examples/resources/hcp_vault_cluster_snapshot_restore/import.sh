@@ -0,0 +1,7 @@
+# The import ID identifies the cluster and the restored snapshot.
+# Using an explicit project ID, the import ID is:
+# {project_id}:{cluster_id}:{snapshot_id}
+terraform import hcp_vault_cluster_snapshot_restore.example f709ec73-55d4-46d8-897d-816ebba28778:vault-cluster:3d3f0d5c-1f2b-4c5e-9a57-1b2f7e0c9a41
+# Using the provider-default project ID, the import ID is:
+# {cluster_id}:{snapshot_id}
+terraform import hcp_vault_cluster_snapshot_restore.example vault-cluster:3d3f0d5c-1f2b-4c5e-9a57-1b2f7e0c9a41
@@ -0,0 +1,9 @@
+resource "hcp_vault_cluster_snapshot" "example" {
+  cluster_id    = "vault-cluster"
+  snapshot_name = "before-upgrade"
+}
+
+resource "hcp_vault_cluster_snapshot_restore" "example" {
+  cluster_id  = hcp_vault_cluster_snapshot.example.cluster_id
+  snapshot_id = hcp_vault_cluster_snapshot.example.snapshot_id
+}
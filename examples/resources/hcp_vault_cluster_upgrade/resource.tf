@@ -0,0 +1,24 @@
+resource "hcp_hvn" "example" {
+  hvn_id         = "hvn"
+  cloud_provider = "aws"
+  region         = "us-west-2"
+  cidr_block     = "172.25.16.0/20"
+}
+
+resource "hcp_vault_cluster" "example" {
+  cluster_id = "vault-cluster"
+  hvn_id     = hcp_hvn.example.hvn_id
+  tier       = "standard_small"
+  major_version_upgrade_config {
+    upgrade_type            = "SCHEDULED"
+    maintenance_window_day  = "MONDAY"
+    maintenance_window_time = "WINDOW_12AM_4AM"
+  }
+}
+
+resource "hcp_vault_cluster_upgrade" "example" {
+  cluster_id              = hcp_vault_cluster.example.cluster_id
+  vault_version           = "1.16.0"
+  maintenance_window_day  = "MONDAY"
+  maintenance_window_time = "WINDOW_12AM_4AM"
+}
@@ -6,9 +6,11 @@ package clients
 import (
 	"errors"
 	"fmt"
-	"log"
+	"net/http"
 	"strings"
 
+	"github.com/go-openapi/runtime"
+
 	"github.com/hashicorp/hcp-sdk-go/auth"
 	"github.com/hashicorp/hcp-sdk-go/auth/workload"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -95,6 +97,10 @@ type Client struct {
 	RadarSourceRegistrationService radar_src_registration_service.ClientService
 	RadarConnectionService         radar_connection_service.ClientService
 	RadarSubscriptionService       radar_subscription_service.ClientService
+
+	// transport is the runtime shared by the service clients. It is kept so
+	// that clients with a different source channel can reuse it.
+	transport runtime.ClientTransport
 }
 
 // ClientConfig specifies configuration for the client that interacts with HCP
@@ -122,15 +128,52 @@ type ClientConfig struct {
 	// ProjectID (optional) is the project unique identifier to launch resources in.
 	ProjectID string
 
+	// Profile (optional) is the name of the hcp CLI profile the organization
+	// and project default to. See ApplyProfile.
+	Profile string
+
 	// SourceChannel denotes the client (channel) that originated the HCP cluster request.
 	// this is synonymous to a user-agent.
 	SourceChannel string
+
+	// APIAddress, AuthURL and PortalURL (optional) override the HCP
+	// endpoints, which otherwise come from the HCP_* environment variables or
+	// default to production. APIAddress is a host and port, without a scheme.
+	APIAddress string
+	AuthURL    string
+	PortalURL  string
+
+	// TLS configures how the API and auth endpoints are verified.
+	TLS TLSConfig
+
+	// Retry configures how failed requests are retried. Unset fields use the
+	// defaults.
+	Retry RetryConfig
+
+	// RateLimit bounds the number and rate of requests sent to HCP. The limit
+	// is shared by every client created with the same RateLimit.
+	RateLimit RateLimitConfig
+
+	// OperationWait configures how long-running operations are polled. Unset
+	// fields use the defaults.
+	OperationWait OperationWaitConfig
+
+	// ConsulHTTPClient (optional) sends the requests made directly to the
+	// Consul API of a cluster. http.DefaultClient is used if unset.
+	ConsulHTTPClient *http.Client
 }
 
 // NewClient creates a new Client that is capable of making HCP requests
 func NewClient(config ClientConfig) (*Client, error) {
 	// Build the HCP Config options
 	opts := []hcpConfig.HCPConfigOption{hcpConfig.FromEnv()}
+
+	endpointOpts, err := endpointOptions(config)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, endpointOpts...)
+
 	if config.ClientID != "" && config.ClientSecret != "" {
 		opts = append(opts, hcpConfig.WithClientCredentials(config.ClientID, config.ClientSecret))
 	} else if config.CredentialFile != "" {
@@ -150,46 +193,65 @@ func NewClient(config ClientConfig) (*Client, error) {
 		return nil, fmt.Errorf("no valid credentials available: %w", err)
 	}
 
+	// The source channel is set per request by sourceChannelTransport rather
+	// than by the SDK, so that it can be changed without a new runtime.
 	httpClient, err := sdk.New(sdk.Config{
-		HCPConfig:     hcp,
-		SourceChannel: config.SourceChannel,
+		HCPConfig: hcp,
 	})
 	if err != nil {
 		return nil, err
 	}
+	httpClient.Transport = &sourceChannelTransport{
+		next:          httpClient.Transport,
+		sourceChannel: config.SourceChannel,
+	}
+
+	// Limit and retry requests for every service client. Each retry attempt
+	// goes through the rate limiter again, so backoff does not hold a slot.
+	if config.RateLimit.enabled() {
+		httpClient.Transport = &rateLimitTransport{
+			next:    httpClient.Transport,
+			limiter: sharedRateLimiter(config.RateLimit),
+		}
+	}
+	httpClient.Transport = newRetryTransport(httpClient.Transport, config.Retry)
 
 	httpClient.SetLogger(logger{})
 	if ShouldLog() {
 		httpClient.Debug = true
 	}
 
-	client := &Client{
+	return newClient(config, &attemptTimeoutRuntime{ClientTransport: httpClient}), nil
+}
+
+// newClient creates the service clients on top of the given runtime.
+func newClient(config ClientConfig, transport runtime.ClientTransport) *Client {
+	return &Client{
 		Config:                         config,
-		Billing:                        cloud_billing.New(httpClient, nil).BillingAccountService,
-		Boundary:                       cloud_boundary.New(httpClient, nil).BoundaryService,
-		Consul:                         cloud_consul.New(httpClient, nil).ConsulService,
-		IAM:                            cloud_iam.New(httpClient, nil).IamService,
-		Network:                        cloud_network.New(httpClient, nil).NetworkService,
-		Operation:                      cloud_operation.New(httpClient, nil).OperationService,
-		Organization:                   cloud_resource_manager.New(httpClient, nil).OrganizationService,
-		Packer:                         cloud_packer.New(httpClient, nil).PackerService,
-		PackerV2:                       cloud_packer_v2.New(httpClient, nil).PackerService,
-		Project:                        cloud_resource_manager.New(httpClient, nil).ProjectService,
-		ServicePrincipals:              cloud_iam.New(httpClient, nil).ServicePrincipalsService,
-		Groups:                         cloud_iam.New(httpClient, nil).GroupsService,
-		Vault:                          cloud_vault.New(httpClient, nil).VaultService,
-		VaultSecrets:                   cloud_vault_secrets.New(httpClient, nil).SecretService,
-		Waypoint:                       cloud_waypoint.New(httpClient, nil).WaypointService,
-		LogService:                     cloud_log_service.New(httpClient, nil).LogService,
-		LogStreamingService:            cloud_log_service.New(httpClient, nil).StreamingService,
-		Webhook:                        cloud_webhook.New(httpClient, nil).WebhookService,
-		ResourceService:                cloud_resource_manager.New(httpClient, nil).ResourceService,
-		RadarSourceRegistrationService: cloud_vault_radar.New(httpClient, nil).DataSourceRegistrationService,
-		RadarConnectionService:         cloud_vault_radar.New(httpClient, nil).IntegrationConnectionService,
-		RadarSubscriptionService:       cloud_vault_radar.New(httpClient, nil).IntegrationSubscriptionService,
+		Billing:                        cloud_billing.New(transport, nil).BillingAccountService,
+		Boundary:                       cloud_boundary.New(transport, nil).BoundaryService,
+		Consul:                         cloud_consul.New(transport, nil).ConsulService,
+		IAM:                            cloud_iam.New(transport, nil).IamService,
+		Network:                        cloud_network.New(transport, nil).NetworkService,
+		Operation:                      cloud_operation.New(transport, nil).OperationService,
+		Organization:                   cloud_resource_manager.New(transport, nil).OrganizationService,
+		Packer:                         cloud_packer.New(transport, nil).PackerService,
+		PackerV2:                       cloud_packer_v2.New(transport, nil).PackerService,
+		Project:                        cloud_resource_manager.New(transport, nil).ProjectService,
+		ServicePrincipals:              cloud_iam.New(transport, nil).ServicePrincipalsService,
+		Groups:                         cloud_iam.New(transport, nil).GroupsService,
+		Vault:                          cloud_vault.New(transport, nil).VaultService,
+		VaultSecrets:                   cloud_vault_secrets.New(transport, nil).SecretService,
+		Waypoint:                       cloud_waypoint.New(transport, nil).WaypointService,
+		LogService:                     cloud_log_service.New(transport, nil).LogService,
+		LogStreamingService:            cloud_log_service.New(transport, nil).StreamingService,
+		Webhook:                        cloud_webhook.New(transport, nil).WebhookService,
+		ResourceService:                cloud_resource_manager.New(transport, nil).ResourceService,
+		RadarSourceRegistrationService: cloud_vault_radar.New(transport, nil).DataSourceRegistrationService,
+		RadarConnectionService:         cloud_vault_radar.New(transport, nil).IntegrationConnectionService,
+		RadarSubscriptionService:       cloud_vault_radar.New(transport, nil).IntegrationSubscriptionService,
+		transport:                      transport,
 	}
-
-	return client, nil
 }
 
 // loadCredentialFile loads the credential file from the given config. If the
@@ -260,20 +322,30 @@ func (cl *Client) UpdateSourceChannel(d *schema.ResourceData) (*Client, error) {
 	}
 
 	if m.ModuleName != "" {
-		sc := cl.Config.SourceChannel
-		sc = strings.Join([]string{sc, fmt.Sprintf("terraform-module/%s", m.ModuleName)}, " ")
-		cl.Config.SourceChannel = sc
-
-		// Return a new client with the updated source channel
-		cl, err = NewClient(cl.Config)
-		if err != nil {
-			log.Printf("failed to create new client with updated source channel: %v", err)
-		}
+		sc := strings.Join([]string{cl.Config.SourceChannel, fmt.Sprintf("terraform-module/%s", m.ModuleName)}, " ")
+		return cl.WithSourceChannel(sc), nil
 	}
 
 	return cl, nil
 }
 
+// WithSourceChannel returns a copy of the client that sends the given source
+// channel with each request. The copy shares the runtime, and therefore the
+// token, of the original client.
+func (cl *Client) WithSourceChannel(sourceChannel string) *Client {
+	transport := cl.transport
+	if scr, ok := transport.(*sourceChannelRuntime); ok {
+		transport = scr.ClientTransport
+	}
+
+	config := cl.Config
+	config.SourceChannel = sourceChannel
+	return newClient(config, &sourceChannelRuntime{
+		ClientTransport: transport,
+		sourceChannel:   sourceChannel,
+	})
+}
+
 func (cl *Client) GetOrganizationID() string {
 	if cl == nil {
 		return ""
@@ -289,11 +361,16 @@ func (cl *Client) GetProjectID() string {
 }
 
 // Location returns the organization and project ID to use for a given resource
-// The resource project ID takes precedence over the provider project ID
-func (cl *Client) Location(resourceProjectID types.String) (string, string) {
+// The resource organization and project IDs take precedence over the provider ones
+func (cl *Client) Location(resourceOrganizationID, resourceProjectID types.String) (string, string) {
 	orgID := cl.Config.OrganizationID
 	projID := cl.Config.ProjectID
 
+	// organization ID defined in the resource schema has precedence over the organization ID from the provider
+	if !resourceOrganizationID.IsUnknown() && resourceOrganizationID.ValueString() != "" {
+		orgID = resourceOrganizationID.ValueString()
+	}
+
 	// project ID defined in the resource schema has precedence over the project ID from the provider
 	if !resourceProjectID.IsUnknown() {
 		projID = resourceProjectID.ValueString()
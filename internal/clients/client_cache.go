@@ -0,0 +1,124 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// clientCacheKey identifies the inputs that determine how a Client
+// authenticates and which project it defaults to. The source channel is
+// deliberately excluded so that the SDKv2 and framework providers, which
+// report different user agents, share the same runtime.
+type clientCacheKey struct {
+	clientID                     string
+	clientSecret                 string
+	credentialFile               string
+	workloadIdentityTokenFile    string
+	workloadIdentityToken        string
+	workloadIdentityResourceName string
+	organizationID               string
+	projectID                    string
+	profile                      string
+	apiAddress                   string
+	authURL                      string
+	portalURL                    string
+	tls                          TLSConfig
+	retry                        string
+	rateLimit                    RateLimitConfig
+	operationWait                OperationWaitConfig
+
+	// env captures the HCP_* environment variables read by the SDK.
+	env string
+}
+
+func newClientCacheKey(config ClientConfig) clientCacheKey {
+	var env []string
+	for _, kv := range os.Environ() {
+		if strings.HasPrefix(kv, "HCP_") {
+			env = append(env, kv)
+		}
+	}
+	sort.Strings(env)
+
+	return clientCacheKey{
+		clientID:                     config.ClientID,
+		clientSecret:                 config.ClientSecret,
+		credentialFile:               config.CredentialFile,
+		workloadIdentityTokenFile:    config.WorkloadIdentityTokenFile,
+		workloadIdentityToken:        config.WorkloadIdentityToken,
+		workloadIdentityResourceName: config.WorkloadIdentityResourceName,
+		organizationID:               config.OrganizationID,
+		projectID:                    config.ProjectID,
+		profile:                      config.Profile,
+		apiAddress:                   config.APIAddress,
+		authURL:                      config.AuthURL,
+		portalURL:                    config.PortalURL,
+		tls:                          config.TLS,
+		retry:                        fmt.Sprintf("%+v", config.Retry),
+		rateLimit:                    config.RateLimit,
+		operationWait:                config.OperationWait,
+		env:                          strings.Join(env, "\n"),
+	}
+}
+
+// sharedClient is a cache entry. mu is held while the client is being
+// created so that concurrent callers wait for a single initialization.
+type sharedClient struct {
+	mu     sync.Mutex
+	client *Client
+}
+
+var (
+	sharedClients   = map[clientCacheKey]*sharedClient{}
+	sharedClientsMu sync.Mutex
+)
+
+// NewSharedClient returns a Client for the given configuration, reusing the
+// one previously created in this process for an equivalent configuration.
+// A reused client keeps its runtime but sends the requested source channel.
+//
+// When a new client is created, resolve is called with it before it is
+// cached. It is used to resolve the default organization and project once for
+// all providers. If NewClient or resolve fail, nothing is cached and the next
+// call tries again.
+func NewSharedClient(config ClientConfig, resolve func(*Client) error) (*Client, error) {
+	key := newClientCacheKey(config)
+
+	sharedClientsMu.Lock()
+	entry, ok := sharedClients[key]
+	if !ok {
+		entry = &sharedClient{}
+		sharedClients[key] = entry
+	}
+	sharedClientsMu.Unlock()
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	if entry.client != nil {
+		if entry.client.Config.SourceChannel != config.SourceChannel {
+			return entry.client.WithSourceChannel(config.SourceChannel), nil
+		}
+		return entry.client, nil
+	}
+
+	client, err := NewClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if resolve != nil {
+		if err := resolve(client); err != nil {
+			return nil, err
+		}
+	}
+
+	entry.client = client
+	return client, nil
+}
@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients/hcptest"
+)
+
+func TestNewSharedClient(t *testing.T) {
+	srv := hcptest.NewServer(t)
+	srv.Setenv(t)
+
+	var resolved int
+	resolve := func(*clients.Client) error {
+		resolved++
+		return nil
+	}
+
+	config := srv.ClientConfig()
+	a, err := clients.NewSharedClient(config, resolve)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	b, err := clients.NewSharedClient(config, resolve)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a != b {
+		t.Error("expected equivalent configurations to share a client")
+	}
+
+	// The source channel differs between the SDKv2 and framework providers
+	// and must not cause a second client to be created.
+	config.SourceChannel = "terraform-provider-hcp/other"
+	sc, err := clients.NewSharedClient(config, resolve)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sc.Config.SourceChannel != config.SourceChannel {
+		t.Errorf("expected source channel %q, got %q", config.SourceChannel, sc.Config.SourceChannel)
+	}
+	if resolved != 1 {
+		t.Errorf("expected resolve to be called once, got %d", resolved)
+	}
+
+	config.ProjectID = srv.AddProject("other")
+	c, err := clients.NewSharedClient(config, resolve)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a == c {
+		t.Error("expected a different project to use a different client")
+	}
+}
+
+func TestNewSharedClient_resolveError(t *testing.T) {
+	srv := hcptest.NewServer(t)
+	srv.Setenv(t)
+
+	config := srv.ClientConfig()
+	resolveErr := errors.New("resolve failed")
+	if _, err := clients.NewSharedClient(config, func(*clients.Client) error { return resolveErr }); !errors.Is(err, resolveErr) {
+		t.Fatalf("expected resolve error, got %v", err)
+	}
+
+	// A failed resolution is not cached.
+	var resolved bool
+	client, err := clients.NewSharedClient(config, func(*clients.Client) error {
+		resolved = true
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if client == nil || !resolved {
+		t.Error("expected the client to be created and resolved again")
+	}
+}
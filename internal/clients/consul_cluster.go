@@ -5,6 +5,9 @@ package clients
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
 
 	"github.com/hashicorp/hcp-sdk-go/clients/cloud-consul-service/stable/2021-02-04/client/consul_service"
 
@@ -80,6 +83,47 @@ func CreateCustomerRootACLToken(ctx context.Context, client *Client, loc *shared
 	return resp.Payload, nil
 }
 
+// GetConsulACLTokenAccessorID asks the Consul API at address, a URL without a
+// path, which ACL token secretID belongs to and returns its accessor ID. It
+// returns an empty string if Consul does not know the token, for instance
+// because it was revoked.
+func GetConsulACLTokenAccessorID(ctx context.Context, client *Client, address, secretID string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, address+"/v1/acl/token/self", nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Consul-Token", secretID)
+
+	httpClient := client.Config.ConsulHTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusForbidden:
+		// Consul rejects a token it does not know with "ACL not found".
+		return "", nil
+	default:
+		return "", fmt.Errorf("unexpected response from the Consul ACL API: %s", resp.Status)
+	}
+
+	var token struct {
+		AccessorID string
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("unable to decode the Consul ACL token: %w", err)
+	}
+
+	return token.AccessorID, nil
+}
+
 // CreateConsulCluster will make a call to the Consul service to initiate the create Consul
 // cluster workflow.
 func CreateConsulCluster(ctx context.Context, client *Client, loc *sharedmodels.HashicorpCloudLocationLocation,
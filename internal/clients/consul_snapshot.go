@@ -90,3 +90,59 @@ func RenameSnapshotByID(ctx context.Context, client *Client, loc *sharedmodels.H
 
 	return resp.Payload, nil
 }
+
+// ListSnapshots lists the Consul snapshots of a Consul cluster
+func ListSnapshots(ctx context.Context, client *Client, loc *sharedmodels.HashicorpCloudLocationLocation,
+	clusterID string) ([]*consulmodels.HashicorpCloudConsul20210204Snapshot, error) {
+
+	resourceType := "hashicorp.consul.cluster"
+
+	p := consul_service.NewListSnapshotsParams()
+	p.Context = ctx
+	p.ResourceLocationOrganizationID = loc.OrganizationID
+	p.ResourceLocationProjectID = loc.ProjectID
+	p.ResourceID = &clusterID
+	p.ResourceType = &resourceType
+
+	var snapshots []*consulmodels.HashicorpCloudConsul20210204Snapshot
+	for {
+		resp, err := client.Consul.ListSnapshots(p, nil)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, resp.Payload.Snapshots...)
+
+		pagination := resp.Payload.Pagination
+		if pagination == nil || pagination.NextPageToken == "" {
+			return snapshots, nil
+		}
+		p.PaginationNextPageToken = &pagination.NextPageToken
+	}
+}
+
+// RestoreSnapshot will make a call to the Consul service to restore a Consul
+// snapshot on a Consul cluster, optionally taking a snapshot of the cluster
+// first.
+func RestoreSnapshot(ctx context.Context, client *Client, loc *sharedmodels.HashicorpCloudLocationLocation,
+	clusterID string, snapshot *sharedmodels.HashicorpCloudLocationLink,
+	takeSnapshot bool) (*consulmodels.HashicorpCloudConsul20210204RestoreSnapshotResponse, error) {
+
+	p := consul_service.NewRestoreSnapshotParams()
+	p.Context = ctx
+	p.ClusterID = clusterID
+	p.LocationOrganizationID = loc.OrganizationID
+	p.LocationProjectID = loc.ProjectID
+	p.Body = &consulmodels.HashicorpCloudConsul20210204RestoreSnapshotRequest{
+		ClusterID:    clusterID,
+		Location:     loc,
+		Snapshot:     snapshot,
+		TakeSnapshot: takeSnapshot,
+	}
+
+	resp, err := client.Consul.RestoreSnapshot(p, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Payload, nil
+}
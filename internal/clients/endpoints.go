@@ -0,0 +1,110 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	hcpConfig "github.com/hashicorp/hcp-sdk-go/config"
+)
+
+const (
+	// defaultAPIAddress and defaultAuthURL are the production endpoints the
+	// SDK uses when neither the configuration nor the environment set one.
+	defaultAPIAddress = "api.cloud.hashicorp.com:443"
+	defaultAuthURL    = "https://auth.idp.hashicorp.com"
+
+	// envVarAPIAddress and envVarAuthURL are the environment variables the SDK
+	// reads the endpoints from.
+	envVarAPIAddress = "HCP_API_ADDRESS"
+	envVarAuthURL    = "HCP_AUTH_URL"
+)
+
+// TLSConfig configures how the provider verifies the HCP API and auth
+// endpoints.
+type TLSConfig struct {
+	// CACertFile is the path to a PEM encoded bundle of CA certificates used
+	// instead of the system roots.
+	CACertFile string
+
+	// InsecureSkipVerify disables the verification of the server
+	// certificates. It should only be used for testing.
+	InsecureSkipVerify bool
+}
+
+// enabled reports whether any TLS setting is configured.
+func (c TLSConfig) enabled() bool {
+	return c.CACertFile != "" || c.InsecureSkipVerify
+}
+
+// tlsConfig builds the crypto/tls configuration.
+func (c TLSConfig) tlsConfig() (*tls.Config, error) {
+	config := &tls.Config{
+		InsecureSkipVerify: c.InsecureSkipVerify, //nolint:gosec // opt-in, for testing against local servers
+	}
+
+	if c.CACertFile != "" {
+		pem, err := os.ReadFile(c.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read CA file: %w", err)
+		}
+
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no PEM encoded certificates found in CA file %q", c.CACertFile)
+		}
+	}
+
+	return config, nil
+}
+
+// endpointOptions returns the options that point the SDK at the configured
+// endpoints. They take precedence over the HCP_* environment variables.
+//
+// The TLS settings apply to both the API and the auth endpoint. When they are
+// set without an endpoint, they apply to the endpoint from the environment, or
+// to the production one. An endpoint set without TLS settings is verified
+// against the system roots.
+func endpointOptions(config ClientConfig) ([]hcpConfig.HCPConfigOption, error) {
+	var opts []hcpConfig.HCPConfigOption
+
+	if config.PortalURL != "" {
+		opts = append(opts, hcpConfig.WithPortalURL(config.PortalURL))
+	}
+
+	tlsConfig, err := config.TLS.tlsConfig()
+	if err != nil {
+		return nil, fmt.Errorf("invalid TLS config: %w", err)
+	}
+
+	if config.APIAddress != "" || config.TLS.enabled() {
+		apiAddress := config.APIAddress
+		if apiAddress == "" {
+			apiAddress = envOrDefault(envVarAPIAddress, defaultAPIAddress)
+		}
+		opts = append(opts, hcpConfig.WithAPI(apiAddress, tlsConfig))
+	}
+
+	if config.AuthURL != "" || config.TLS.enabled() {
+		authURL := config.AuthURL
+		if authURL == "" {
+			authURL = envOrDefault(envVarAuthURL, defaultAuthURL)
+		}
+		opts = append(opts, hcpConfig.WithAuth(authURL, tlsConfig))
+	}
+
+	return opts, nil
+}
+
+// envOrDefault returns the value of the environment variable, or def if it is
+// unset or empty.
+func envOrDefault(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients_test
+
+import (
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcp-sdk-go/clients/cloud-resource-manager/stable/2019-12-10/client/project_service"
+
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients/hcptest"
+)
+
+func TestNewClient_endpoints(t *testing.T) {
+	srv := hcptest.NewServer(t)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	if err := os.WriteFile(caFile, caPEM, 0o600); err != nil {
+		t.Fatalf("unexpected error writing CA file: %v", err)
+	}
+
+	tcs := map[string]struct {
+		tls     clients.TLSConfig
+		wantErr string
+	}{
+		"ca file": {
+			tls: clients.TLSConfig{CACertFile: caFile},
+		},
+		"insecure": {
+			tls: clients.TLSConfig{InsecureSkipVerify: true},
+		},
+		"system roots": {
+			wantErr: "certificate",
+		},
+		"missing ca file": {
+			tls:     clients.TLSConfig{CACertFile: filepath.Join(t.TempDir(), "missing.pem")},
+			wantErr: "unable to read CA file",
+		},
+		"invalid ca file": {
+			tls:     clients.TLSConfig{CACertFile: os.Args[0]},
+			wantErr: "no PEM encoded certificates",
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			// Avoid reusing a token cached by the SDK in another test.
+			t.Setenv("HOME", t.TempDir())
+
+			config := srv.ClientConfig()
+			config.APIAddress = srv.Address()
+			config.AuthURL = srv.URL
+			config.TLS = tc.tls
+
+			client, err := clients.NewClient(config)
+			if err == nil {
+				params := project_service.NewProjectServiceGetParams()
+				params.ID = srv.ProjectID
+				_, err = client.Project.ProjectServiceGet(params, nil)
+			}
+
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
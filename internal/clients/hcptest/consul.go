@@ -0,0 +1,514 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package hcptest
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	consulmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-consul-service/stable/2021-02-04/models"
+	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
+	"google.golang.org/grpc/codes"
+)
+
+// ConsulVersions are the versions reported by the consul versions endpoints.
+// The first entry is the recommended version.
+var ConsulVersions = []string{"v1.17.2", "v1.16.5", "v1.15.9"}
+
+type consulCluster struct {
+	model *consulmodels.HashicorpCloudConsul20210204Cluster
+
+	// rootTokenAccessorID and rootTokenSecretID identify the valid root
+	// token, the last one created.
+	rootTokenAccessorID string
+	rootTokenSecretID   string
+}
+
+type consulSnapshot struct {
+	model *consulmodels.HashicorpCloudConsul20210204Snapshot
+}
+
+func (s *Server) registerConsul(mux *http.ServeMux) {
+	const base = "/consul/2021-02-04/organizations/{org}/projects/{project}"
+
+	mux.HandleFunc("GET /consul/2021-02-04/versions", s.listConsulVersions)
+	mux.HandleFunc("GET "+base+"/versions", s.listConsulVersions)
+
+	mux.HandleFunc("GET "+base+"/clusters", s.listConsulClusters)
+	mux.HandleFunc("POST "+base+"/clusters", s.createConsulCluster)
+	mux.HandleFunc("GET "+base+"/clusters/{id}", s.getConsulCluster)
+	mux.HandleFunc("PATCH "+base+"/clusters/{id}", s.updateConsulCluster)
+	mux.HandleFunc("DELETE "+base+"/clusters/{id}", s.deleteConsulCluster)
+	mux.HandleFunc("GET "+base+"/clusters/{id}/client-config", s.getConsulClientConfig)
+	mux.HandleFunc("POST "+base+"/clusters/{id}/master-acl-tokens", s.createConsulRootToken)
+	mux.HandleFunc("GET "+base+"/clusters/{id}/upgrade-versions", s.listConsulUpgradeVersions)
+	mux.HandleFunc("POST "+base+"/clusters/{id}/restore", s.restoreConsulSnapshot)
+
+	mux.HandleFunc("GET "+base+"/snapshots", s.listConsulSnapshots)
+	mux.HandleFunc("POST "+base+"/snapshots", s.createConsulSnapshot)
+	mux.HandleFunc("GET "+base+"/snapshots/{id}", s.getConsulSnapshot)
+	mux.HandleFunc("PATCH "+base+"/snapshots/{id}", s.renameConsulSnapshot)
+	mux.HandleFunc("DELETE "+base+"/snapshots/{id}", s.deleteConsulSnapshot)
+
+	// The Consul API of the clusters, see ConsulHTTPClient.
+	mux.HandleFunc("GET /v1/acl/token/self", s.readConsulACLTokenSelf)
+}
+
+// consulClusterFor looks up the cluster addressed by the request, writing a
+// 404 if it does not exist. It must be called with s.mu held.
+func (s *Server) consulClusterFor(w http.ResponseWriter, r *http.Request) (*consulCluster, bool) {
+	c, ok := s.consulClusters[locationKey(r.PathValue("project"), r.PathValue("id"))]
+	if !ok {
+		writeNotFound(w, "consul cluster", r.PathValue("id"))
+	}
+	return c, ok
+}
+
+func (s *Server) listConsulVersions(w http.ResponseWriter, r *http.Request) {
+	resp := &consulmodels.HashicorpCloudConsul20210204ListVersionsResponse{}
+	for i, v := range ConsulVersions {
+		status := consulmodels.HashicorpCloudConsul20210204VersionStatusAVAILABLE
+		if i == 0 {
+			status = consulmodels.HashicorpCloudConsul20210204VersionStatusRECOMMENDED
+		}
+		resp.Versions = append(resp.Versions, &consulmodels.HashicorpCloudConsul20210204Version{
+			Version: v,
+			Status:  status.Pointer(),
+		})
+	}
+
+	writeJSON(w, resp)
+}
+
+func (s *Server) listConsulUpgradeVersions(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.consulClusterFor(w, r); !ok {
+		return
+	}
+
+	s.listConsulVersions(w, r)
+}
+
+func (s *Server) listConsulClusters(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resp := &consulmodels.HashicorpCloudConsul20210204ListResponse{}
+	for _, c := range s.consulClusters {
+		if c.model.Location.ProjectID == r.PathValue("project") {
+			resp.Clusters = append(resp.Clusters, c.model)
+		}
+	}
+
+	writeJSON(w, resp)
+}
+
+func (s *Server) createConsulCluster(w http.ResponseWriter, r *http.Request) {
+	var req consulmodels.HashicorpCloudConsul20210204CreateRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	cluster := req.Cluster
+	if cluster == nil || cluster.ID == "" || cluster.Config == nil || cluster.Config.NetworkConfig == nil || cluster.Config.NetworkConfig.Network == nil {
+		writeError(w, http.StatusBadRequest, codes.InvalidArgument, "cluster id and network config are required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := locationKey(r.PathValue("project"), cluster.ID)
+	if _, ok := s.consulClusters[key]; ok {
+		writeAlreadyExists(w, "consul cluster", cluster.ID)
+		return
+	}
+
+	hvn, ok := s.networks[locationKey(r.PathValue("project"), cluster.Config.NetworkConfig.Network.ID)]
+	if !ok {
+		writeNotFound(w, "network", cluster.Config.NetworkConfig.Network.ID)
+		return
+	}
+
+	if cluster.ConsulVersion == "" {
+		cluster.ConsulVersion = ConsulVersions[0]
+	}
+	if cluster.Config.ConsulConfig == nil {
+		cluster.Config.ConsulConfig = &consulmodels.HashicorpCloudConsul20210204ConsulConfig{}
+	}
+	if cluster.Config.ConsulConfig.Datacenter == "" {
+		cluster.Config.ConsulConfig.Datacenter = cluster.ID
+	}
+	cluster.Location = &sharedmodels.HashicorpCloudLocationLocation{
+		OrganizationID: r.PathValue("org"),
+		ProjectID:      r.PathValue("project"),
+		Region:         hvn.model.Location.Region,
+	}
+	cluster.ResourceID = uuid.NewString()
+	cluster.CreatedAt = now()
+	cluster.State = consulmodels.HashicorpCloudConsul20210204ClusterStateCREATING.Pointer()
+	cluster.DNSNames = &consulmodels.HashicorpCloudConsul20210204ClusterDNSNames{
+		Private: fmt.Sprintf("%s.private.consul.hcptest.local", cluster.ID),
+	}
+	if !cluster.Config.NetworkConfig.Private {
+		cluster.DNSNames.Public = fmt.Sprintf("%s.public.consul.hcptest.local", cluster.ID)
+	}
+	s.consulClusters[key] = &consulCluster{model: cluster}
+
+	op := s.startOperation(consulClusterLink(cluster), func() {
+		cluster.State = consulmodels.HashicorpCloudConsul20210204ClusterStateRUNNING.Pointer()
+	})
+
+	writeJSON(w, &consulmodels.HashicorpCloudConsul20210204CreateResponse{Cluster: cluster, Operation: op})
+}
+
+func (s *Server) getConsulCluster(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.consulClusterFor(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, &consulmodels.HashicorpCloudConsul20210204GetResponse{Cluster: c.model})
+}
+
+func (s *Server) updateConsulCluster(w http.ResponseWriter, r *http.Request) {
+	var in consulmodels.HashicorpCloudConsul20210204Cluster
+	if !readJSON(w, r, &in) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.consulClusterFor(w, r)
+	if !ok {
+		return
+	}
+
+	c.model.State = consulmodels.HashicorpCloudConsul20210204ClusterStateUPDATING.Pointer()
+	op := s.startOperation(consulClusterLink(c.model), func() {
+		if in.ConsulVersion != "" {
+			c.model.ConsulVersion = in.ConsulVersion
+		}
+		if in.Config != nil {
+			if in.Config.CapacityConfig != nil {
+				c.model.Config.CapacityConfig = in.Config.CapacityConfig
+			}
+			if in.Config.Tier != nil {
+				c.model.Config.Tier = in.Config.Tier
+			}
+			if in.Config.NetworkConfig != nil && in.Config.NetworkConfig.IPAllowlist != nil {
+				c.model.Config.NetworkConfig.IPAllowlist = in.Config.NetworkConfig.IPAllowlist
+			}
+		}
+		c.model.State = consulmodels.HashicorpCloudConsul20210204ClusterStateRUNNING.Pointer()
+	})
+
+	writeJSON(w, &consulmodels.HashicorpCloudConsul20210204UpdateResponse{Operation: op})
+}
+
+func (s *Server) deleteConsulCluster(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.consulClusterFor(w, r)
+	if !ok {
+		return
+	}
+
+	key := locationKey(r.PathValue("project"), r.PathValue("id"))
+	c.model.State = consulmodels.HashicorpCloudConsul20210204ClusterStateDELETING.Pointer()
+	op := s.startOperation(consulClusterLink(c.model), func() { delete(s.consulClusters, key) })
+
+	writeJSON(w, &consulmodels.HashicorpCloudConsul20210204DeleteResponse{Operation: op})
+}
+
+func (s *Server) getConsulClientConfig(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.consulClusterFor(w, r)
+	if !ok {
+		return
+	}
+
+	config, err := json.Marshal(map[string]interface{}{
+		"datacenter":              c.model.Config.ConsulConfig.Datacenter,
+		"retry_join":              []string{c.model.DNSNames.Private},
+		"encrypt":                 "aGNwdGVzdC1nb3NzaXAta2V5LTMyYnl0ZXMhISE=",
+		"encrypt_verify_incoming": true,
+		"encrypt_verify_outgoing": true,
+		"server":                  false,
+		"log_level":               "INFO",
+		"ui":                      true,
+		"ca_file":                 "./ca.pem",
+		"verify_outgoing":         true,
+		"verify_server_hostname":  true,
+		"auto_encrypt":            map[string]interface{}{"tls": true},
+		"ports":                   map[string]interface{}{"grpc_tls": 8502},
+		"acl": map[string]interface{}{
+			"enabled":        true,
+			"default_policy": "deny",
+			"down_policy":    "async-cache",
+		},
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, codes.Internal, "encoding client config: %v", err)
+		return
+	}
+
+	// Serve the test server's own certificate as the cluster CA so that the
+	// returned bundle is a well-formed PEM.
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.srv.Certificate().Raw})
+
+	writeJSON(w, &consulmodels.HashicorpCloudConsul20210204GetClientConfigResponse{
+		ConsulConfigFile: config,
+		CaFile:           ca,
+	})
+}
+
+func (s *Server) createConsulRootToken(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.consulClusterFor(w, r)
+	if !ok {
+		return
+	}
+
+	// Creating a root token revokes the previous one.
+	c.rootTokenAccessorID = uuid.NewString()
+	c.rootTokenSecretID = uuid.NewString()
+
+	writeJSON(w, &consulmodels.HashicorpCloudConsul20210204CreateCustomerMasterACLTokenResponse{
+		ACLToken: &consulmodels.HashicorpCloudConsul20210204ACLToken{
+			AccessorID: c.rootTokenAccessorID,
+			SecretID:   c.rootTokenSecretID,
+		},
+	})
+}
+
+// readConsulACLTokenSelf implements the Consul API endpoint reading the ACL
+// token of the request, which only knows the valid root tokens.
+func (s *Server) readConsulACLTokenSelf(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	secretID := r.Header.Get("X-Consul-Token")
+	for _, c := range s.consulClusters {
+		if secretID != "" && c.rootTokenSecretID == secretID {
+			writeJSON(w, map[string]string{
+				"AccessorID": c.rootTokenAccessorID,
+				"SecretID":   c.rootTokenSecretID,
+			})
+			return
+		}
+	}
+
+	http.Error(w, "ACL not found", http.StatusForbidden)
+}
+
+// ConsulRootTokenAccessorID returns the accessor ID of the valid root token of
+// the Consul cluster in the seeded project, or an empty string if none was
+// created.
+func (s *Server) ConsulRootTokenAccessorID(clusterID string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.consulClusters[locationKey(s.ProjectID, clusterID)]
+	if !ok {
+		return ""
+	}
+	return c.rootTokenAccessorID
+}
+
+func (s *Server) listConsulSnapshots(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clusterID := r.URL.Query().Get("resource.id")
+
+	resp := &consulmodels.HashicorpCloudConsul20210204ListSnapshotsResponse{}
+	for _, snap := range s.consulSnapshots {
+		if snap.model.Location.ProjectID != r.PathValue("project") {
+			continue
+		}
+		if clusterID != "" && snap.model.Resource.ID != clusterID {
+			continue
+		}
+		resp.Snapshots = append(resp.Snapshots, snap.model)
+	}
+
+	writeJSON(w, resp)
+}
+
+func (s *Server) createConsulSnapshot(w http.ResponseWriter, r *http.Request) {
+	var req consulmodels.HashicorpCloudConsul20210204CreateSnapshotRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.Resource == nil {
+		writeError(w, http.StatusBadRequest, codes.InvalidArgument, "snapshot resource is required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.consulClusters[locationKey(r.PathValue("project"), req.Resource.ID)]
+	if !ok {
+		writeNotFound(w, "consul cluster", req.Resource.ID)
+		return
+	}
+
+	snap := &consulmodels.HashicorpCloudConsul20210204Snapshot{
+		ID:        uuid.NewString(),
+		Name:      req.Name,
+		CreatedAt: now(),
+		Location:  c.model.Location,
+		Resource:  req.Resource,
+		State:     consulmodels.HashicorpCloudConsul20210204SnapshotSnapshotStateCREATING.Pointer(),
+		Type:      consulmodels.HashicorpCloudConsul20210204SnapshotSnapshotTypeMANUAL.Pointer(),
+		Meta: &consulmodels.HashicorpCloudConsul20210204SnapshotSnapshotMeta{
+			ProductVersion: c.model.ConsulVersion,
+		},
+	}
+	s.consulSnapshots[locationKey(r.PathValue("project"), snap.ID)] = &consulSnapshot{model: snap}
+
+	op := s.startOperation(consulSnapshotLink(snap), func() {
+		snap.State = consulmodels.HashicorpCloudConsul20210204SnapshotSnapshotStateREADY.Pointer()
+		snap.FinishedAt = now()
+		snap.Meta.Size = "4096"
+	})
+	snap.RunningOperationID = op.ID
+
+	writeJSON(w, &consulmodels.HashicorpCloudConsul20210204CreateSnapshotResponse{SnapshotID: snap.ID, Operation: op})
+}
+
+func (s *Server) getConsulSnapshot(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snap, ok := s.consulSnapshots[locationKey(r.PathValue("project"), r.PathValue("id"))]
+	if !ok {
+		writeNotFound(w, "snapshot", r.PathValue("id"))
+		return
+	}
+
+	writeJSON(w, &consulmodels.HashicorpCloudConsul20210204GetSnapshotResponse{Snapshot: snap.model})
+}
+
+func (s *Server) renameConsulSnapshot(w http.ResponseWriter, r *http.Request) {
+	var in consulmodels.HashicorpCloudConsul20210204Snapshot
+	if !readJSON(w, r, &in) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snap, ok := s.consulSnapshots[locationKey(r.PathValue("project"), r.PathValue("id"))]
+	if !ok {
+		writeNotFound(w, "snapshot", r.PathValue("id"))
+		return
+	}
+	snap.model.Name = in.Name
+
+	writeJSON(w, &consulmodels.HashicorpCloudConsul20210204UpdateSnapshotResponse{Snapshot: snap.model})
+}
+
+func (s *Server) deleteConsulSnapshot(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := locationKey(r.PathValue("project"), r.PathValue("id"))
+	snap, ok := s.consulSnapshots[key]
+	if !ok {
+		writeNotFound(w, "snapshot", r.PathValue("id"))
+		return
+	}
+
+	snap.model.State = consulmodels.HashicorpCloudConsul20210204SnapshotSnapshotStateDELETING.Pointer()
+	op := s.startOperation(consulSnapshotLink(snap.model), func() { delete(s.consulSnapshots, key) })
+
+	writeJSON(w, &consulmodels.HashicorpCloudConsul20210204DeleteSnapshotResponse{Operation: op})
+}
+
+func (s *Server) restoreConsulSnapshot(w http.ResponseWriter, r *http.Request) {
+	var req consulmodels.HashicorpCloudConsul20210204RestoreSnapshotRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.Snapshot == nil || req.Snapshot.Location == nil {
+		writeError(w, http.StatusBadRequest, codes.InvalidArgument, "snapshot is required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.consulClusterFor(w, r)
+	if !ok {
+		return
+	}
+
+	snap, ok := s.consulSnapshots[locationKey(req.Snapshot.Location.ProjectID, req.Snapshot.ID)]
+	if !ok {
+		writeNotFound(w, "snapshot", req.Snapshot.ID)
+		return
+	}
+	if *snap.model.State != consulmodels.HashicorpCloudConsul20210204SnapshotSnapshotStateREADY {
+		writeError(w, http.StatusBadRequest, codes.FailedPrecondition, "snapshot %q is not ready", req.Snapshot.ID)
+		return
+	}
+
+	if req.TakeSnapshot {
+		backup := &consulmodels.HashicorpCloudConsul20210204Snapshot{
+			ID:         uuid.NewString(),
+			Name:       "before-restore-" + snap.model.ID,
+			CreatedAt:  now(),
+			FinishedAt: now(),
+			Location:   c.model.Location,
+			Resource:   consulClusterLink(c.model),
+			State:      consulmodels.HashicorpCloudConsul20210204SnapshotSnapshotStateREADY.Pointer(),
+			Type:       consulmodels.HashicorpCloudConsul20210204SnapshotSnapshotTypeMANUAL.Pointer(),
+			Meta: &consulmodels.HashicorpCloudConsul20210204SnapshotSnapshotMeta{
+				ProductVersion: c.model.ConsulVersion,
+				Size:           "4096",
+			},
+		}
+		s.consulSnapshots[locationKey(c.model.Location.ProjectID, backup.ID)] = &consulSnapshot{model: backup}
+	}
+
+	snap.model.State = consulmodels.HashicorpCloudConsul20210204SnapshotSnapshotStateRESTORING.Pointer()
+	op := s.startOperation(consulClusterLink(c.model), func() {
+		snap.model.State = consulmodels.HashicorpCloudConsul20210204SnapshotSnapshotStateREADY.Pointer()
+		snap.model.Meta.RestoredAt = now()
+	})
+
+	writeJSON(w, &consulmodels.HashicorpCloudConsul20210204RestoreSnapshotResponse{Operation: op})
+}
+
+func consulClusterLink(c *consulmodels.HashicorpCloudConsul20210204Cluster) *sharedmodels.HashicorpCloudLocationLink {
+	return &sharedmodels.HashicorpCloudLocationLink{
+		ID:       c.ID,
+		Type:     "hashicorp.consul.cluster",
+		Location: c.Location,
+	}
+}
+
+func consulSnapshotLink(snap *consulmodels.HashicorpCloudConsul20210204Snapshot) *sharedmodels.HashicorpCloudLocationLink {
+	return &sharedmodels.HashicorpCloudLocationLink{
+		ID:       snap.ID,
+		Type:     "hashicorp.consul.snapshot",
+		Location: snap.Location,
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package hcptest
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	iammodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-iam/stable/2019-12-10/models"
+	"google.golang.org/grpc/codes"
+)
+
+type servicePrincipal struct {
+	model *iammodels.HashicorpCloudIamServicePrincipal
+}
+
+// callerPrincipalID is the principal ID of the service principal the fake
+// credentials authenticate as.
+const callerPrincipalID = "hcptest-caller"
+
+func (s *Server) registerIAM(mux *http.ServeMux) {
+	mux.HandleFunc("GET /iam/2019-12-10/caller-identity", s.getCallerIdentity)
+	mux.HandleFunc("GET /iam/2019-12-10/organizations/{org}/principals", s.batchGetPrincipals)
+
+	// Service principal paths embed a resource name, which is escaped into a
+	// single path segment by the generated client.
+	mux.HandleFunc("POST /2019-12-10/iam/{parent}/service-principals", s.createServicePrincipal)
+	mux.HandleFunc("GET /2019-12-10/{resource_name}", s.getServicePrincipal)
+	mux.HandleFunc("DELETE /2019-12-10/{resource_name}", s.deleteServicePrincipal)
+}
+
+func (s *Server) getCallerIdentity(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	writeJSON(w, &iammodels.HashicorpCloudIamGetCallerIdentityResponse{
+		Principal: s.callerPrincipal(),
+	})
+}
+
+func (s *Server) callerPrincipal() *iammodels.HashicorpCloudIamPrincipal {
+	return &iammodels.HashicorpCloudIamPrincipal{
+		ID:   callerPrincipalID,
+		Type: iammodels.HashicorpCloudIamPrincipalTypePRINCIPALTYPESERVICE.Pointer(),
+		Service: &iammodels.HashicorpCloudIamServicePrincipal{
+			ID:             callerPrincipalID,
+			Name:           "hcptest",
+			OrganizationID: s.OrganizationID,
+			ResourceName:   fmt.Sprintf("iam/organization/%s/service-principal/hcptest", s.OrganizationID),
+		},
+	}
+}
+
+func (s *Server) batchGetPrincipals(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resp := &iammodels.HashicorpCloudIamBatchGetPrincipalsResponse{}
+	for _, id := range r.URL.Query()["principal_ids"] {
+		if id == callerPrincipalID {
+			resp.Principals = append(resp.Principals, s.callerPrincipal())
+			continue
+		}
+
+		for _, sp := range s.servicePrincipals {
+			if sp.model.ID == id {
+				resp.Principals = append(resp.Principals, &iammodels.HashicorpCloudIamPrincipal{
+					ID:      sp.model.ID,
+					Type:    iammodels.HashicorpCloudIamPrincipalTypePRINCIPALTYPESERVICE.Pointer(),
+					Service: sp.model,
+				})
+			}
+		}
+	}
+
+	writeJSON(w, resp)
+}
+
+func (s *Server) createServicePrincipal(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Name string `json:"name"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The parent is either "organization/<id>" or "project/<id>".
+	parent := r.PathValue("parent")
+	parts := strings.Split(parent, "/")
+	if len(parts) != 2 || (parts[0] != "organization" && parts[0] != "project") {
+		writeError(w, http.StatusBadRequest, codes.InvalidArgument, "invalid parent resource name %q", parent)
+		return
+	}
+
+	sp := &iammodels.HashicorpCloudIamServicePrincipal{
+		ID:             uuid.NewString(),
+		Name:           req.Name,
+		CreatedAt:      now(),
+		OrganizationID: s.OrganizationID,
+		ResourceName:   fmt.Sprintf("iam/%s/service-principal/%s", parent, req.Name),
+	}
+	if parts[0] == "project" {
+		sp.ProjectID = parts[1]
+	}
+
+	if _, ok := s.servicePrincipals[sp.ResourceName]; ok {
+		writeAlreadyExists(w, "service principal", sp.ResourceName)
+		return
+	}
+	s.servicePrincipals[sp.ResourceName] = &servicePrincipal{model: sp}
+
+	writeJSON(w, &iammodels.HashicorpCloudIamCreateServicePrincipalResponse{ServicePrincipal: sp})
+}
+
+func (s *Server) getServicePrincipal(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sp, ok := s.servicePrincipals[r.PathValue("resource_name")]
+	if !ok {
+		writeNotFound(w, "service principal", r.PathValue("resource_name"))
+		return
+	}
+
+	writeJSON(w, &iammodels.HashicorpCloudIamGetServicePrincipalResponse{ServicePrincipal: sp.model})
+}
+
+func (s *Server) deleteServicePrincipal(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := r.PathValue("resource_name")
+	if _, ok := s.servicePrincipals[name]; !ok {
+		writeNotFound(w, "service principal", name)
+		return
+	}
+	delete(s.servicePrincipals, name)
+
+	writeJSON(w, struct{}{})
+}
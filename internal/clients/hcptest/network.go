@@ -0,0 +1,119 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package hcptest
+
+import (
+	"net/http"
+
+	networkmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/models"
+	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
+	"google.golang.org/grpc/codes"
+)
+
+type network struct {
+	model *networkmodels.HashicorpCloudNetwork20200907Network
+}
+
+// fakeAWSAccountID is reported as the provider account of every AWS HVN.
+const fakeAWSAccountID = "000000000000"
+
+func (s *Server) registerNetwork(mux *http.ServeMux) {
+	const base = "/network/2020-09-07/organizations/{org}/projects/{project}/networks"
+
+	mux.HandleFunc("GET "+base, s.listNetworks)
+	mux.HandleFunc("POST "+base, s.createNetwork)
+	mux.HandleFunc("GET "+base+"/{id}", s.getNetwork)
+	mux.HandleFunc("DELETE "+base+"/{id}", s.deleteNetwork)
+}
+
+func (s *Server) listNetworks(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resp := &networkmodels.HashicorpCloudNetwork20200907ListResponse{}
+	for _, n := range s.networks {
+		if n.model.Location.ProjectID == r.PathValue("project") {
+			resp.Networks = append(resp.Networks, n.model)
+		}
+	}
+
+	writeJSON(w, resp)
+}
+
+func (s *Server) createNetwork(w http.ResponseWriter, r *http.Request) {
+	var req networkmodels.HashicorpCloudNetwork20200907CreateRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.Network == nil || req.Network.ID == "" || req.Network.Location == nil {
+		writeError(w, http.StatusBadRequest, codes.InvalidArgument, "network id and location are required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := req.Network
+	key := locationKey(r.PathValue("project"), n.ID)
+	if _, ok := s.networks[key]; ok {
+		writeAlreadyExists(w, "network", n.ID)
+		return
+	}
+
+	n.Location.OrganizationID = r.PathValue("org")
+	n.Location.ProjectID = r.PathValue("project")
+	n.CreatedAt = now()
+	n.State = networkmodels.HashicorpCloudNetwork20200907NetworkStateCREATING.Pointer()
+	n.ProviderNetworkData = &networkmodels.HashicorpCloudNetwork20200907NetworkProviderNetworkData{}
+	if n.Location.Region != nil && n.Location.Region.Provider == "aws" {
+		n.ProviderNetworkData.AwsNetworkData = &networkmodels.HashicorpCloudNetwork20200907AWSNetworkData{
+			AccountID: fakeAWSAccountID,
+		}
+	}
+	s.networks[key] = &network{model: n}
+
+	op := s.startOperation(networkLink(n), func() {
+		n.State = networkmodels.HashicorpCloudNetwork20200907NetworkStateSTABLE.Pointer()
+	})
+
+	writeJSON(w, &networkmodels.HashicorpCloudNetwork20200907CreateResponse{Network: n, Operation: op})
+}
+
+func (s *Server) getNetwork(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n, ok := s.networks[locationKey(r.PathValue("project"), r.PathValue("id"))]
+	if !ok {
+		writeNotFound(w, "network", r.PathValue("id"))
+		return
+	}
+
+	writeJSON(w, &networkmodels.HashicorpCloudNetwork20200907GetResponse{Network: n.model})
+}
+
+func (s *Server) deleteNetwork(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := locationKey(r.PathValue("project"), r.PathValue("id"))
+	n, ok := s.networks[key]
+	if !ok {
+		writeNotFound(w, "network", r.PathValue("id"))
+		return
+	}
+
+	n.model.State = networkmodels.HashicorpCloudNetwork20200907NetworkStateDELETING.Pointer()
+	op := s.startOperation(networkLink(n.model), func() { delete(s.networks, key) })
+
+	writeJSON(w, &networkmodels.HashicorpCloudNetwork20200907DeleteResponse{Operation: op})
+}
+
+func networkLink(n *networkmodels.HashicorpCloudNetwork20200907Network) *sharedmodels.HashicorpCloudLocationLink {
+	return &sharedmodels.HashicorpCloudLocationLink{
+		ID:       n.ID,
+		Type:     "hashicorp.network.hvn",
+		Location: n.Location,
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package hcptest
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	operationmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-operation/stable/2020-05-05/models"
+	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
+	"google.golang.org/grpc/codes"
+)
+
+// operation is a long-running operation tracked by the fake server.
+type operation struct {
+	model *sharedmodels.HashicorpCloudOperationOperation
+
+	// pendingPolls is the number of wait calls left before the operation
+	// completes.
+	pendingPolls int
+
+	// onDone is applied to the server state when the operation completes
+	// successfully.
+	onDone func()
+
+	// failure, if set, is reported as the operation error on completion
+	// instead of applying onDone.
+	failure string
+}
+
+// FailNextOperation causes the next operation started by the server to
+// complete with the given error message instead of applying its change.
+func (s *Server) FailNextOperation(message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextOperationFailure = message
+}
+
+// startOperation registers a new operation against the given resource link.
+// onDone is applied to the server state once the operation completes. It must
+// be called with s.mu held.
+func (s *Server) startOperation(link *sharedmodels.HashicorpCloudLocationLink, onDone func()) *sharedmodels.HashicorpCloudOperationOperation {
+	state := sharedmodels.HashicorpCloudOperationOperationStateRUNNING
+	op := &operation{
+		model: &sharedmodels.HashicorpCloudOperationOperation{
+			ID:        uuid.NewString(),
+			CreatedAt: now(),
+			UpdatedAt: now(),
+			Link:      link,
+			Location:  link.Location,
+			State:     &state,
+		},
+		pendingPolls: s.PendingPolls,
+		onDone:       onDone,
+		failure:      s.nextOperationFailure,
+	}
+	s.nextOperationFailure = ""
+	s.operations[op.model.ID] = op
+
+	return op.model
+}
+
+// poll advances the operation by one wait call, completing it when no
+// pending polls are left. It must be called with s.mu held.
+func (op *operation) poll() {
+	if *op.model.State == sharedmodels.HashicorpCloudOperationOperationStateDONE {
+		return
+	}
+
+	if op.pendingPolls > 0 {
+		op.pendingPolls--
+		return
+	}
+
+	done := sharedmodels.HashicorpCloudOperationOperationStateDONE
+	op.model.State = &done
+	op.model.UpdatedAt = now()
+
+	if op.failure != "" {
+		op.model.Error = &sharedmodels.GoogleRPCStatus{
+			Code:    int32(codes.Internal),
+			Message: op.failure,
+		}
+		return
+	}
+
+	if op.onDone != nil {
+		op.onDone()
+	}
+}
+
+// CompleteOperations finishes every outstanding operation, regardless of
+// PendingPolls.
+func (s *Server) CompleteOperations() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, op := range s.operations {
+		op.pendingPolls = 0
+		op.poll()
+	}
+}
+
+func (s *Server) registerOperation(mux *http.ServeMux) {
+	const base = "/operation/2020-05-05/organizations/{org}/projects/{project}/operations"
+
+	mux.HandleFunc("GET "+base, s.listOperations)
+	mux.HandleFunc("GET "+base+"/{id}", s.getOperation)
+	mux.HandleFunc("GET "+base+"/{id}/wait", s.waitOperation)
+}
+
+func (s *Server) listOperations(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	linkType := r.URL.Query().Get("linked_resource.type")
+	linkID := r.URL.Query().Get("linked_resource.id")
+
+	resp := &operationmodels.HashicorpCloudOperationListResponse{}
+	for _, op := range s.operations {
+		if op.model.Location == nil || op.model.Location.ProjectID != r.PathValue("project") {
+			continue
+		}
+		if (linkType != "" && op.model.Link.Type != linkType) || (linkID != "" && op.model.Link.ID != linkID) {
+			continue
+		}
+		resp.Operations = append(resp.Operations, op.model)
+	}
+
+	writeJSON(w, resp)
+}
+
+func (s *Server) getOperation(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	op, ok := s.operations[r.PathValue("id")]
+	if !ok {
+		writeNotFound(w, "operation", r.PathValue("id"))
+		return
+	}
+
+	writeJSON(w, &operationmodels.HashicorpCloudOperationGetResponse{Operation: op.model})
+}
+
+func (s *Server) waitOperation(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	op, ok := s.operations[r.PathValue("id")]
+	if !ok {
+		writeNotFound(w, "operation", r.PathValue("id"))
+		return
+	}
+
+	op.poll()
+	writeJSON(w, &operationmodels.HashicorpCloudOperationWaitResponse{Operation: op.model})
+}
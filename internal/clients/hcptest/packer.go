@@ -0,0 +1,132 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package hcptest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/google/uuid"
+	packermodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-packer-service/stable/2023-01-01/models"
+	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
+	"google.golang.org/grpc/codes"
+)
+
+type packerBucket struct {
+	model *packermodels.HashicorpCloudPacker20230101Bucket
+}
+
+func (s *Server) registerPacker(mux *http.ServeMux) {
+	const base = "/packer/2023-01-01/organizations/{org}/projects/{project}/buckets"
+
+	mux.HandleFunc("GET "+base, s.listPackerBuckets)
+	mux.HandleFunc("PUT "+base, s.createPackerBucket)
+	mux.HandleFunc("GET "+base+"/{name}", s.getPackerBucket)
+	mux.HandleFunc("PATCH "+base+"/{name}", s.updatePackerBucket)
+	mux.HandleFunc("DELETE "+base+"/{name}", s.deletePackerBucket)
+}
+
+func (s *Server) listPackerBuckets(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resp := &packermodels.HashicorpCloudPacker20230101ListBucketsResponse{}
+	for _, b := range s.packerBuckets {
+		if b.model.Location.ProjectID == r.PathValue("project") {
+			resp.Buckets = append(resp.Buckets, b.model)
+		}
+	}
+	sort.Slice(resp.Buckets, func(i, j int) bool { return resp.Buckets[i].Name < resp.Buckets[j].Name })
+
+	writeJSON(w, resp)
+}
+
+func (s *Server) createPackerBucket(w http.ResponseWriter, r *http.Request) {
+	var req packermodels.HashicorpCloudPacker20230101CreateBucketBody
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.Name == "" {
+		writeError(w, http.StatusBadRequest, codes.InvalidArgument, "bucket name is required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := locationKey(r.PathValue("project"), req.Name)
+	if _, ok := s.packerBuckets[key]; ok {
+		writeAlreadyExists(w, "bucket", req.Name)
+		return
+	}
+
+	b := &packermodels.HashicorpCloudPacker20230101Bucket{
+		ID:          uuid.NewString(),
+		Name:        req.Name,
+		Description: req.Description,
+		Labels:      req.Labels,
+		CreatedAt:   now(),
+		UpdatedAt:   now(),
+		Platforms:   []string{},
+		Location: &sharedmodels.HashicorpCloudLocationLocation{
+			OrganizationID: r.PathValue("org"),
+			ProjectID:      r.PathValue("project"),
+		},
+		ResourceName: fmt.Sprintf("packer/project/%s/bucket/%s", r.PathValue("project"), req.Name),
+		VersionCount: "0",
+	}
+	s.packerBuckets[key] = &packerBucket{model: b}
+
+	writeJSON(w, &packermodels.HashicorpCloudPacker20230101CreateBucketResponse{Bucket: b})
+}
+
+func (s *Server) getPackerBucket(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.packerBuckets[locationKey(r.PathValue("project"), r.PathValue("name"))]
+	if !ok {
+		writeNotFound(w, "bucket", r.PathValue("name"))
+		return
+	}
+
+	writeJSON(w, &packermodels.HashicorpCloudPacker20230101GetBucketResponse{Bucket: b.model})
+}
+
+func (s *Server) updatePackerBucket(w http.ResponseWriter, r *http.Request) {
+	var req packermodels.HashicorpCloudPacker20230101UpdateBucketBody
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.packerBuckets[locationKey(r.PathValue("project"), r.PathValue("name"))]
+	if !ok {
+		writeNotFound(w, "bucket", r.PathValue("name"))
+		return
+	}
+
+	b.model.Description = req.Description
+	b.model.Labels = req.Labels
+	b.model.UpdatedAt = now()
+
+	writeJSON(w, &packermodels.HashicorpCloudPacker20230101UpdateBucketResponse{Bucket: b.model})
+}
+
+func (s *Server) deletePackerBucket(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := locationKey(r.PathValue("project"), r.PathValue("name"))
+	if _, ok := s.packerBuckets[key]; !ok {
+		writeNotFound(w, "bucket", r.PathValue("name"))
+		return
+	}
+	delete(s.packerBuckets, key)
+
+	writeJSON(w, struct{}{})
+}
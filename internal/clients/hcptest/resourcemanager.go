@@ -0,0 +1,227 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package hcptest
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/google/uuid"
+	resourcemodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-resource-manager/stable/2019-12-10/models"
+	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
+	"google.golang.org/grpc/codes"
+)
+
+type organization struct {
+	model *resourcemodels.HashicorpCloudResourcemanagerOrganization
+}
+
+type project struct {
+	model *resourcemodels.HashicorpCloudResourcemanagerProject
+}
+
+// seed creates the default organization and project.
+func (s *Server) seed() {
+	orgState := resourcemodels.HashicorpCloudResourcemanagerOrganizationOrganizationStateACTIVE
+	s.organizations[s.OrganizationID] = &organization{
+		model: &resourcemodels.HashicorpCloudResourcemanagerOrganization{
+			ID:        s.OrganizationID,
+			Name:      "hcptest-organization",
+			CreatedAt: now(),
+			State:     &orgState,
+		},
+	}
+
+	// Backdate the seeded project so that it is always selected as the
+	// oldest project when the provider falls back to the credentials' default.
+	s.addProject(s.ProjectID, "hcptest-project", "", strfmt.DateTime(time.Now().Add(-time.Hour).UTC()))
+}
+
+// AddProject creates an additional project in the seeded organization and
+// returns its ID.
+func (s *Server) AddProject(name string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := uuid.NewString()
+	s.addProject(id, name, "", now())
+	return id
+}
+
+func (s *Server) addProject(id, name, description string, createdAt strfmt.DateTime) *project {
+	state := resourcemodels.HashicorpCloudResourcemanagerProjectProjectStateACTIVE
+	p := &project{
+		model: &resourcemodels.HashicorpCloudResourcemanagerProject{
+			ID:          id,
+			Name:        name,
+			Description: description,
+			CreatedAt:   createdAt,
+			State:       &state,
+			Parent: &resourcemodels.HashicorpCloudResourcemanagerResourceID{
+				ID:   s.OrganizationID,
+				Type: resourcemodels.HashicorpCloudResourcemanagerResourceIDResourceTypeORGANIZATION.Pointer(),
+			},
+		},
+	}
+	s.projects[id] = p
+	return p
+}
+
+func (s *Server) registerResourceManager(mux *http.ServeMux) {
+	const base = "/resource-manager/2019-12-10"
+
+	mux.HandleFunc("GET "+base+"/organizations", s.listOrganizations)
+	mux.HandleFunc("GET "+base+"/organizations/{id}", s.getOrganization)
+
+	mux.HandleFunc("GET "+base+"/projects", s.listProjects)
+	mux.HandleFunc("POST "+base+"/projects", s.createProject)
+	mux.HandleFunc("GET "+base+"/projects/{id}", s.getProject)
+	mux.HandleFunc("DELETE "+base+"/projects/{id}", s.deleteProject)
+	mux.HandleFunc("PUT "+base+"/projects/{id}/name", s.setProjectName)
+	mux.HandleFunc("PUT "+base+"/projects/{id}/description", s.setProjectDescription)
+}
+
+func (s *Server) listOrganizations(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resp := &resourcemodels.HashicorpCloudResourcemanagerOrganizationListResponse{}
+	for _, org := range s.organizations {
+		resp.Organizations = append(resp.Organizations, org.model)
+	}
+
+	writeJSON(w, resp)
+}
+
+func (s *Server) getOrganization(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	org, ok := s.organizations[r.PathValue("id")]
+	if !ok {
+		writeNotFound(w, "organization", r.PathValue("id"))
+		return
+	}
+
+	writeJSON(w, &resourcemodels.HashicorpCloudResourcemanagerOrganizationGetResponse{Organization: org.model})
+}
+
+func (s *Server) listProjects(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	scopeID := r.URL.Query().Get("scope.id")
+
+	resp := &resourcemodels.HashicorpCloudResourcemanagerProjectListResponse{}
+	for _, p := range s.projects {
+		if scopeID != "" && p.model.Parent.ID != scopeID {
+			continue
+		}
+		resp.Projects = append(resp.Projects, p.model)
+	}
+	sort.Slice(resp.Projects, func(i, j int) bool {
+		return time.Time(resp.Projects[i].CreatedAt).Before(time.Time(resp.Projects[j].CreatedAt))
+	})
+
+	writeJSON(w, resp)
+}
+
+func (s *Server) createProject(w http.ResponseWriter, r *http.Request) {
+	var req resourcemodels.HashicorpCloudResourcemanagerProjectCreateRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if req.Parent == nil || s.organizations[req.Parent.ID] == nil {
+		writeError(w, http.StatusBadRequest, codes.InvalidArgument, "project parent must be an existing organization")
+		return
+	}
+
+	p := s.addProject(uuid.NewString(), req.Name, req.Description, now())
+	writeJSON(w, &resourcemodels.HashicorpCloudResourcemanagerProjectCreateResponse{Project: p.model})
+}
+
+func (s *Server) getProject(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.projects[r.PathValue("id")]
+	if !ok {
+		writeNotFound(w, "project", r.PathValue("id"))
+		return
+	}
+
+	writeJSON(w, &resourcemodels.HashicorpCloudResourcemanagerProjectGetResponse{Project: p.model})
+}
+
+func (s *Server) deleteProject(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	p, ok := s.projects[id]
+	if !ok {
+		writeNotFound(w, "project", id)
+		return
+	}
+
+	link := &sharedmodels.HashicorpCloudLocationLink{
+		ID:   id,
+		Type: "hashicorp.resource-manager.project",
+		Location: &sharedmodels.HashicorpCloudLocationLocation{
+			OrganizationID: p.model.Parent.ID,
+			ProjectID:      id,
+		},
+	}
+	op := s.startOperation(link, func() { delete(s.projects, id) })
+
+	writeJSON(w, &resourcemodels.HashicorpCloudResourcemanagerProjectDeleteResponse{Operation: op})
+}
+
+func (s *Server) setProjectName(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Name string `json:"name"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.projects[r.PathValue("id")]
+	if !ok {
+		writeNotFound(w, "project", r.PathValue("id"))
+		return
+	}
+
+	p.model.Name = req.Name
+	writeJSON(w, struct{}{})
+}
+
+func (s *Server) setProjectDescription(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Description string `json:"description"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.projects[r.PathValue("id")]
+	if !ok {
+		writeNotFound(w, "project", r.PathValue("id"))
+		return
+	}
+
+	p.model.Description = req.Description
+	writeJSON(w, struct{}{})
+}
@@ -0,0 +1,289 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package hcptest provides an in-process fake of the HCP control plane that
+// can be used to exercise the provider without network access or real HCP
+// credentials.
+//
+// The server implements the subset of the resource-manager, IAM, network,
+// vault, consul, packer, vault-secrets and operation APIs that the clients
+// package calls. State is kept in memory for the lifetime of the server, and
+// every long-running call returns an operation that can be waited on through
+// clients.WaitForOperation.
+//
+// A typical acceptance-style test looks like:
+//
+//	srv := hcptest.NewServer(t)
+//	srv.Setenv(t)
+//
+//	resource.Test(t, resource.TestCase{
+//		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+//		Steps:                    []resource.TestStep{...},
+//	})
+package hcptest
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/google/uuid"
+	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
+	"google.golang.org/grpc/codes"
+
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+)
+
+const (
+	// ClientID is the client ID the fake auth server accepts.
+	ClientID = "hcptest-client-id"
+
+	// ClientSecret is the client secret the fake auth server accepts.
+	ClientSecret = "hcptest-client-secret"
+
+	// accessToken is the bearer token issued by the fake auth server.
+	accessToken = "hcptest-access-token"
+)
+
+// Server is an in-process fake of the HCP APIs.
+type Server struct {
+	// URL is the base URL of the server, including the https scheme.
+	URL string
+
+	// OrganizationID and ProjectID identify the organization and project that
+	// are seeded when the server starts.
+	OrganizationID string
+	ProjectID      string
+
+	// PendingPolls is the number of operation wait calls an operation
+	// reports as RUNNING before it transitions to DONE. The zero value
+	// completes operations on their first poll.
+	PendingPolls int
+
+	// VaultPluginVersion is the version Vault plugins are registered at when
+	// they are added to a cluster.
+	VaultPluginVersion string
+
+	srv *httptest.Server
+
+	mu                 sync.Mutex
+	organizations      map[string]*organization
+	projects           map[string]*project
+	operations         map[string]*operation
+	servicePrincipals  map[string]*servicePrincipal
+	networks           map[string]*network
+	vaultClusters      map[string]*vaultCluster
+	vaultSnapshots     map[string]*vaultSnapshot
+	consulClusters     map[string]*consulCluster
+	consulSnapshots    map[string]*consulSnapshot
+	packerBuckets      map[string]*packerBucket
+	vaultSecretsApps   map[string]*vaultSecretsApp
+	vaultSecretsValues map[string]*vaultSecretsSecret
+
+	nextOperationFailure string
+}
+
+// NewServer starts a fake HCP server that is shut down when the test
+// completes. The server is seeded with a single organization containing a
+// single project.
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+
+	s := &Server{
+		OrganizationID:     uuid.NewString(),
+		ProjectID:          uuid.NewString(),
+		VaultPluginVersion: DefaultVaultPluginVersion,
+		organizations:      map[string]*organization{},
+		projects:           map[string]*project{},
+		operations:         map[string]*operation{},
+		servicePrincipals:  map[string]*servicePrincipal{},
+		networks:           map[string]*network{},
+		vaultClusters:      map[string]*vaultCluster{},
+		vaultSnapshots:     map[string]*vaultSnapshot{},
+		consulClusters:     map[string]*consulCluster{},
+		consulSnapshots:    map[string]*consulSnapshot{},
+		packerBuckets:      map[string]*packerBucket{},
+		vaultSecretsApps:   map[string]*vaultSecretsApp{},
+		vaultSecretsValues: map[string]*vaultSecretsSecret{},
+	}
+	s.seed()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /oauth2/token", s.handleToken)
+	s.registerResourceManager(mux)
+	s.registerIAM(mux)
+	s.registerOperation(mux)
+	s.registerNetwork(mux)
+	s.registerVault(mux)
+	s.registerConsul(mux)
+	s.registerPacker(mux)
+	s.registerVaultSecrets(mux)
+
+	s.srv = httptest.NewTLSServer(s.authenticate(mux))
+	s.URL = s.srv.URL
+	t.Cleanup(s.srv.Close)
+
+	return s
+}
+
+// Address returns the host and port of the server, without a scheme, in the
+// form expected by HCP_API_ADDRESS.
+func (s *Server) Address() string {
+	return strings.TrimPrefix(s.URL, "https://")
+}
+
+// Certificate returns the self-signed certificate the server uses for TLS.
+func (s *Server) Certificate() *x509.Certificate {
+	return s.srv.Certificate()
+}
+
+// Setenv points the HCP SDK at the fake server for the duration of the test by
+// setting the environment variables read by hcpConfig.FromEnv. HOME is moved
+// to a temporary directory so the SDK token cache does not leak between tests
+// or into the developer's real HCP configuration.
+func (s *Server) Setenv(t *testing.T) {
+	t.Helper()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("HCP_API_ADDRESS", s.Address())
+	t.Setenv("HCP_API_TLS", "insecure")
+	t.Setenv("HCP_AUTH_URL", s.URL)
+	t.Setenv("HCP_AUTH_TLS", "insecure")
+	t.Setenv("HCP_CLIENT_ID", ClientID)
+	t.Setenv("HCP_CLIENT_SECRET", ClientSecret)
+	t.Setenv("HCP_PROJECT_ID", s.ProjectID)
+}
+
+// ClientConfig returns a client configuration targeting the seeded project.
+// Setenv must have been called for clients.NewClient to reach the server.
+func (s *Server) ClientConfig() clients.ClientConfig {
+	return clients.ClientConfig{
+		ClientID:         ClientID,
+		ClientSecret:     ClientSecret,
+		OrganizationID:   s.OrganizationID,
+		ProjectID:        s.ProjectID,
+		SourceChannel:    "terraform-provider-hcp/hcptest",
+		ConsulHTTPClient: s.ConsulHTTPClient(),
+	}
+}
+
+// ConsulHTTPClient returns an HTTP client that sends requests for the Consul
+// API of any cluster to the server.
+func (s *Server) ConsulHTTPClient() *http.Client {
+	client := s.srv.Client()
+	transport := client.Transport.(*http.Transport).Clone()
+
+	// The cluster addresses do not resolve, so every connection is made to
+	// the server, and verified against the name in its certificate.
+	addr := s.srv.Listener.Addr().String()
+	transport.DialContext = func(ctx context.Context, network, _ string) (net.Conn, error) {
+		return (&net.Dialer{}).DialContext(ctx, network, addr)
+	}
+	transport.TLSClientConfig.ServerName = "example.com"
+
+	client.Transport = transport
+	return client
+}
+
+// Location returns the shared location of the seeded project.
+func (s *Server) Location() *sharedmodels.HashicorpCloudLocationLocation {
+	return &sharedmodels.HashicorpCloudLocationLocation{
+		OrganizationID: s.OrganizationID,
+		ProjectID:      s.ProjectID,
+	}
+}
+
+// handleToken implements the OAuth2 client credentials grant for the fixed
+// ClientID and ClientSecret.
+func (s *Server) handleToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	id, secret, ok := r.BasicAuth()
+	if !ok {
+		id, secret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if id != ClientID || secret != ClientSecret {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"access_token": accessToken,
+		"token_type":   "Bearer",
+		"expires_in":   int((time.Hour).Seconds()),
+	})
+}
+
+// authenticate rejects API requests that do not carry the issued token.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The token endpoint and the Consul API do not take an HCP token.
+		public := r.URL.Path == "/oauth2/token" || strings.HasPrefix(r.URL.Path, "/v1/")
+		if !public && r.Header.Get("Authorization") != "Bearer "+accessToken {
+			writeError(w, http.StatusUnauthorized, codes.Unauthenticated, "missing or invalid bearer token")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// writeJSON writes v as the JSON body of a 200 response.
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes a google.rpc.Status body, which is the error shape every
+// generated HCP client decodes into its Default response.
+func writeError(w http.ResponseWriter, status int, code codes.Code, format string, args ...interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(&sharedmodels.GoogleRPCStatus{
+		Code:    int32(code),
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// writeNotFound writes a 404 for the named kind of object.
+func writeNotFound(w http.ResponseWriter, kind, id string) {
+	writeError(w, http.StatusNotFound, codes.NotFound, "%s %q not found", kind, id)
+}
+
+// writeAlreadyExists writes a 409 for the named kind of object.
+func writeAlreadyExists(w http.ResponseWriter, kind, id string) {
+	writeError(w, http.StatusConflict, codes.AlreadyExists, "%s %q already exists", kind, id)
+}
+
+// readJSON decodes the request body into v, writing a 400 on failure.
+func readJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, codes.InvalidArgument, "invalid request body: %v", err)
+		return false
+	}
+	return true
+}
+
+// locationKey scopes an identifier to the project it belongs to.
+func locationKey(projectID string, ids ...string) string {
+	return strings.Join(append([]string{projectID}, ids...), "/")
+}
+
+// now returns the current time in the format used by the generated models.
+func now() strfmt.DateTime {
+	return strfmt.DateTime(time.Now().UTC())
+}
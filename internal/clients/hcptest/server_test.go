@@ -0,0 +1,157 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package hcptest_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/client/network_service"
+	networkmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/models"
+	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
+	vaultmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-vault-service/stable/2020-11-25/models"
+
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients/hcptest"
+)
+
+func newClient(t *testing.T) (*hcptest.Server, *clients.Client) {
+	t.Helper()
+
+	srv := hcptest.NewServer(t)
+	srv.Setenv(t)
+
+	client, err := clients.NewClient(srv.ClientConfig())
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	return srv, client
+}
+
+func createHVN(t *testing.T, srv *hcptest.Server, client *clients.Client, id string) {
+	t.Helper()
+
+	loc := srv.Location()
+	params := network_service.NewCreateParams()
+	params.NetworkLocationOrganizationID = loc.OrganizationID
+	params.NetworkLocationProjectID = loc.ProjectID
+	params.Body = &networkmodels.HashicorpCloudNetwork20200907CreateRequest{
+		Network: &networkmodels.HashicorpCloudNetwork20200907Network{
+			ID:        id,
+			CidrBlock: "172.25.16.0/20",
+			Location: &sharedmodels.HashicorpCloudLocationLocation{
+				OrganizationID: loc.OrganizationID,
+				ProjectID:      loc.ProjectID,
+				Region:         &sharedmodels.HashicorpCloudLocationRegion{Provider: "aws", Region: "us-west-2"},
+			},
+		},
+	}
+
+	resp, err := client.Network.Create(params, nil)
+	if err != nil {
+		t.Fatalf("unexpected error creating HVN: %v", err)
+	}
+
+	if err := clients.WaitForOperation(context.Background(), client, "create HVN", loc, resp.Payload.Operation.ID); err != nil {
+		t.Fatalf("unexpected error waiting for HVN: %v", err)
+	}
+}
+
+func TestServer_project(t *testing.T) {
+	srv, client := newClient(t)
+	ctx := context.Background()
+
+	project, err := clients.GetProjectByID(ctx, client, srv.ProjectID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if project.Parent.ID != srv.OrganizationID {
+		t.Errorf("expected project parent %q, got %q", srv.OrganizationID, project.Parent.ID)
+	}
+
+	_, err = clients.GetProjectByID(ctx, client, "does-not-exist")
+	if !clients.IsResponseCodeNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestServer_vaultCluster(t *testing.T) {
+	srv, client := newClient(t)
+	ctx := context.Background()
+	loc := srv.Location()
+
+	createHVN(t, srv, client, "test-hvn")
+
+	hvn, err := clients.GetHvnByID(ctx, client, loc, "test-hvn")
+	if err != nil {
+		t.Fatalf("unexpected error reading HVN: %v", err)
+	}
+	if *hvn.State != networkmodels.HashicorpCloudNetwork20200907NetworkStateSTABLE {
+		t.Errorf("expected HVN to be STABLE, got %s", *hvn.State)
+	}
+
+	resp, err := clients.CreateVaultCluster(ctx, client, loc, &vaultmodels.HashicorpCloudVault20201125InputCluster{
+		ID: "test-vault",
+		Config: &vaultmodels.HashicorpCloudVault20201125InputClusterConfig{
+			Tier: vaultmodels.HashicorpCloudVault20201125TierDEV.Pointer(),
+			NetworkConfig: &vaultmodels.HashicorpCloudVault20201125InputNetworkConfig{
+				NetworkID: "test-hvn",
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating vault cluster: %v", err)
+	}
+
+	if err := clients.WaitForOperation(ctx, client, "create Vault cluster", loc, resp.Operation.ID); err != nil {
+		t.Fatalf("unexpected error waiting for vault cluster: %v", err)
+	}
+
+	cluster, err := clients.GetVaultClusterByID(ctx, client, loc, "test-vault")
+	if err != nil {
+		t.Fatalf("unexpected error reading vault cluster: %v", err)
+	}
+	if *cluster.State != vaultmodels.HashicorpCloudVault20201125ClusterStateRUNNING {
+		t.Errorf("expected vault cluster to be RUNNING, got %s", *cluster.State)
+	}
+	if cluster.Location.Region.Region != "us-west-2" {
+		t.Errorf("expected vault cluster region to follow the HVN, got %q", cluster.Location.Region.Region)
+	}
+
+	_, err = clients.GetVaultClusterByID(ctx, client, loc, "does-not-exist")
+	if !clients.IsResponseCodeNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestServer_failedOperation(t *testing.T) {
+	srv, client := newClient(t)
+	ctx := context.Background()
+	loc := srv.Location()
+
+	createHVN(t, srv, client, "test-hvn")
+
+	srv.FailNextOperation("injected failure")
+
+	params := network_service.NewDeleteParams()
+	params.LocationOrganizationID = loc.OrganizationID
+	params.LocationProjectID = loc.ProjectID
+	params.ID = "test-hvn"
+	deleteResp, err := client.Network.Delete(params, nil)
+	if err != nil {
+		t.Fatalf("unexpected error deleting HVN: %v", err)
+	}
+
+	err = clients.WaitForOperation(ctx, client, "delete HVN", loc, deleteResp.Payload.Operation.ID)
+	if err == nil || !strings.Contains(err.Error(), "injected failure") {
+		t.Fatalf("expected injected operation failure, got %v", err)
+	}
+
+	// A failed operation must not apply its change.
+	if _, err := clients.GetHvnByID(ctx, client, loc, "test-hvn"); err != nil {
+		t.Errorf("expected HVN to survive failed delete, got %v", err)
+	}
+}
@@ -0,0 +1,783 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package hcptest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-version"
+	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
+	vaultmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-vault-service/stable/2020-11-25/models"
+	"google.golang.org/grpc/codes"
+)
+
+// DefaultVaultVersion is the version new Vault clusters run when the create
+// request does not specify one.
+const DefaultVaultVersion = "v1.15.4"
+
+// DefaultVaultPluginVersion is the version Vault plugins are registered at
+// unless Server.VaultPluginVersion is changed.
+const DefaultVaultPluginVersion = "v0.13.0"
+
+type vaultCluster struct {
+	model   *vaultmodels.HashicorpCloudVault20201125Cluster
+	plugins map[string]*vaultmodels.HashicorpCloudVault20201125PluginRegistrationStatus
+}
+
+type vaultSnapshot struct {
+	model *vaultmodels.HashicorpCloudVault20201125Snapshot
+}
+
+func (s *Server) registerVault(mux *http.ServeMux) {
+	const base = "/vault/2020-11-25/organizations/{org}/projects/{project}/clusters"
+
+	mux.HandleFunc("GET "+base, s.listVaultClusters)
+	mux.HandleFunc("POST "+base, s.createVaultCluster)
+	mux.HandleFunc("GET "+base+"/{id}", s.getVaultCluster)
+	mux.HandleFunc("PATCH "+base+"/{id}", s.updateVaultCluster)
+	mux.HandleFunc("DELETE "+base+"/{id}", s.deleteVaultCluster)
+	mux.HandleFunc("GET "+base+"/{id}/admintoken", s.getVaultAdminToken)
+	mux.HandleFunc("POST "+base+"/{id}/public-ips", s.updateVaultPublicIps)
+	mux.HandleFunc("POST "+base+"/{id}/lock", s.lockVaultCluster)
+	mux.HandleFunc("POST "+base+"/{id}/unlock", s.unlockVaultCluster)
+	mux.HandleFunc("POST "+base+"/{id}/restore", s.restoreVaultSnapshot)
+	mux.HandleFunc("POST "+base+"/{id}/{version}", s.updateVaultVersion)
+	mux.HandleFunc("POST "+base+"/{id}/major-version-upgrade-config/update", s.updateVaultMajorVersionUpgradeConfig)
+	mux.HandleFunc("POST "+base+"/{id}/paths-filter/update", s.updateVaultPathsFilter)
+	mux.HandleFunc("DELETE "+base+"/{id}/paths-filter/delete", s.deleteVaultPathsFilter)
+	mux.HandleFunc("GET "+base+"/{id}/replication-status", s.getVaultReplicationStatus)
+	mux.HandleFunc("GET "+base+"/{id}/list-performance-replication-secondaries", s.listVaultReplicationSecondaries)
+	mux.HandleFunc("GET "+base+"/{id}/plugin/registration-status", s.listVaultPlugins)
+	mux.HandleFunc("POST "+base+"/{id}/plugin/add", s.addVaultPlugin)
+	mux.HandleFunc("POST "+base+"/{id}/plugin/delete", s.deleteVaultPlugin)
+
+	const snapshots = "/vault/2020-11-25/organizations/{org}/projects/{project}/snapshots"
+
+	mux.HandleFunc("GET "+snapshots, s.listVaultSnapshots)
+	mux.HandleFunc("POST "+snapshots, s.createVaultSnapshot)
+	mux.HandleFunc("GET "+snapshots+"/{id}", s.getVaultSnapshot)
+	mux.HandleFunc("DELETE "+snapshots+"/{id}", s.deleteVaultSnapshot)
+}
+
+// vaultClusterFor looks up the cluster addressed by the request, writing a 404
+// if it does not exist. It must be called with s.mu held.
+func (s *Server) vaultClusterFor(w http.ResponseWriter, r *http.Request) (*vaultCluster, bool) {
+	c, ok := s.vaultClusters[locationKey(r.PathValue("project"), r.PathValue("id"))]
+	if !ok {
+		writeNotFound(w, "vault cluster", r.PathValue("id"))
+	}
+	return c, ok
+}
+
+func (s *Server) listVaultClusters(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resp := &vaultmodels.HashicorpCloudVault20201125ListResponse{}
+	for _, c := range s.vaultClusters {
+		if c.model.Location.ProjectID == r.PathValue("project") {
+			resp.Clusters = append(resp.Clusters, c.model)
+		}
+	}
+
+	writeJSON(w, resp)
+}
+
+func (s *Server) createVaultCluster(w http.ResponseWriter, r *http.Request) {
+	var req vaultmodels.HashicorpCloudVault20201125CreateRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	in := req.Cluster
+	if in == nil || in.ID == "" || in.Config == nil || in.Config.NetworkConfig == nil {
+		writeError(w, http.StatusBadRequest, codes.InvalidArgument, "cluster id and network config are required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := locationKey(r.PathValue("project"), in.ID)
+	if _, ok := s.vaultClusters[key]; ok {
+		writeAlreadyExists(w, "vault cluster", in.ID)
+		return
+	}
+
+	hvn, ok := s.networks[locationKey(r.PathValue("project"), in.Config.NetworkConfig.NetworkID)]
+	if !ok {
+		writeNotFound(w, "network", in.Config.NetworkConfig.NetworkID)
+		return
+	}
+
+	version := DefaultVaultVersion
+	vaultConfig := &vaultmodels.HashicorpCloudVault20201125VaultConfig{Namespace: "admin"}
+	if in.Config.VaultConfig != nil && in.Config.VaultConfig.InitialVersion != "" {
+		version = in.Config.VaultConfig.InitialVersion
+	}
+	vaultConfig.InitialVersion = version
+
+	tier := vaultmodels.HashicorpCloudVault20201125TierDEV.Pointer()
+	if in.Config.Tier != nil {
+		tier = in.Config.Tier
+	}
+
+	upgradeType := vaultmodels.HashicorpCloudVault20201125MajorVersionUpgradeConfigUpgradeTypeAUTOMATIC
+	cluster := &vaultmodels.HashicorpCloudVault20201125Cluster{
+		ID:             in.ID,
+		ResourceID:     uuid.NewString(),
+		CreatedAt:      now(),
+		CurrentVersion: version,
+		State:          vaultmodels.HashicorpCloudVault20201125ClusterStateCREATING.Pointer(),
+		Location: &vaultmodels.HashicorpCloudInternalLocationLocation{
+			OrganizationID: r.PathValue("org"),
+			ProjectID:      r.PathValue("project"),
+			Region: &vaultmodels.HashicorpCloudInternalLocationRegion{
+				Provider: hvn.model.Location.Region.Provider,
+				Region:   hvn.model.Location.Region.Region,
+			},
+		},
+		Config: &vaultmodels.HashicorpCloudVault20201125ClusterConfig{
+			Tier:        tier,
+			VaultConfig: vaultConfig,
+			NetworkConfig: &vaultmodels.HashicorpCloudVault20201125NetworkConfig{
+				NetworkID:        in.Config.NetworkConfig.NetworkID,
+				PublicIpsEnabled: in.Config.NetworkConfig.PublicIpsEnabled,
+				HTTPProxyOption:  in.Config.NetworkConfig.HTTPProxyOption,
+				IPAllowlist:      in.Config.NetworkConfig.IPAllowlist,
+			},
+			MetricsConfig:        storedObservabilityConfig(in.Config.MetricsConfig),
+			AuditLogExportConfig: storedObservabilityConfig(in.Config.AuditLogExportConfig),
+			MajorVersionUpgradeConfig: &vaultmodels.HashicorpCloudVault20201125MajorVersionUpgradeConfig{
+				UpgradeType: &upgradeType,
+			},
+		},
+		DNSNames: &vaultmodels.HashicorpCloudVault20201125ClusterDNSNames{
+			Private: fmt.Sprintf("%s.private.vault.hcptest.local", in.ID),
+		},
+	}
+	if in.Config.NetworkConfig.PublicIpsEnabled {
+		cluster.DNSNames.Public = fmt.Sprintf("%s.public.vault.hcptest.local", in.ID)
+	}
+	if link := in.PerformanceReplicationPrimaryCluster; link != nil {
+		if link.Location == nil {
+			writeError(w, http.StatusBadRequest, codes.InvalidArgument, "primary cluster location is required")
+			return
+		}
+		primary, ok := s.vaultClusters[locationKey(link.Location.ProjectID, link.ID)]
+		if !ok {
+			writeNotFound(w, "vault cluster", link.ID)
+			return
+		}
+		if primary.model.PerformanceReplicationInfo == nil {
+			primary.model.PerformanceReplicationInfo = &vaultmodels.HashicorpCloudVault20201125ClusterPerformanceReplicationInfo{
+				Mode: vaultmodels.HashicorpCloudVault20201125ClusterPerformanceReplicationInfoModePRIMARY.Pointer(),
+			}
+		}
+		cluster.PerformanceReplicationInfo = &vaultmodels.HashicorpCloudVault20201125ClusterPerformanceReplicationInfo{
+			Mode:               vaultmodels.HashicorpCloudVault20201125ClusterPerformanceReplicationInfoModeSECONDARY.Pointer(),
+			PrimaryClusterLink: link,
+			PathsFilter:        in.PerformanceReplicationPathsFilter,
+		}
+	}
+	s.vaultClusters[key] = &vaultCluster{
+		model:   cluster,
+		plugins: map[string]*vaultmodels.HashicorpCloudVault20201125PluginRegistrationStatus{},
+	}
+
+	op := s.startOperation(vaultClusterLink(cluster), func() {
+		cluster.State = vaultmodels.HashicorpCloudVault20201125ClusterStateRUNNING.Pointer()
+	})
+
+	writeJSON(w, &vaultmodels.HashicorpCloudVault20201125CreateResponse{ClusterID: cluster.ID, Operation: op})
+}
+
+func (s *Server) getVaultCluster(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.vaultClusterFor(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, &vaultmodels.HashicorpCloudVault20201125GetResponse{Cluster: c.model})
+}
+
+func (s *Server) updateVaultCluster(w http.ResponseWriter, r *http.Request) {
+	var in vaultmodels.HashicorpCloudVault20201125InputCluster
+	if !readJSON(w, r, &in) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.vaultClusterFor(w, r)
+	if !ok {
+		return
+	}
+
+	c.model.State = vaultmodels.HashicorpCloudVault20201125ClusterStateUPDATING.Pointer()
+	op := s.startOperation(vaultClusterLink(c.model), func() {
+		cfg := c.model.Config
+		if in.Config != nil {
+			if in.Config.Tier != nil {
+				cfg.Tier = in.Config.Tier
+			}
+			if in.Config.MetricsConfig != nil {
+				cfg.MetricsConfig = storedObservabilityConfig(in.Config.MetricsConfig)
+			}
+			if in.Config.AuditLogExportConfig != nil {
+				cfg.AuditLogExportConfig = storedObservabilityConfig(in.Config.AuditLogExportConfig)
+			}
+			if n := in.Config.NetworkConfig; n != nil {
+				for _, path := range r.URL.Query()["update_mask.paths"] {
+					switch path {
+					case "config.network_config.public_ips_enabled":
+						cfg.NetworkConfig.PublicIpsEnabled = n.PublicIpsEnabled
+					case "config.network_config.http_proxy_option":
+						cfg.NetworkConfig.HTTPProxyOption = n.HTTPProxyOption
+					case "config.network_config.ip_allowlist":
+						cfg.NetworkConfig.IPAllowlist = n.IPAllowlist
+					}
+				}
+			}
+		}
+		c.model.State = vaultmodels.HashicorpCloudVault20201125ClusterStateRUNNING.Pointer()
+	})
+
+	writeJSON(w, &vaultmodels.HashicorpCloudVault20201125UpdateResponse{Operation: op})
+}
+
+func (s *Server) deleteVaultCluster(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.vaultClusterFor(w, r)
+	if !ok {
+		return
+	}
+
+	key := locationKey(r.PathValue("project"), r.PathValue("id"))
+	c.model.State = vaultmodels.HashicorpCloudVault20201125ClusterStateDELETING.Pointer()
+	op := s.startOperation(vaultClusterLink(c.model), func() {
+		delete(s.vaultClusters, key)
+
+		// A primary without secondaries left is no longer replicating.
+		if primaryKey, ok := vaultPrimaryKey(c.model); ok {
+			if primary, ok := s.vaultClusters[primaryKey]; ok && len(s.vaultSecondaries(primaryKey)) == 0 {
+				primary.model.PerformanceReplicationInfo = nil
+			}
+		}
+	})
+
+	writeJSON(w, &vaultmodels.HashicorpCloudVault20201125DeleteResponse{Operation: op})
+}
+
+func (s *Server) getVaultAdminToken(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.vaultClusterFor(w, r); !ok {
+		return
+	}
+
+	writeJSON(w, &vaultmodels.HashicorpCloudVault20201125GetAdminTokenResponse{
+		Token: "hvs." + uuid.NewString(),
+	})
+}
+
+func (s *Server) updateVaultPublicIps(w http.ResponseWriter, r *http.Request) {
+	var req vaultmodels.HashicorpCloudVault20201125UpdatePublicIpsRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.vaultClusterFor(w, r)
+	if !ok {
+		return
+	}
+
+	op := s.startOperation(vaultClusterLink(c.model), func() {
+		c.model.Config.NetworkConfig.PublicIpsEnabled = req.EnablePublicIps
+		c.model.DNSNames.Public = ""
+		if req.EnablePublicIps {
+			c.model.DNSNames.Public = fmt.Sprintf("%s.public.vault.hcptest.local", c.model.ID)
+		}
+	})
+
+	writeJSON(w, &vaultmodels.HashicorpCloudVault20201125UpdatePublicIpsResponse{Operation: op})
+}
+
+func (s *Server) lockVaultCluster(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.vaultClusterFor(w, r)
+	if !ok {
+		return
+	}
+	if *c.model.State != vaultmodels.HashicorpCloudVault20201125ClusterStateRUNNING {
+		writeError(w, http.StatusBadRequest, codes.FailedPrecondition, "only running clusters can be locked")
+		return
+	}
+
+	c.model.State = vaultmodels.HashicorpCloudVault20201125ClusterStateLOCKING.Pointer()
+	op := s.startOperation(vaultClusterLink(c.model), func() {
+		c.model.State = vaultmodels.HashicorpCloudVault20201125ClusterStateLOCKED.Pointer()
+	})
+
+	writeJSON(w, &vaultmodels.HashicorpCloudVault20201125LockResponse{Operation: op})
+}
+
+func (s *Server) unlockVaultCluster(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.vaultClusterFor(w, r)
+	if !ok {
+		return
+	}
+	if *c.model.State != vaultmodels.HashicorpCloudVault20201125ClusterStateLOCKED {
+		writeError(w, http.StatusBadRequest, codes.FailedPrecondition, "only locked clusters can be unlocked")
+		return
+	}
+
+	c.model.State = vaultmodels.HashicorpCloudVault20201125ClusterStateUNLOCKING.Pointer()
+	op := s.startOperation(vaultClusterLink(c.model), func() {
+		c.model.State = vaultmodels.HashicorpCloudVault20201125ClusterStateRUNNING.Pointer()
+	})
+
+	writeJSON(w, &vaultmodels.HashicorpCloudVault20201125UnlockResponse{Operation: op})
+}
+
+func (s *Server) updateVaultVersion(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.vaultClusterFor(w, r)
+	if !ok {
+		return
+	}
+
+	target, err := version.NewSemver(r.PathValue("version"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codes.InvalidArgument, "invalid version")
+		return
+	}
+	current, err := version.NewSemver(c.model.CurrentVersion)
+	if err != nil || !target.GreaterThan(current) {
+		writeError(w, http.StatusBadRequest, codes.FailedPrecondition, "clusters can only be upgraded to a newer version")
+		return
+	}
+
+	c.model.State = vaultmodels.HashicorpCloudVault20201125ClusterStateUPDATING.Pointer()
+	op := s.startOperation(vaultClusterLink(c.model), func() {
+		c.model.CurrentVersion = r.PathValue("version")
+		c.model.State = vaultmodels.HashicorpCloudVault20201125ClusterStateRUNNING.Pointer()
+	})
+
+	writeJSON(w, &vaultmodels.HashicorpCloudVault20201125UpdateVersionResponse{Operation: op})
+}
+
+func (s *Server) updateVaultMajorVersionUpgradeConfig(w http.ResponseWriter, r *http.Request) {
+	var req vaultmodels.HashicorpCloudVault20201125UpdateMajorVersionUpgradeConfigRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.vaultClusterFor(w, r)
+	if !ok {
+		return
+	}
+
+	c.model.Config.MajorVersionUpgradeConfig = &vaultmodels.HashicorpCloudVault20201125MajorVersionUpgradeConfig{
+		UpgradeType:       req.UpgradeType,
+		MaintenanceWindow: req.MaintenanceWindow,
+	}
+
+	writeJSON(w, struct{}{})
+}
+
+func (s *Server) updateVaultPathsFilter(w http.ResponseWriter, r *http.Request) {
+	var req vaultmodels.HashicorpCloudVault20201125UpdatePathsFilterRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.vaultClusterFor(w, r)
+	if !ok {
+		return
+	}
+	if c.model.PerformanceReplicationInfo == nil {
+		writeError(w, http.StatusBadRequest, codes.FailedPrecondition, "only performance replication secondaries may have a paths filter")
+		return
+	}
+
+	op := s.startOperation(vaultClusterLink(c.model), func() {
+		c.model.PerformanceReplicationInfo.PathsFilter = &vaultmodels.HashicorpCloudVault20201125ClusterPerformanceReplicationPathsFilter{
+			Mode:  req.Mode,
+			Paths: req.Paths,
+		}
+	})
+
+	writeJSON(w, &vaultmodels.HashicorpCloudVault20201125UpdatePathsFilterResponse{Operation: op})
+}
+
+func (s *Server) deleteVaultPathsFilter(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.vaultClusterFor(w, r)
+	if !ok {
+		return
+	}
+
+	op := s.startOperation(vaultClusterLink(c.model), func() {
+		if c.model.PerformanceReplicationInfo != nil {
+			c.model.PerformanceReplicationInfo.PathsFilter = nil
+		}
+	})
+
+	writeJSON(w, &vaultmodels.HashicorpCloudVault20201125DeletePathsFilterResponse{Operation: op})
+}
+
+func (s *Server) getVaultReplicationStatus(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.vaultClusterFor(w, r)
+	if !ok {
+		return
+	}
+	if c.model.PerformanceReplicationInfo == nil {
+		writeError(w, http.StatusBadRequest, codes.FailedPrecondition, "performance replication is not enabled")
+		return
+	}
+
+	writeJSON(w, &vaultmodels.HashicorpCloudVault20201125GetReplicationStatusResponse{
+		ConnectionStatus: vaultmodels.HashicorpCloudVault20201125GetReplicationStatusResponseConnectionStatusCONNECTED.Pointer(),
+		SyncProgress:     vaultmodels.HashicorpCloudVault20201125GetReplicationStatusResponseSyncProgressSTREAMING.Pointer(),
+	})
+}
+
+func (s *Server) listVaultReplicationSecondaries(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.vaultClusterFor(w, r); !ok {
+		return
+	}
+
+	writeJSON(w, &vaultmodels.HashicorpCloudVault20201125ListPerformanceReplicationSecondariesResponse{
+		Secondaries: s.vaultSecondaries(locationKey(r.PathValue("project"), r.PathValue("id"))),
+	})
+}
+
+// vaultSecondaries returns the performance replication secondaries of the
+// primary stored under primaryKey. It must be called with s.mu held.
+func (s *Server) vaultSecondaries(primaryKey string) []*vaultmodels.HashicorpCloudVault20201125Cluster {
+	var secondaries []*vaultmodels.HashicorpCloudVault20201125Cluster
+	for _, c := range s.vaultClusters {
+		if key, ok := vaultPrimaryKey(c.model); ok && key == primaryKey {
+			secondaries = append(secondaries, c.model)
+		}
+	}
+	return secondaries
+}
+
+func (s *Server) listVaultPlugins(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.vaultClusterFor(w, r)
+	if !ok {
+		return
+	}
+
+	resp := &vaultmodels.HashicorpCloudVault20201125PluginRegistrationStatusResponse{}
+	for _, p := range c.plugins {
+		resp.Plugins = append(resp.Plugins, p)
+	}
+
+	writeJSON(w, resp)
+}
+
+func (s *Server) addVaultPlugin(w http.ResponseWriter, r *http.Request) {
+	var req vaultmodels.HashicorpCloudVault20201125AddPluginRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.vaultClusterFor(w, r)
+	if !ok {
+		return
+	}
+
+	c.plugins[req.PluginType+"/"+req.PluginName] = &vaultmodels.HashicorpCloudVault20201125PluginRegistrationStatus{
+		IsRegistered:  true,
+		OptIn:         vaultmodels.HashicorpCloudVault20201125OptInStateCUSTOMERENABLED.Pointer(),
+		PluginName:    req.PluginName,
+		PluginType:    vaultmodels.HashicorpCloudVault20201125PluginType(req.PluginType).Pointer(),
+		PluginVersion: s.VaultPluginVersion,
+	}
+
+	writeJSON(w, struct{}{})
+}
+
+func (s *Server) deleteVaultPlugin(w http.ResponseWriter, r *http.Request) {
+	var req vaultmodels.HashicorpCloudVault20201125DeletePluginRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.vaultClusterFor(w, r)
+	if !ok {
+		return
+	}
+
+	delete(c.plugins, req.PluginType+"/"+req.PluginName)
+
+	writeJSON(w, struct{}{})
+}
+
+func (s *Server) listVaultSnapshots(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clusterID := r.URL.Query().Get("resource.id")
+
+	resp := &vaultmodels.HashicorpCloudVault20201125ListSnapshotsResponse{}
+	for _, snap := range s.vaultSnapshots {
+		if snap.model.Location.ProjectID != r.PathValue("project") {
+			continue
+		}
+		if clusterID != "" && snap.model.ClusterID != clusterID {
+			continue
+		}
+		resp.Snapshots = append(resp.Snapshots, snap.model)
+	}
+
+	writeJSON(w, resp)
+}
+
+func (s *Server) createVaultSnapshot(w http.ResponseWriter, r *http.Request) {
+	var req vaultmodels.HashicorpCloudVault20201125CreateSnapshotRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.Resource == nil {
+		writeError(w, http.StatusBadRequest, codes.InvalidArgument, "snapshot resource is required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.vaultClusters[locationKey(r.PathValue("project"), req.Resource.ID)]
+	if !ok {
+		writeNotFound(w, "vault cluster", req.Resource.ID)
+		return
+	}
+
+	snap := &vaultmodels.HashicorpCloudVault20201125Snapshot{
+		SnapshotID:   uuid.NewString(),
+		Name:         req.Name,
+		ClusterID:    c.model.ID,
+		RequestedAt:  now(),
+		Location:     c.model.Location,
+		State:        vaultmodels.HashicorpCloudVault20201125SnapshotStateCREATING.Pointer(),
+		Type:         vaultmodels.HashicorpCloudVault20201125SnapshotTypeMANUAL.Pointer(),
+		VaultVersion: c.model.CurrentVersion,
+	}
+	s.vaultSnapshots[locationKey(r.PathValue("project"), snap.SnapshotID)] = &vaultSnapshot{model: snap}
+
+	op := s.startOperation(vaultSnapshotLink(snap), func() {
+		snap.State = vaultmodels.HashicorpCloudVault20201125SnapshotStateSTORED.Pointer()
+		snap.FinishedAt = now()
+	})
+
+	writeJSON(w, &vaultmodels.HashicorpCloudVault20201125CreateSnapshotResponse{SnapshotID: snap.SnapshotID, Operation: op})
+}
+
+func (s *Server) getVaultSnapshot(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snap, ok := s.vaultSnapshots[locationKey(r.PathValue("project"), r.PathValue("id"))]
+	if !ok {
+		writeNotFound(w, "snapshot", r.PathValue("id"))
+		return
+	}
+
+	writeJSON(w, &vaultmodels.HashicorpCloudVault20201125GetSnapshotResponse{Snapshot: snap.model})
+}
+
+func (s *Server) deleteVaultSnapshot(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := locationKey(r.PathValue("project"), r.PathValue("id"))
+	snap, ok := s.vaultSnapshots[key]
+	if !ok {
+		writeNotFound(w, "snapshot", r.PathValue("id"))
+		return
+	}
+
+	snap.model.State = vaultmodels.HashicorpCloudVault20201125SnapshotStateDELETING.Pointer()
+	op := s.startOperation(vaultSnapshotLink(snap.model), func() { delete(s.vaultSnapshots, key) })
+
+	writeJSON(w, &vaultmodels.HashicorpCloudVault20201125DeleteSnapshotResponse{Operation: op})
+}
+
+func (s *Server) restoreVaultSnapshot(w http.ResponseWriter, r *http.Request) {
+	var req vaultmodels.HashicorpCloudVault20201125RestoreSnapshotRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.vaultClusterFor(w, r)
+	if !ok {
+		return
+	}
+	if *c.model.State != vaultmodels.HashicorpCloudVault20201125ClusterStateRUNNING {
+		writeError(w, http.StatusBadRequest, codes.FailedPrecondition, "only running clusters can be restored")
+		return
+	}
+
+	snap, ok := s.vaultSnapshots[locationKey(r.PathValue("project"), req.SnapshotID)]
+	if !ok {
+		writeNotFound(w, "snapshot", req.SnapshotID)
+		return
+	}
+	if *snap.model.State != vaultmodels.HashicorpCloudVault20201125SnapshotStateSTORED {
+		writeError(w, http.StatusBadRequest, codes.FailedPrecondition, "snapshot %q is not stored", req.SnapshotID)
+		return
+	}
+
+	c.model.State = vaultmodels.HashicorpCloudVault20201125ClusterStateRESTORING.Pointer()
+	op := s.startOperation(vaultClusterLink(c.model), func() {
+		c.model.State = vaultmodels.HashicorpCloudVault20201125ClusterStateRUNNING.Pointer()
+	})
+
+	writeJSON(w, &vaultmodels.HashicorpCloudVault20201125RestoreSnapshotResponse{Operation: op})
+}
+
+// storedObservabilityConfig returns the observability configuration as HCP
+// stores it: without any destination if none is configured, and with its
+// secrets redacted.
+func storedObservabilityConfig(c *vaultmodels.HashicorpCloudVault20201125ObservabilityConfig) *vaultmodels.HashicorpCloudVault20201125ObservabilityConfig {
+	if c == nil {
+		return nil
+	}
+
+	redact := func(secret *string) {
+		if *secret != "" {
+			*secret = "redacted"
+		}
+	}
+
+	stored := &vaultmodels.HashicorpCloudVault20201125ObservabilityConfig{}
+	if g := c.Grafana; g != nil && g.Endpoint != "" {
+		redact(&g.Password)
+		stored.Grafana = g
+	}
+	if sp := c.Splunk; sp != nil && sp.HecEndpoint != "" {
+		redact(&sp.Token)
+		stored.Splunk = sp
+	}
+	if dd := c.Datadog; dd != nil && dd.Region != "" {
+		redact(&dd.APIKey)
+		stored.Datadog = dd
+	}
+	if cw := c.Cloudwatch; cw != nil && cw.AccessKeyID != "" {
+		redact(&cw.SecretAccessKey)
+		stored.Cloudwatch = cw
+	}
+	if es := c.Elasticsearch; es != nil && es.Endpoint != "" {
+		redact(&es.Password)
+		stored.Elasticsearch = es
+	}
+	if h := c.HTTP; h != nil && h.URI != "" {
+		if h.Basic != nil {
+			redact(&h.Basic.Password)
+		}
+		if h.Bearer != nil {
+			redact(&h.Bearer.Token)
+		}
+		stored.HTTP = h
+	}
+	if nr := c.Newrelic; nr != nil && nr.AccountID != "" {
+		redact(&nr.LicenseKey)
+		stored.Newrelic = nr
+	}
+
+	if *stored == (vaultmodels.HashicorpCloudVault20201125ObservabilityConfig{}) {
+		return nil
+	}
+	return stored
+}
+
+// vaultPrimaryKey returns the key of the primary a performance replication
+// secondary replicates from.
+func vaultPrimaryKey(c *vaultmodels.HashicorpCloudVault20201125Cluster) (string, bool) {
+	info := c.PerformanceReplicationInfo
+	if info == nil || info.PrimaryClusterLink == nil || info.PrimaryClusterLink.Location == nil {
+		return "", false
+	}
+	return locationKey(info.PrimaryClusterLink.Location.ProjectID, info.PrimaryClusterLink.ID), true
+}
+
+func vaultClusterLink(c *vaultmodels.HashicorpCloudVault20201125Cluster) *sharedmodels.HashicorpCloudLocationLink {
+	return &sharedmodels.HashicorpCloudLocationLink{
+		ID:       c.ID,
+		Type:     "hashicorp.vault.cluster",
+		Location: vaultSharedLocation(c.Location),
+	}
+}
+
+func vaultSnapshotLink(snap *vaultmodels.HashicorpCloudVault20201125Snapshot) *sharedmodels.HashicorpCloudLocationLink {
+	return &sharedmodels.HashicorpCloudLocationLink{
+		ID:       snap.SnapshotID,
+		Type:     "hashicorp.vault.snapshot",
+		Location: vaultSharedLocation(snap.Location),
+	}
+}
+
+// vaultSharedLocation converts a Vault service location to the shared model
+// used by operations.
+func vaultSharedLocation(l *vaultmodels.HashicorpCloudInternalLocationLocation) *sharedmodels.HashicorpCloudLocationLocation {
+	loc := &sharedmodels.HashicorpCloudLocationLocation{
+		OrganizationID: l.OrganizationID,
+		ProjectID:      l.ProjectID,
+	}
+	if l.Region != nil {
+		loc.Region = &sharedmodels.HashicorpCloudLocationRegion{
+			Provider: l.Region.Provider,
+			Region:   l.Region.Region,
+		}
+	}
+
+	return loc
+}
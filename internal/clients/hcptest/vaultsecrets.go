@@ -0,0 +1,307 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package hcptest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	secretmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-vault-secrets/stable/2023-11-28/models"
+	"google.golang.org/grpc/codes"
+)
+
+type vaultSecretsApp struct {
+	model *secretmodels.Secrets20231128App
+}
+
+// vaultSecretsSecret is a static key-value secret. Only the latest version
+// is retained.
+type vaultSecretsSecret struct {
+	model *secretmodels.Secrets20231128Secret
+	value string
+}
+
+func (s *Server) registerVaultSecrets(mux *http.ServeMux) {
+	const base = "/secrets/2023-11-28/organizations/{org}/projects/{project}/apps"
+
+	mux.HandleFunc("GET "+base, s.listVaultSecretsApps)
+	mux.HandleFunc("POST "+base, s.createVaultSecretsApp)
+	mux.HandleFunc("GET "+base+"/{app}", s.getVaultSecretsApp)
+	mux.HandleFunc("PATCH "+base+"/{app}", s.updateVaultSecretsApp)
+	mux.HandleFunc("DELETE "+base+"/{app}", s.deleteVaultSecretsApp)
+
+	mux.HandleFunc("POST "+base+"/{app}/secret/kv", s.createVaultSecretsKVSecret)
+	mux.HandleFunc("GET "+base+"/{app}/secrets", s.listVaultSecretsSecrets)
+	mux.HandleFunc("GET "+base+"/{app}/secrets:open", s.openVaultSecretsSecrets)
+	mux.HandleFunc("GET "+base+"/{app}/secrets/{secret}", s.getVaultSecretsSecret)
+	mux.HandleFunc("DELETE "+base+"/{app}/secrets/{secret}", s.deleteVaultSecretsSecret)
+}
+
+// vaultSecretsAppFor looks up the app addressed by the request, writing a 404
+// if it does not exist. It must be called with s.mu held.
+func (s *Server) vaultSecretsAppFor(w http.ResponseWriter, r *http.Request) (*vaultSecretsApp, bool) {
+	app, ok := s.vaultSecretsApps[locationKey(r.PathValue("project"), r.PathValue("app"))]
+	if !ok {
+		writeNotFound(w, "app", r.PathValue("app"))
+	}
+	return app, ok
+}
+
+// appSecrets returns the secrets of an app sorted by name. It must be called
+// with s.mu held.
+func (s *Server) appSecrets(projectID, appName string) []*vaultSecretsSecret {
+	prefix := locationKey(projectID, appName) + "/"
+
+	var secrets []*vaultSecretsSecret
+	for key, secret := range s.vaultSecretsValues {
+		if strings.HasPrefix(key, prefix) {
+			secrets = append(secrets, secret)
+		}
+	}
+	sort.Slice(secrets, func(i, j int) bool { return secrets[i].model.Name < secrets[j].model.Name })
+
+	return secrets
+}
+
+func (s *Server) listVaultSecretsApps(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resp := &secretmodels.Secrets20231128ListAppsResponse{}
+	for _, app := range s.vaultSecretsApps {
+		if app.model.ProjectID == r.PathValue("project") {
+			resp.Apps = append(resp.Apps, app.model)
+		}
+	}
+	sort.Slice(resp.Apps, func(i, j int) bool { return resp.Apps[i].Name < resp.Apps[j].Name })
+
+	writeJSON(w, resp)
+}
+
+func (s *Server) createVaultSecretsApp(w http.ResponseWriter, r *http.Request) {
+	var req secretmodels.SecretServiceCreateAppBody
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.Name == "" {
+		writeError(w, http.StatusBadRequest, codes.InvalidArgument, "app name is required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := locationKey(r.PathValue("project"), req.Name)
+	if _, ok := s.vaultSecretsApps[key]; ok {
+		writeAlreadyExists(w, "app", req.Name)
+		return
+	}
+
+	app := &secretmodels.Secrets20231128App{
+		Name:           req.Name,
+		Description:    req.Description,
+		SyncNames:      req.SyncNames,
+		OrganizationID: r.PathValue("org"),
+		ProjectID:      r.PathValue("project"),
+		ResourceID:     uuid.NewString(),
+		ResourceName:   fmt.Sprintf("secrets/project/%s/app/%s", r.PathValue("project"), req.Name),
+		CreatedAt:      now(),
+		UpdatedAt:      now(),
+	}
+	s.vaultSecretsApps[key] = &vaultSecretsApp{model: app}
+
+	writeJSON(w, &secretmodels.Secrets20231128CreateAppResponse{App: app})
+}
+
+func (s *Server) getVaultSecretsApp(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	app, ok := s.vaultSecretsAppFor(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, &secretmodels.Secrets20231128GetAppResponse{App: app.model})
+}
+
+func (s *Server) updateVaultSecretsApp(w http.ResponseWriter, r *http.Request) {
+	var req secretmodels.SecretServiceUpdateAppBody
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	app, ok := s.vaultSecretsAppFor(w, r)
+	if !ok {
+		return
+	}
+
+	app.model.Description = req.Description
+	app.model.SyncNames = req.SyncNames
+	app.model.UpdatedAt = now()
+
+	writeJSON(w, &secretmodels.Secrets20231128UpdateAppResponse{App: app.model})
+}
+
+func (s *Server) deleteVaultSecretsApp(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.vaultSecretsAppFor(w, r); !ok {
+		return
+	}
+
+	if len(s.appSecrets(r.PathValue("project"), r.PathValue("app"))) > 0 {
+		writeError(w, http.StatusBadRequest, codes.FailedPrecondition, "app %q still contains secrets", r.PathValue("app"))
+		return
+	}
+	delete(s.vaultSecretsApps, locationKey(r.PathValue("project"), r.PathValue("app")))
+
+	writeJSON(w, struct{}{})
+}
+
+func (s *Server) createVaultSecretsKVSecret(w http.ResponseWriter, r *http.Request) {
+	var req secretmodels.SecretServiceCreateAppKVSecretBody
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.Name == "" {
+		writeError(w, http.StatusBadRequest, codes.InvalidArgument, "secret name is required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	app, ok := s.vaultSecretsAppFor(w, r)
+	if !ok {
+		return
+	}
+
+	key := locationKey(r.PathValue("project"), r.PathValue("app"), req.Name)
+	secret, ok := s.vaultSecretsValues[key]
+	if !ok {
+		secret = &vaultSecretsSecret{
+			model: &secretmodels.Secrets20231128Secret{
+				Name:      req.Name,
+				Type:      "kv",
+				Provider:  "",
+				CreatedAt: now(),
+			},
+		}
+		s.vaultSecretsValues[key] = secret
+		app.model.SecretCount++
+	}
+
+	secret.value = req.Value
+	secret.model.LatestVersion++
+	secret.model.VersionCount = fmt.Sprint(secret.model.LatestVersion)
+	secret.model.StaticVersion = &secretmodels.Secrets20231128SecretStaticVersion{
+		Version:   secret.model.LatestVersion,
+		CreatedAt: now(),
+	}
+
+	writeJSON(w, &secretmodels.Secrets20231128CreateAppKVSecretResponse{Secret: secret.model})
+}
+
+func (s *Server) listVaultSecretsSecrets(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.vaultSecretsAppFor(w, r); !ok {
+		return
+	}
+
+	resp := &secretmodels.Secrets20231128ListAppSecretsResponse{}
+	for _, secret := range s.appSecrets(r.PathValue("project"), r.PathValue("app")) {
+		resp.Secrets = append(resp.Secrets, secret.model)
+	}
+
+	writeJSON(w, resp)
+}
+
+func (s *Server) openVaultSecretsSecrets(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.vaultSecretsAppFor(w, r); !ok {
+		return
+	}
+
+	resp := &secretmodels.Secrets20231128OpenAppSecretsResponse{}
+	for _, secret := range s.appSecrets(r.PathValue("project"), r.PathValue("app")) {
+		resp.Secrets = append(resp.Secrets, secret.open())
+	}
+
+	writeJSON(w, resp)
+}
+
+// getVaultSecretsSecret serves both GetAppSecret and OpenAppSecret. The latter
+// is addressed as "{secret_name}:open", which the mux cannot match as a
+// separate pattern.
+func (s *Server) getVaultSecretsSecret(w http.ResponseWriter, r *http.Request) {
+	name, open := strings.CutSuffix(r.PathValue("secret"), ":open")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.vaultSecretsAppFor(w, r); !ok {
+		return
+	}
+
+	secret, ok := s.vaultSecretsValues[locationKey(r.PathValue("project"), r.PathValue("app"), name)]
+	if !ok {
+		writeNotFound(w, "secret", name)
+		return
+	}
+
+	if open {
+		writeJSON(w, &secretmodels.Secrets20231128OpenAppSecretResponse{Secret: secret.open()})
+		return
+	}
+
+	writeJSON(w, &secretmodels.Secrets20231128GetAppSecretResponse{Secret: secret.model})
+}
+
+func (s *Server) deleteVaultSecretsSecret(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	app, ok := s.vaultSecretsAppFor(w, r)
+	if !ok {
+		return
+	}
+
+	key := locationKey(r.PathValue("project"), r.PathValue("app"), r.PathValue("secret"))
+	if _, ok := s.vaultSecretsValues[key]; !ok {
+		writeNotFound(w, "secret", r.PathValue("secret"))
+		return
+	}
+	delete(s.vaultSecretsValues, key)
+	app.model.SecretCount--
+
+	writeJSON(w, struct{}{})
+}
+
+// open returns the secret including its value.
+func (v *vaultSecretsSecret) open() *secretmodels.Secrets20231128OpenSecret {
+	return &secretmodels.Secrets20231128OpenSecret{
+		Name:          v.model.Name,
+		Type:          v.model.Type,
+		Provider:      v.model.Provider,
+		CreatedAt:     v.model.CreatedAt,
+		LatestVersion: v.model.LatestVersion,
+		StaticVersion: &secretmodels.Secrets20231128OpenSecretStaticVersion{
+			Version:   v.model.StaticVersion.Version,
+			CreatedAt: v.model.StaticVersion.CreatedAt,
+			Value:     v.value,
+		},
+	}
+}
@@ -6,24 +6,97 @@ package clients
 import (
 	"context"
 	"fmt"
-	"log"
 	"time"
 
 	"github.com/hashicorp/hcp-sdk-go/clients/cloud-operation/stable/2020-05-05/client/operation_service"
 	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"google.golang.org/grpc/codes"
 )
 
-// maxConsecutiveWaitErrors is the maximum number of consecutive http response errors
-// from the operation wait endpoint.
-const maxConsecutiveWaitErrors = 4
+const (
+	// defaultOperationPollInterval is the default interval between two polls
+	// of an operation. It is also the time the wait endpoint blocks for.
+	defaultOperationPollInterval = time.Second * 5
 
-const operationWaitTimeout = time.Second * 5
+	// defaultMaxConsecutiveWaitErrors is the default number of consecutive
+	// http response errors from the operation wait endpoint that are tolerated.
+	defaultMaxConsecutiveWaitErrors = 4
+)
+
+// OperationWaitConfig configures how long-running operations are polled. Zero
+// values are replaced with defaults.
+type OperationWaitConfig struct {
+	// PollInterval is the interval between two polls of an operation.
+	PollInterval time.Duration
+
+	// MaxConsecutiveErrors is the number of consecutive errors from the wait
+	// endpoint after which waiting for an operation fails.
+	MaxConsecutiveErrors int
+}
+
+// withDefaults returns a copy of the configuration with unset fields
+// populated.
+func (c OperationWaitConfig) withDefaults() OperationWaitConfig {
+	if c.PollInterval <= 0 {
+		c.PollInterval = defaultOperationPollInterval
+	}
+	if c.MaxConsecutiveErrors <= 0 {
+		c.MaxConsecutiveErrors = defaultMaxConsecutiveWaitErrors
+	}
+	return c
+}
+
+// OperationError is returned by WaitForOperation when an operation completes
+// with an error.
+type OperationError struct {
+	// Name describes the operation, e.g. "create Vault cluster".
+	Name string
+
+	// Operation is the failed operation.
+	Operation *sharedmodels.HashicorpCloudOperationOperation
+}
+
+func (e *OperationError) Error() string {
+	op := e.Operation
+	msg := fmt.Sprintf("%s operation (%s) failed [code=%d, message=%s]", e.Name, op.ID, op.Error.Code, op.Error.Message)
+	if op.Link != nil {
+		msg += fmt.Sprintf(" on %s %q", op.Link.Type, op.Link.ID)
+	}
+
+	return msg + ". " + e.Remediation()
+}
+
+// Remediation returns a hint on how to address the failure, based on its
+// status code.
+func (e *OperationError) Remediation() string {
+	switch codes.Code(e.Operation.Error.Code) {
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return "Check the resource configuration for values HCP does not accept, then apply again."
+	case codes.PermissionDenied, codes.Unauthenticated:
+		return "Check that the provider credentials have a role granting this operation on the project."
+	case codes.ResourceExhausted:
+		return "A quota was reached, free up resources or request a quota increase, then apply again."
+	case codes.AlreadyExists:
+		return "The resource already exists, import it into the state or choose another ID."
+	case codes.NotFound:
+		return "A resource the operation depends on no longer exists, refresh the state and apply again."
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Aborted, codes.Internal, codes.Unknown:
+		return "This may be a transient failure, apply again. If it persists, contact HashiCorp support with the operation ID."
+	default:
+		return "If the failure persists, contact HashiCorp support with the operation ID."
+	}
+}
 
 // WaitForOperation will poll the operation wait endpoint until an operation
 // is DONE, ctx is canceled, or consecutive errors occur waiting for operation to complete.
+// Progress is logged with tflog, and an operation that completes with an error
+// is returned as an *OperationError.
 func WaitForOperation(ctx context.Context, client *Client, operationName string, loc *sharedmodels.HashicorpCloudLocationLocation, operationID string) error {
+	config := client.Config.OperationWait.withDefaults()
+
 	// Construct operation wait params.
-	waitTimeout := operationWaitTimeout.String()
+	waitTimeout := config.PollInterval.String()
 	waitParams := operation_service.NewWaitParams()
 	waitParams.Context = ctx
 	waitParams.ID = operationID
@@ -31,17 +104,25 @@ func WaitForOperation(ctx context.Context, client *Client, operationName string,
 	waitParams.LocationOrganizationID = loc.OrganizationID
 	waitParams.LocationProjectID = loc.ProjectID
 
+	ctx = tflog.SetField(ctx, "operation_id", operationID)
+	ctx = tflog.SetField(ctx, "operation_name", operationName)
+
+	start := time.Now()
+	var lastState sharedmodels.HashicorpCloudOperationOperationState
+
 	// Start with no consecutive errors.
 	consecutiveErrors := 0
 
 	for {
 		// Use the function to improve break logic of for loop and case statements.
 		shouldBreak, err := func() (bool, error) {
-			// Prevent the loop from running faster than our timeout, in the case where an error causes the api to respond early.
-			notSoonerThan, cancel := context.WithTimeout(context.Background(), operationWaitTimeout)
+			// Prevent the loop from running faster than the poll interval, in the case where an error causes the api to respond early.
+			notSoonerThan, cancel := context.WithTimeout(context.Background(), config.PollInterval)
 			defer cancel()
 
-			log.Printf("[INFO] Waiting for %s operation (%s)", operationName, operationID)
+			tflog.Debug(ctx, "Waiting for operation", map[string]interface{}{
+				"elapsed": time.Since(start).Round(time.Second).String(),
+			})
 			waitResponse, err := client.Operation.Wait(waitParams, nil)
 			if err != nil {
 				// Increment consecutive errors - intermittent network errors shouldn't
@@ -49,28 +130,34 @@ func WaitForOperation(ctx context.Context, client *Client, operationName string,
 				consecutiveErrors++
 
 				// Terminate wait if the number of consecutive errors has exceeded the threshold.
-				if consecutiveErrors >= maxConsecutiveWaitErrors {
+				if consecutiveErrors >= config.MaxConsecutiveErrors {
 					return true, err
 				}
 
-				log.Printf("[WARN] Error waiting for %s operation (%s), will retry if possible: %s", operationName, operationID, err.Error())
+				tflog.Warn(ctx, "Error waiting for operation, will retry if possible", map[string]interface{}{
+					"error":              err.Error(),
+					"consecutive_errors": consecutiveErrors,
+				})
 			} else {
 				// Reset consecutive errors after a successful response.
 				consecutiveErrors = 0
 
-				log.Printf("[INFO] Received state of %s operation (%s): %s", operationName, operationID, *waitResponse.Payload.Operation.State)
-				if *waitResponse.Payload.Operation.State == sharedmodels.HashicorpCloudOperationOperationStateDONE {
-					if waitResponse.Payload.Operation.Error != nil {
-						err := fmt.Errorf("%s operation (%s) failed [code=%d, message=%s]",
-							operationName, waitResponse.Payload.Operation.ID, waitResponse.Payload.Operation.Error.Code, waitResponse.Payload.Operation.Error.Message)
-						return true, err
+				op := waitResponse.Payload.Operation
+				if *op.State != lastState {
+					lastState = *op.State
+					tflog.Info(ctx, "Operation state changed", operationFields(op, start))
+				}
+
+				if *op.State == sharedmodels.HashicorpCloudOperationOperationStateDONE {
+					if op.Error != nil {
+						return true, &OperationError{Name: operationName, Operation: op}
 					}
 
 					return true, nil
 				}
 			}
 
-			// Ensure we don't retry fast if the api responds faster than timeout.
+			// Ensure we don't retry fast if the api responds faster than the poll interval.
 			select {
 			case <-ctx.Done():
 				return true, fmt.Errorf("context canceled waiting for %s operation (%s) to complete", operationName, operationID)
@@ -90,3 +177,61 @@ func WaitForOperation(ctx context.Context, client *Client, operationName string,
 
 	return nil
 }
+
+// operationFields returns the structured log fields describing the operation.
+func operationFields(op *sharedmodels.HashicorpCloudOperationOperation, start time.Time) map[string]interface{} {
+	fields := map[string]interface{}{
+		"state":      string(*op.State),
+		"elapsed":    time.Since(start).Round(time.Second).String(),
+		"created_at": op.CreatedAt.String(),
+		"updated_at": op.UpdatedAt.String(),
+	}
+	if op.Link != nil {
+		fields["resource_type"] = op.Link.Type
+		fields["resource_id"] = op.Link.ID
+	}
+	if op.Error != nil {
+		fields["error_code"] = codes.Code(op.Error.Code).String()
+		fields["error_message"] = op.Error.Message
+	}
+
+	return fields
+}
+
+// GetPendingOperation returns the oldest operation on the given resource that
+// is not DONE yet, or nil if there is none. It lets a resource resume waiting
+// for an operation started by an earlier, interrupted apply.
+func GetPendingOperation(ctx context.Context, client *Client, loc *sharedmodels.HashicorpCloudLocationLocation, resourceType, resourceID string) (*sharedmodels.HashicorpCloudOperationOperation, error) {
+	listParams := operation_service.NewListParams()
+	listParams.Context = ctx
+	listParams.LocationOrganizationID = loc.OrganizationID
+	listParams.LocationProjectID = loc.ProjectID
+	listParams.LinkedResourceType = &resourceType
+	listParams.LinkedResourceID = &resourceID
+
+	var pending *sharedmodels.HashicorpCloudOperationOperation
+	for {
+		listResp, err := client.Operation.List(listParams, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, op := range listResp.Payload.Operations {
+			if op.Link == nil || op.Link.Type != resourceType || op.Link.ID != resourceID {
+				continue
+			}
+			if op.State == nil || *op.State == sharedmodels.HashicorpCloudOperationOperationStateDONE {
+				continue
+			}
+			if pending == nil || time.Time(op.CreatedAt).Before(time.Time(pending.CreatedAt)) {
+				pending = op
+			}
+		}
+
+		pagination := listResp.Payload.Pagination
+		if pagination == nil || pagination.NextPageToken == "" {
+			return pending, nil
+		}
+		listParams.PaginationNextPageToken = &pagination.NextPageToken
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/hcp-sdk-go/clients/cloud-resource-manager/stable/2019-12-10/client/project_service"
+
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients/hcptest"
+)
+
+func TestWaitForOperation(t *testing.T) {
+	tcs := map[string]struct {
+		pendingPolls int
+		failure      string
+		operationID  string
+		wantErr      []string
+	}{
+		"done": {
+			pendingPolls: 3,
+		},
+		"failed": {
+			failure: "injected failure",
+			wantErr: []string{"injected failure", "operation (", "hashicorp.resource-manager.project", "transient failure"},
+		},
+		"error budget": {
+			operationID: "does-not-exist",
+			wantErr:     []string{"not found"},
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			srv := hcptest.NewServer(t)
+			srv.Setenv(t)
+			srv.PendingPolls = tc.pendingPolls
+
+			config := srv.ClientConfig()
+			config.OperationWait = clients.OperationWaitConfig{
+				PollInterval:         10 * time.Millisecond,
+				MaxConsecutiveErrors: 2,
+			}
+			client, err := clients.NewClient(config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tc.failure != "" {
+				srv.FailNextOperation(tc.failure)
+			}
+
+			params := project_service.NewProjectServiceDeleteParams()
+			params.ID = srv.AddProject("other")
+			resp, err := client.Project.ProjectServiceDelete(params, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			operationID := resp.Payload.Operation.ID
+			if tc.operationID != "" {
+				operationID = tc.operationID
+			}
+
+			start := time.Now()
+			err = clients.WaitForOperation(context.Background(), client, "delete project", srv.Location(), operationID)
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("expected the configured poll interval to be used, waited %s", elapsed)
+			}
+
+			if len(tc.wantErr) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			for _, want := range tc.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("expected error to contain %q, got %q", want, err)
+				}
+			}
+
+			var opErr *clients.OperationError
+			if tc.failure != "" && !errors.As(err, &opErr) {
+				t.Errorf("expected an *OperationError, got %T", err)
+			}
+		})
+	}
+}
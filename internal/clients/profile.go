@@ -0,0 +1,132 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsimple"
+	"github.com/hashicorp/hcp-sdk-go/auth"
+	"github.com/hashicorp/hcp-sdk-go/config/files"
+)
+
+const (
+	// envVarProfile selects the hcp CLI profile, like the CLI's own
+	// environment variable.
+	envVarProfile = "HCP_PROFILE"
+
+	// profilesDir is the directory, within the hcp configuration directory,
+	// in which the hcp CLI stores one <name>.hcl file per profile.
+	profilesDir = "profiles"
+
+	// activeProfileFile is the file, within the hcp configuration directory,
+	// in which the hcp CLI stores the name of the active profile.
+	activeProfileFile = "active_profile.hcl"
+)
+
+// Profile is the subset of an hcp CLI profile used by the provider.
+type Profile struct {
+	Name           string `hcl:"name"`
+	OrganizationID string `hcl:"organization_id,optional"`
+	ProjectID      string `hcl:"project_id,optional"`
+
+	// Remain holds the CLI specific settings, which are ignored.
+	Remain hcl.Body `hcl:",remain"`
+}
+
+// activeProfile is the content of the active profile file.
+type activeProfile struct {
+	Name   string   `hcl:"name"`
+	Remain hcl.Body `hcl:",remain"`
+}
+
+// LoadProfile loads the named hcp CLI profile. If name is empty, the profile
+// named by HCP_PROFILE, or else the CLI's active profile, is loaded. It returns
+// nil without an error if no name was given and no profile is active.
+func LoadProfile(name string) (*Profile, error) {
+	userHome, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve user's home directory path: %w", err)
+	}
+	dir := filepath.Join(userHome, files.DefaultDirectory)
+
+	if name == "" {
+		name = os.Getenv(envVarProfile)
+	}
+
+	if name == "" {
+		activePath := filepath.Join(dir, activeProfileFile)
+		if _, err := os.Stat(activePath); errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+
+		var active activeProfile
+		if err := hclsimple.DecodeFile(activePath, nil, &active); err != nil {
+			return nil, fmt.Errorf("failed to read the active hcp profile: %w", err)
+		}
+		name = active.Name
+	}
+
+	var p Profile
+	if err := hclsimple.DecodeFile(filepath.Join(dir, profilesDir, name+".hcl"), nil, &p); err != nil {
+		return nil, fmt.Errorf("failed to read hcp profile %q: %w", name, err)
+	}
+
+	return &p, nil
+}
+
+// ApplyProfile defaults the organization and project of the configuration to
+// those of an hcp CLI profile.
+//
+// A profile selected through config.Profile or HCP_PROFILE is always applied,
+// including alongside configured credentials, as it only provides defaults.
+// The CLI's active profile is only applied when no credentials are configured,
+// in which case the provider authenticates with the user session the CLI
+// shares through the SDK credential cache.
+func ApplyProfile(config ClientConfig) (ClientConfig, error) {
+	if config.Profile == "" && os.Getenv(envVarProfile) == "" && hasExplicitCredentials(config) {
+		return config, nil
+	}
+
+	p, err := LoadProfile(config.Profile)
+	if err != nil || p == nil {
+		return config, err
+	}
+
+	// The profile only provides defaults, so a configured organization or
+	// project is never combined with one from the profile.
+	if config.ProjectID == "" && config.OrganizationID == "" {
+		config.OrganizationID = p.OrganizationID
+		config.ProjectID = p.ProjectID
+	}
+
+	return config, nil
+}
+
+// hasExplicitCredentials reports whether the configuration or the environment
+// provide credentials other than a user session.
+func hasExplicitCredentials(config ClientConfig) bool {
+	if config.ClientID != "" || config.CredentialFile != "" || loadCredentialFile(config) != nil {
+		return true
+	}
+
+	if os.Getenv("HCP_CLIENT_ID") != "" || os.Getenv("HCP_CLIENT_SECRET") != "" {
+		return true
+	}
+
+	// The SDK also loads a credential file from HCP_CRED_FILE or the default
+	// location.
+	if p, err := auth.GetCredentialFilePath(); err == nil {
+		if _, err := os.Stat(p); err == nil {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,170 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// setupProfiles creates an hcp configuration directory in a temporary home
+// directory, containing the given profiles and, if set, the active profile.
+func setupProfiles(t *testing.T, active string, profiles map[string]string) {
+	t.Helper()
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("HCP_PROFILE", "")
+	t.Setenv("HCP_CLIENT_ID", "")
+	t.Setenv("HCP_CLIENT_SECRET", "")
+	t.Setenv("HCP_CRED_FILE", "")
+
+	dir := filepath.Join(home, ".config", "hcp")
+	if err := os.MkdirAll(filepath.Join(dir, profilesDir), 0o755); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if active != "" {
+		content := "name = \"" + active + "\"\n"
+		if err := os.WriteFile(filepath.Join(dir, activeProfileFile), []byte(content), 0o600); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	for name, content := range profiles {
+		if err := os.WriteFile(filepath.Join(dir, profilesDir, name+".hcl"), []byte(content), 0o600); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+const (
+	defaultProfile = `
+name            = "default"
+organization_id = "default-org"
+project_id      = "default-project"
+
+core {
+  output_format = "json"
+}
+`
+
+	stagingProfile = `
+name            = "staging"
+organization_id = "staging-org"
+project_id      = "staging-project"
+`
+)
+
+func TestLoadProfile(t *testing.T) {
+	profiles := map[string]string{
+		"default": defaultProfile,
+		"staging": stagingProfile,
+	}
+
+	tcs := map[string]struct {
+		active  string
+		name    string
+		env     string
+		want    string
+		wantErr bool
+	}{
+		"active profile": {
+			active: "default",
+			want:   "default-project",
+		},
+		"named profile": {
+			active: "default",
+			name:   "staging",
+			want:   "staging-project",
+		},
+		"environment": {
+			active: "default",
+			env:    "staging",
+			want:   "staging-project",
+		},
+		"no active profile": {},
+		"missing profile": {
+			name:    "missing",
+			wantErr: true,
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			setupProfiles(t, tc.active, profiles)
+			t.Setenv("HCP_PROFILE", tc.env)
+
+			p, err := LoadProfile(tc.name)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var got string
+			if p != nil {
+				got = p.ProjectID
+			}
+			if got != tc.want {
+				t.Errorf("expected project %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestApplyProfile(t *testing.T) {
+	profiles := map[string]string{
+		"default": defaultProfile,
+		"staging": stagingProfile,
+	}
+
+	tcs := map[string]struct {
+		config  ClientConfig
+		want    string
+		wantOrg string
+	}{
+		"user session": {
+			want:    "default-project",
+			wantOrg: "default-org",
+		},
+		"configured project": {
+			config: ClientConfig{ProjectID: "configured-project"},
+			want:   "configured-project",
+		},
+		"client credentials": {
+			config: ClientConfig{ClientID: "id", ClientSecret: "secret"},
+		},
+		"named profile": {
+			config:  ClientConfig{Profile: "staging"},
+			want:    "staging-project",
+			wantOrg: "staging-org",
+		},
+		"named profile with client credentials": {
+			config:  ClientConfig{Profile: "staging", ClientID: "id", ClientSecret: "secret"},
+			want:    "staging-project",
+			wantOrg: "staging-org",
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			setupProfiles(t, "default", profiles)
+
+			got, err := ApplyProfile(tc.config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.ProjectID != tc.want {
+				t.Errorf("expected project %q, got %q", tc.want, got.ProjectID)
+			}
+			if got.OrganizationID != tc.wantOrg {
+				t.Errorf("expected organization %q, got %q", tc.wantOrg, got.OrganizationID)
+			}
+		})
+	}
+}
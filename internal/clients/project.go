@@ -7,8 +7,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/cenkalti/backoff/v4"
-
 	"github.com/hashicorp/hcp-sdk-go/clients/cloud-resource-manager/stable/2019-12-10/client/project_service"
 	resourcemodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-resource-manager/stable/2019-12-10/models"
 )
@@ -54,48 +52,3 @@ func CreateProject(ctx context.Context, client *Client, name, organizationID str
 
 	return createProjectResp.Payload.Project, nil
 }
-
-// CreateProjectWithRetry wraps the projects service client with an exponential backoff retry mechanism.
-func CreateProjectWithRetry(client *Client, params *project_service.ProjectServiceCreateParams) (*project_service.ProjectServiceCreateOK, error) {
-	var res *project_service.ProjectServiceCreateOK
-	op := func() error {
-		var err error
-		res, err = client.Project.ProjectServiceCreate(params, nil)
-		return err
-	}
-
-	serviceErr := &project_service.ProjectServiceCreateDefault{}
-	err := backoff.Retry(newBackoffOp(op, serviceErr), newBackoff())
-
-	return res, err
-}
-
-// SetProjectNameWithRetry wraps the projects service client with an exponential backoff retry mechanism.
-func SetProjectNameWithRetry(client *Client, params *project_service.ProjectServiceSetNameParams) (*project_service.ProjectServiceSetNameOK, error) {
-	var res *project_service.ProjectServiceSetNameOK
-	op := func() error {
-		var err error
-		res, err = client.Project.ProjectServiceSetName(params, nil)
-		return err
-	}
-
-	serviceErr := &project_service.ProjectServiceSetNameDefault{}
-	err := backoff.Retry(newBackoffOp(op, serviceErr), newBackoff())
-
-	return res, err
-}
-
-// SetProjectDescriptionWithRetry wraps the projects service client with an exponential backoff retry mechanism.
-func SetProjectDescriptionWithRetry(client *Client, params *project_service.ProjectServiceSetDescriptionParams) (*project_service.ProjectServiceSetDescriptionOK, error) {
-	var res *project_service.ProjectServiceSetDescriptionOK
-	op := func() error {
-		var err error
-		res, err = client.Project.ProjectServiceSetDescription(params, nil)
-		return err
-	}
-
-	serviceErr := &project_service.ProjectServiceSetDescriptionDefault{}
-	err := backoff.Retry(newBackoffOp(op, serviceErr), newBackoff())
-
-	return res, err
-}
@@ -0,0 +1,169 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// RateLimitConfig bounds the load the provider puts on the HCP APIs. Zero
+// values disable the corresponding limit.
+type RateLimitConfig struct {
+	// MaxConcurrentRequests is the maximum number of requests in flight at
+	// any time.
+	MaxConcurrentRequests int
+
+	// RequestsPerSecond is the maximum sustained rate at which requests are
+	// sent.
+	RequestsPerSecond float64
+}
+
+// enabled reports whether any limit is configured.
+func (c RateLimitConfig) enabled() bool {
+	return c.MaxConcurrentRequests > 0 || c.RequestsPerSecond > 0
+}
+
+var (
+	// rateLimiters holds one limiter per configuration so that the SDKv2 and
+	// framework providers, which each build their own client, enforce a
+	// single process-wide limit when configured identically.
+	rateLimiters   = map[RateLimitConfig]*rateLimiter{}
+	rateLimitersMu sync.Mutex
+)
+
+// sharedRateLimiter returns the process-wide limiter for the configuration.
+func sharedRateLimiter(config RateLimitConfig) *rateLimiter {
+	rateLimitersMu.Lock()
+	defer rateLimitersMu.Unlock()
+
+	l, ok := rateLimiters[config]
+	if !ok {
+		l = newRateLimiter(config)
+		rateLimiters[config] = l
+	}
+	return l
+}
+
+// rateLimiter enforces a concurrency limit with a semaphore and a request rate
+// by spacing requests evenly.
+type rateLimiter struct {
+	// slots is nil when concurrency is unlimited.
+	slots chan struct{}
+
+	// interval is the minimum time between two requests, or zero when the
+	// rate is unlimited.
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+func newRateLimiter(config RateLimitConfig) *rateLimiter {
+	l := &rateLimiter{}
+	if config.MaxConcurrentRequests > 0 {
+		l.slots = make(chan struct{}, config.MaxConcurrentRequests)
+	}
+	if config.RequestsPerSecond > 0 {
+		l.interval = time.Duration(float64(time.Second) / config.RequestsPerSecond)
+	}
+	return l
+}
+
+// acquire blocks until the request may be sent or ctx is done. On success the
+// returned function must be called once the request has completed.
+func (l *rateLimiter) acquire(ctx context.Context) (func(), error) {
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		default:
+			start := time.Now()
+			select {
+			case l.slots <- struct{}{}:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+			tflog.Debug(ctx, fmt.Sprintf("waited %s for a free request slot, max concurrent requests: %d", time.Since(start), cap(l.slots)))
+		}
+	}
+
+	release := func() {
+		if l.slots != nil {
+			<-l.slots
+		}
+	}
+
+	if wait := l.reserve(time.Now()); wait > 0 {
+		tflog.Debug(ctx, fmt.Sprintf("rate limit reached, delaying request by %s", wait))
+		if err := sleepWithContext(ctx, wait); err != nil {
+			release()
+			return nil, err
+		}
+	}
+
+	return release, nil
+}
+
+// reserve claims the next send time and returns how long to wait for it.
+func (l *rateLimiter) reserve(now time.Time) time.Duration {
+	if l.interval == 0 {
+		return 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.next.Before(now) {
+		l.next = now
+	}
+	wait := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+
+	return wait
+}
+
+// rateLimitTransport is an http.RoundTripper that applies a rateLimiter to
+// every request.
+type rateLimitTransport struct {
+	next    http.RoundTripper
+	limiter *rateLimiter
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	release, err := t.limiter.acquire(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	// Hold the slot until the body has been consumed, since the connection
+	// is in use until then.
+	resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+// releaseOnClose calls release exactly once when the body is closed.
+type releaseOnClose struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (r *releaseOnClose) Close() error {
+	err := r.ReadCloser.Close()
+	r.once.Do(r.release)
+	return err
+}
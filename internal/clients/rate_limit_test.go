@@ -0,0 +1,111 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimitTransport_maxConcurrentRequests(t *testing.T) {
+	const limit = 3
+
+	var inFlight, maxInFlight int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}))
+	defer srv.Close()
+
+	transport := &rateLimitTransport{
+		next:    http.DefaultTransport,
+		limiter: newRateLimiter(RateLimitConfig{MaxConcurrentRequests: limit}),
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+			resp, err := transport.RoundTrip(req)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight > limit {
+		t.Errorf("expected at most %d requests in flight, got %d", limit, maxInFlight)
+	}
+}
+
+func TestRateLimiter_acquireCanceled(t *testing.T) {
+	l := newRateLimiter(RateLimitConfig{MaxConcurrentRequests: 1})
+
+	release, err := l.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := l.acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context deadline error, got %v", err)
+	}
+}
+
+func TestRateLimiter_reserve(t *testing.T) {
+	l := newRateLimiter(RateLimitConfig{RequestsPerSecond: 4})
+	now := time.Now()
+
+	for i, want := range []time.Duration{0, 250 * time.Millisecond, 500 * time.Millisecond} {
+		if got := l.reserve(now); got != want {
+			t.Errorf("reservation %d: expected wait %s, got %s", i, want, got)
+		}
+	}
+
+	// Idle time is not banked as burst capacity.
+	later := now.Add(10 * time.Second)
+	if got := l.reserve(later); got != 0 {
+		t.Errorf("expected no wait after idling, got %s", got)
+	}
+	if got := l.reserve(later); got != 250*time.Millisecond {
+		t.Errorf("expected 250ms wait, got %s", got)
+	}
+}
+
+func TestSharedRateLimiter(t *testing.T) {
+	a := sharedRateLimiter(RateLimitConfig{MaxConcurrentRequests: 7})
+	b := sharedRateLimiter(RateLimitConfig{MaxConcurrentRequests: 7})
+	c := sharedRateLimiter(RateLimitConfig{MaxConcurrentRequests: 8})
+
+	if a != b {
+		t.Error("expected identical configurations to share a limiter")
+	}
+	if a == c {
+		t.Error("expected different configurations to use different limiters")
+	}
+}
@@ -4,179 +4,407 @@
 package clients
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"regexp"
+	"strconv"
+	"syscall"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
-
-	billing "github.com/hashicorp/hcp-sdk-go/clients/cloud-billing/preview/2020-11-05/client/billing_account_service"
-	"github.com/hashicorp/hcp-sdk-go/clients/cloud-resource-manager/stable/2019-12-10/client/organization_service"
-	"github.com/hashicorp/hcp-sdk-go/clients/cloud-resource-manager/stable/2019-12-10/client/project_service"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 const (
-	retryCount   = 10
-	retryDelay   = 10
-	counterStart = 1
+	// defaultRetryMaxAttempts is the default number of attempts made for a
+	// single request, including the first one.
+	defaultRetryMaxAttempts = 10
+
+	// defaultRetryMaxBackoff is the default upper bound on the delay between
+	// two attempts.
+	defaultRetryMaxBackoff = 60 * time.Second
+
+	// retryInitialBackoff is the delay before the first retry when the server
+	// does not ask for a specific one.
+	retryInitialBackoff = time.Second
+
+	// maxRetryBodyPeek bounds how much of a throttled response is read when
+	// looking for a backoff hint in the error message.
+	maxRetryBodyPeek = 64 * 1024
 )
 
-var errorCodesToRetry = [...]int{502, 503, 504}
+// defaultRetryableStatusCodes are the response status codes that are retried
+// when the configuration does not specify any.
+var defaultRetryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
 
-// Helper to check what requests to retry based on the response HTTP code
-func shouldRetryErrorCode(errorCode int, errorCodesToRetry []int) bool {
-	for i := range errorCodesToRetry {
-		if errorCodesToRetry[i] == errorCode {
-			return true
-		}
+// unprocessedStatusCodes are the response status codes with which the API
+// rejects a request before acting on it. They are the only codes for which a
+// request that is not idempotent is retried.
+var unprocessedStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusServiceUnavailable,
+}
+
+// apiBackoffHint matches the backoff hint some services include in the message
+// of a rate limited response.
+var apiBackoffHint = regexp.MustCompile(`try again in (\d+) seconds`)
+
+// RetryConfig configures how requests to the HCP APIs are retried. Zero values
+// are replaced with defaults.
+type RetryConfig struct {
+	// MaxAttempts is the maximum number of attempts made for a single request,
+	// including the first one.
+	MaxAttempts int
+
+	// MaxBackoff is the maximum delay between two attempts. It also caps the
+	// delay requested by the server through the Retry-After header.
+	MaxBackoff time.Duration
+
+	// RetryableStatusCodes are the response status codes that cause a request
+	// to be retried. Requests that are not idempotent are only retried on the
+	// codes that are also in unprocessedStatusCodes.
+	RetryableStatusCodes []int
+}
+
+// withDefaults returns a copy of the configuration with unset fields
+// populated.
+func (c RetryConfig) withDefaults() RetryConfig {
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = defaultRetryMaxAttempts
 	}
-	return false
+	if c.MaxBackoff <= 0 {
+		c.MaxBackoff = defaultRetryMaxBackoff
+	}
+	if len(c.RetryableStatusCodes) == 0 {
+		c.RetryableStatusCodes = defaultRetryableStatusCodes
+	}
+	return c
 }
 
-// Wraps the OrganizationServiceList function in a loop that supports retrying the GET request
-func RetryOrganizationServiceList(client *Client, params *organization_service.OrganizationServiceListParams) (*organization_service.OrganizationServiceListOK, error) {
-	resp, err := client.Organization.OrganizationServiceList(params, nil)
+// retryTransport is an http.RoundTripper that retries requests failing with a
+// retryable status code or a transient network error. It is installed on the
+// runtime shared by every service client, so individual API calls do not need
+// their own retry loops.
+//
+// A request that is not idempotent, such as a create, may already have been
+// applied when the connection drops or a gateway times out, so it is only
+// retried when the API is known not to have acted on it.
+type retryTransport struct {
+	next   http.RoundTripper
+	config RetryConfig
 
-	if err != nil {
-		serviceErr, ok := err.(*organization_service.OrganizationServiceListDefault)
-		if !ok {
+	// newBackoff returns the backoff policy for a single request. It is a
+	// field so that tests can avoid real delays.
+	newBackoff func(RetryConfig) backoff.BackOff
+}
+
+func newRetryTransport(next http.RoundTripper, config RetryConfig) *retryTransport {
+	return &retryTransport{
+		next:       next,
+		config:     config.withDefaults(),
+		newBackoff: newRetryBackoff,
+	}
+}
+
+// newRetryBackoff creates an exponential backoff bounded by the configured
+// maximum delay. The number of attempts is enforced by the transport.
+func newRetryBackoff(config RetryConfig) backoff.BackOff {
+	return backoff.NewExponentialBackOff(
+		backoff.WithInitialInterval(retryInitialBackoff),
+		backoff.WithRandomizationFactor(backoff.DefaultRandomizationFactor),
+		backoff.WithMultiplier(backoff.DefaultMultiplier),
+		backoff.WithMaxInterval(config.MaxBackoff),
+		backoff.WithMaxElapsedTime(0),
+	)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	// The body has to be replayed on every attempt.
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		body, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
 			return nil, err
 		}
-		counter := counterStart
-		for shouldRetryErrorCode(serviceErr.Code(), errorCodesToRetry[:]) && counter < retryCount {
-			resp, err = client.Organization.OrganizationServiceList(params, nil)
-			if err == nil {
-				break
-			}
-			// Avoid wasting time if we're not going to retry next loop cycle
-			if (counter + 1) != retryCount {
-				fmt.Printf("Error trying to get list of organizations. Retrying in %d seconds...", retryDelay*counter)
-				time.Sleep(time.Duration(retryDelay*counter) * time.Second)
-			}
-			counter++
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
 		}
+		req.Body, _ = req.GetBody()
 	}
-	return resp, err
-}
 
-// Wraps the ProjectServiceList function in a loop that supports retrying the GET request
-func RetryProjectServiceList(client *Client, params *project_service.ProjectServiceListParams) (*project_service.ProjectServiceListOK, error) {
-	resp, err := client.Project.ProjectServiceList(params, nil)
+	idempotent := isIdempotentRequest(req)
 
-	if err != nil {
-		serviceErr, ok := err.(*project_service.ProjectServiceListDefault)
-		if !ok {
-			return nil, err
+	// The timeout of the request, if any, applies to each attempt rather than
+	// to the retries as a whole.
+	var attemptTimeout time.Duration
+	if timeout, ok := ctx.Value(attemptTimeoutKey{}).(*time.Duration); ok {
+		attemptTimeout = *timeout
+	}
+
+	b := t.newBackoff(t.config)
+	for attempt := 1; ; attempt++ {
+		attemptCtx, cancel := ctx, context.CancelFunc(func() {})
+		if attemptTimeout > 0 {
+			attemptCtx, cancel = context.WithTimeout(ctx, attemptTimeout)
 		}
 
-		counter := counterStart
-		for shouldRetryErrorCode(serviceErr.Code(), errorCodesToRetry[:]) && counter < retryCount {
-			resp, err = client.Project.ProjectServiceList(params, nil)
-			if err == nil {
-				break
-			}
-			// Avoid wasting time if we're not going to retry next loop cycle
-			if (counter + 1) != retryCount {
-				fmt.Printf("Error trying to get list of projects. Retrying in %d seconds...", retryDelay*counter)
-				time.Sleep(time.Duration(retryDelay*counter) * time.Second)
+		attemptReq := req.Clone(attemptCtx)
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				cancel()
+				return nil, err
 			}
-			counter++
+			attemptReq.Body = body
 		}
-	}
-	return resp, err
-}
 
-// Wraps the ProjectServiceGet function in a loop that supports retrying the GET request
-func RetryProjectServiceGet(client *Client, params *project_service.ProjectServiceGetParams) (*project_service.ProjectServiceGetOK, error) {
-	resp, err := client.Project.ProjectServiceGet(params, nil)
-
-	if err != nil {
-		serviceErr, ok := err.(*project_service.ProjectServiceGetDefault)
-		if !ok {
-			return nil, err
+		resp, err := t.next.RoundTrip(attemptReq)
+		if err != nil {
+			cancel()
+		} else {
+			// The body is read after RoundTrip returns, so the attempt is only
+			// over once it is closed.
+			resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+		}
+		if attempt >= t.config.MaxAttempts || ctx.Err() != nil {
+			return resp, err
 		}
 
-		counter := counterStart
-		for shouldRetryErrorCode(serviceErr.Code(), errorCodesToRetry[:]) && counter < retryCount {
-			resp, err = client.Project.ProjectServiceGet(params, nil)
-			if err == nil {
-				break
+		var delay time.Duration
+		switch {
+		case err != nil:
+			// An attempt that timed out is retried like a network timeout.
+			timedOut := attemptCtx.Err() != nil
+			if !(isRetryableError(err) || timedOut) || (!idempotent && !isUnsentRequestError(err)) {
+				return nil, err
 			}
-			// Avoid wasting time if we're not going to retry next loop cycle
-			if (counter + 1) != retryCount {
-				fmt.Printf("Error trying to get configured project. Retrying in %d seconds...", retryDelay*counter)
-				time.Sleep(time.Duration(retryDelay*counter) * time.Second)
+			delay = b.NextBackOff()
+			tflog.Debug(ctx, fmt.Sprintf("%s %s failed: %v, retrying in %s, attempt: %d", req.Method, req.URL.Path, err, delay, attempt+1))
+		case shouldRetryErrorCode(resp.StatusCode, t.config.RetryableStatusCodes) &&
+			(idempotent || shouldRetryErrorCode(resp.StatusCode, unprocessedStatusCodes)):
+			delay = t.serverBackoff(resp)
+			if delay <= 0 {
+				delay = b.NextBackOff()
 			}
-			counter++
+			tflog.Debug(ctx, fmt.Sprintf("%s %s returned %q, retrying in %s, attempt: %d", req.Method, req.URL.Path, http.StatusText(resp.StatusCode), delay, attempt+1))
+
+			// Drain the body so the connection can be reused.
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxRetryBodyPeek))
+			_ = resp.Body.Close()
+		default:
+			return resp, nil
 		}
-	}
-	return resp, err
-}
 
-// Wraps the BillingServiceUpdate function in a loop that supports retrying the PUT request
-func RetryBillingServiceUpdate(client *Client, params *billing.BillingAccountServiceUpdateParams) (*billing.BillingAccountServiceUpdateOK, error) {
-	resp, err := client.Billing.BillingAccountServiceUpdate(params, nil)
+		if delay > t.config.MaxBackoff {
+			delay = t.config.MaxBackoff
+		}
 
-	if err != nil {
-		serviceErr, ok := err.(*billing.BillingAccountServiceUpdateDefault)
-		if !ok {
+		if err := sleepWithContext(ctx, delay); err != nil {
 			return nil, err
 		}
+	}
+}
 
-		counter := counterStart
-		for shouldRetryErrorCode(serviceErr.Code(), []int{503}) && counter < retryCount {
-			resp, err = client.Billing.BillingAccountServiceUpdate(params, nil)
-			if err == nil {
-				break
-			}
-			// Avoid wasting time if we're not going to retry next loop cycle
-			if (counter + 1) != retryCount {
-				fmt.Printf("Error trying to update billing account. Retrying in %d seconds...", retryDelay*counter)
-				time.Sleep(time.Duration(retryDelay*counter) * time.Second)
-			}
-			counter++
-		}
+// cancelOnCloseBody is a response body that cancels the context of the attempt
+// it belongs to once it is closed.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close implements io.Closer.
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// attemptTimeoutKey is the context key under which attemptTimeoutRuntime
+// stores the timeout of each attempt of a request.
+type attemptTimeoutKey struct{}
+
+// attemptTimeoutRuntime is a runtime.ClientTransport that hands the timeout of
+// every operation submitted through it to retryTransport.
+//
+// The go-openapi runtime applies the timeout of the operation parameters, 30
+// seconds unless set otherwise, to the context of the whole request. Left in
+// place, it would also bound the retries and the delays between them, cutting
+// them short long before the retry configuration allows.
+type attemptTimeoutRuntime struct {
+	runtime.ClientTransport
+}
+
+// Submit implements runtime.ClientTransport.
+func (r *attemptTimeoutRuntime) Submit(op *runtime.ClientOperation) (interface{}, error) {
+	if op.Params == nil {
+		return r.ClientTransport.Submit(op)
+	}
+
+	ctx := op.Context
+	if ctx == nil {
+		ctx = context.Background()
 	}
-	return resp, err
+
+	// The parameters are written while the operation is submitted, so the
+	// timeout is only known by then.
+	timeout := new(time.Duration)
+
+	// The operation belongs to the caller, so the changes are made on a copy.
+	atOp := *op
+	atOp.Context = context.WithValue(ctx, attemptTimeoutKey{}, timeout)
+	atOp.Params = runtime.ClientRequestWriterFunc(func(req runtime.ClientRequest, reg strfmt.Registry) error {
+		return op.Params.WriteToRequest(&attemptTimeoutRequest{ClientRequest: req, timeout: timeout}, reg)
+	})
+	return r.ClientTransport.Submit(&atOp)
 }
 
-// newBackoff creates a new exponential backoff with default values.
-func newBackoff() backoff.BackOff {
-	// Create a new exponential backoff with explicit default values.
-	return backoff.NewExponentialBackOff(
-		backoff.WithInitialInterval(backoff.DefaultInitialInterval),
-		backoff.WithRandomizationFactor(backoff.DefaultRandomizationFactor),
-		backoff.WithMultiplier(backoff.DefaultMultiplier),
-		backoff.WithMaxInterval(backoff.DefaultMaxInterval),
-		backoff.WithMaxElapsedTime(backoff.DefaultMaxElapsedTime),
-	)
+// attemptTimeoutRequest is a runtime.ClientRequest that records the timeout
+// set by the operation parameters instead of applying it to the request.
+type attemptTimeoutRequest struct {
+	runtime.ClientRequest
+	timeout *time.Duration
 }
 
-func newBackoffOp(op func() error, serviceError ServiceError) func() error {
-	return func() error {
-		err := op()
+// SetTimeout implements runtime.ClientRequest.
+func (r *attemptTimeoutRequest) SetTimeout(timeout time.Duration) error {
+	*r.timeout = timeout
+	return r.ClientRequest.SetTimeout(0)
+}
 
-		if err == nil {
-			return nil
+// serverBackoff returns the delay the server asked for, either through the
+// Retry-After header or, for rate limited responses, through a hint in the
+// error message. It returns zero if the server did not ask for a delay.
+func (t *retryTransport) serverBackoff(resp *http.Response) time.Duration {
+	if delay, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+		return delay
+	}
+
+	if resp.StatusCode != http.StatusTooManyRequests {
+		return 0
+	}
+
+	// Some services only report the backoff in the error message. Peek at the
+	// body and put it back so it can still be decoded if this is the last
+	// attempt.
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxRetryBodyPeek))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+	if err != nil {
+		return 0
+	}
+
+	return getAPIBackoffDuration(string(body))
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either a
+// number of seconds or an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
 		}
+		return time.Duration(seconds) * time.Second, true
+	}
 
-		code, codeErr := getCode(err, serviceError)
-		if codeErr != nil {
-			return backoff.Permanent(codeErr)
+	if date, err := http.ParseTime(value); err == nil {
+		delay := date.Sub(now)
+		if delay < 0 {
+			delay = 0
 		}
+		return delay, true
+	}
 
-		if !shouldRetryErrorCode(code, groupErrorCodesToRetry[:]) {
-			return backoff.Permanent(err)
+	return 0, false
+}
+
+// getAPIBackoffDuration extracts the "try again in N seconds" hint from an
+// error message. It returns zero if the message contains no hint.
+func getAPIBackoffDuration(serviceErrStr string) time.Duration {
+	match := apiBackoffHint.FindStringSubmatch(serviceErrStr)
+	if len(match) > 1 {
+		backoffSeconds, err := strconv.Atoi(match[1])
+		if err == nil {
+			return time.Duration(backoffSeconds) * time.Second
 		}
+	}
+	return 0
+}
+
+// isRetryableError reports whether a transport error is likely to be
+// transient.
+func isRetryableError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// isUnsentRequestError reports whether a transport error means the request
+// never reached the server, so it is safe to retry whatever its method.
+func isUnsentRequestError(err error) bool {
+	return errors.Is(err, syscall.ECONNREFUSED)
+}
+
+// isIdempotentRequest reports whether sending the request twice has the same
+// effect as sending it once. PUT and DELETE are idempotent by HTTP semantics,
+// but the HCP APIs start a new operation for every write they accept, so only
+// reads are treated as idempotent.
+func isIdempotentRequest(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
+
+// sleepWithContext waits for the given duration or until ctx is done.
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
 
-		return err
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
 	}
 }
 
-func getCode(err error, serviceErr ServiceError) (int, error) {
-	ok := errors.As(err, &serviceErr)
-	if !ok {
-		return 0, err
+// Helper to check what requests to retry based on the response HTTP code
+func shouldRetryErrorCode(errorCode int, errorCodesToRetry []int) bool {
+	for i := range errorCodesToRetry {
+		if errorCodesToRetry[i] == errorCode {
+			return true
+		}
 	}
-	return serviceErr.Code(), nil
+	return false
 }
@@ -3,7 +3,22 @@
 
 package clients
 
-import "testing"
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	httptransport "github.com/go-openapi/runtime/client"
+	"github.com/hashicorp/hcp-sdk-go/clients/cloud-resource-manager/stable/2019-12-10/client/project_service"
+)
 
 func TestShouldRetryErrorCode(t *testing.T) {
 	errorCodesToRetry := []int{502, 503, 504}
@@ -18,3 +33,327 @@ func TestShouldRetryErrorCode(t *testing.T) {
 		t.Errorf("shouldRetryErrorCode(503, []int{502, 503, 504}[:]) = %v; want true", shouldSucceed)
 	}
 }
+
+func newTestRetryTransport(config RetryConfig) *retryTransport {
+	rt := newRetryTransport(http.DefaultTransport, config)
+	rt.newBackoff = func(RetryConfig) backoff.BackOff { return &backoff.ZeroBackOff{} }
+	return rt
+}
+
+func TestRetryTransport(t *testing.T) {
+	tcs := map[string]struct {
+		method       string
+		config       RetryConfig
+		statuses     []int
+		wantStatus   int
+		wantAttempts int
+	}{
+		"success": {
+			statuses:     []int{http.StatusOK},
+			wantStatus:   http.StatusOK,
+			wantAttempts: 1,
+		},
+		"retries default codes": {
+			method:       http.MethodGet,
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusBadGateway, http.StatusGatewayTimeout, http.StatusOK},
+			wantStatus:   http.StatusOK,
+			wantAttempts: 5,
+		},
+		"retries unprocessed codes for writes": {
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK},
+			wantStatus:   http.StatusOK,
+			wantAttempts: 3,
+		},
+		"does not retry gateway errors for writes": {
+			statuses:     []int{http.StatusBadGateway, http.StatusOK},
+			wantStatus:   http.StatusBadGateway,
+			wantAttempts: 1,
+		},
+		"does not retry other codes": {
+			statuses:     []int{http.StatusNotFound, http.StatusOK},
+			wantStatus:   http.StatusNotFound,
+			wantAttempts: 1,
+		},
+		"custom codes": {
+			method:       http.MethodGet,
+			config:       RetryConfig{RetryableStatusCodes: []int{http.StatusConflict}},
+			statuses:     []int{http.StatusConflict, http.StatusServiceUnavailable, http.StatusOK},
+			wantStatus:   http.StatusServiceUnavailable,
+			wantAttempts: 2,
+		},
+		"custom codes for writes": {
+			config:       RetryConfig{RetryableStatusCodes: []int{http.StatusConflict, http.StatusServiceUnavailable}},
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusConflict, http.StatusOK},
+			wantStatus:   http.StatusConflict,
+			wantAttempts: 2,
+		},
+		"max attempts": {
+			config:       RetryConfig{MaxAttempts: 3},
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK},
+			wantStatus:   http.StatusServiceUnavailable,
+			wantAttempts: 3,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			method := tc.method
+			if method == "" {
+				method = http.MethodPost
+			}
+
+			var attempts int
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				if string(body) != "payload" {
+					t.Errorf("attempt %d: expected replayed body, got %q", attempts+1, body)
+				}
+				w.WriteHeader(tc.statuses[attempts])
+				attempts++
+			}))
+			defer srv.Close()
+
+			req, err := http.NewRequest(method, srv.URL, strings.NewReader("payload"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := newTestRetryTransport(tc.config).RoundTrip(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.wantStatus {
+				t.Errorf("expected status %d, got %d", tc.wantStatus, resp.StatusCode)
+			}
+			if attempts != tc.wantAttempts {
+				t.Errorf("expected %d attempts, got %d", tc.wantAttempts, attempts)
+			}
+		})
+	}
+}
+
+// errorTransport fails every request with err.
+type errorTransport struct {
+	err      error
+	attempts int
+}
+
+func (t *errorTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	t.attempts++
+	return nil, t.err
+}
+
+func TestRetryTransport_networkErrors(t *testing.T) {
+	tcs := map[string]struct {
+		method       string
+		err          error
+		wantAttempts int
+	}{
+		"read retried": {
+			method:       http.MethodGet,
+			err:          io.ErrUnexpectedEOF,
+			wantAttempts: 3,
+		},
+		"write not retried": {
+			method:       http.MethodPost,
+			err:          io.ErrUnexpectedEOF,
+			wantAttempts: 1,
+		},
+		"delete not retried": {
+			method:       http.MethodDelete,
+			err:          syscall.ECONNRESET,
+			wantAttempts: 1,
+		},
+		"unsent write retried": {
+			method:       http.MethodPost,
+			err:          syscall.ECONNREFUSED,
+			wantAttempts: 3,
+		},
+		"non-transient error": {
+			method:       http.MethodGet,
+			err:          errors.New("certificate signed by unknown authority"),
+			wantAttempts: 1,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			next := &errorTransport{err: tc.err}
+			rt := newTestRetryTransport(RetryConfig{MaxAttempts: 3})
+			rt.next = next
+
+			req, err := http.NewRequest(tc.method, "https://api.cloud.hashicorp.com", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := rt.RoundTrip(req); !errors.Is(err, tc.err) {
+				t.Errorf("expected error %v, got %v", tc.err, err)
+			}
+			if next.attempts != tc.wantAttempts {
+				t.Errorf("expected %d attempts, got %d", tc.wantAttempts, next.attempts)
+			}
+		})
+	}
+}
+
+func TestRetryTransport_contextCanceled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	_, err = newRetryTransport(http.DefaultTransport, RetryConfig{}).RoundTrip(req)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context deadline error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("expected canceled request to return promptly, took %s", elapsed)
+	}
+}
+
+// TestRetryTransport_runtimeTimeout checks that the timeout of the go-openapi
+// runtime, 30 seconds by default, applies to each attempt of a request rather
+// than to its retries as a whole.
+func TestRetryTransport_runtimeTimeout(t *testing.T) {
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch attempt := attempts.Add(1); {
+		case attempt <= 3:
+			// 33 seconds of backoff in total.
+			w.Header().Set("Retry-After", "11")
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case attempt == 5:
+			// Outlive the timeout of the slow request below.
+			time.Sleep(time.Second)
+			fallthrough
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer srv.Close()
+
+	rt := httptransport.New(strings.TrimPrefix(srv.URL, "http://"), "", []string{"http"})
+	rt.Transport = newRetryTransport(http.DefaultTransport, RetryConfig{})
+	client := newClient(ClientConfig{}, &attemptTimeoutRuntime{ClientTransport: rt})
+
+	params := project_service.NewProjectServiceGetParams()
+	params.ID = "project"
+	start := time.Now()
+	if _, err := client.Project.ProjectServiceGet(params, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < httptransport.DefaultTimeout {
+		t.Errorf("expected the retries to outlast the runtime timeout, took %s", elapsed)
+	}
+	if got := attempts.Load(); got != 4 {
+		t.Fatalf("expected 4 attempts, got %d", got)
+	}
+
+	// An attempt that runs out of time is retried.
+	params = project_service.NewProjectServiceGetParamsWithTimeout(100 * time.Millisecond)
+	params.ID = "project"
+	if _, err := client.Project.ProjectServiceGet(params, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := attempts.Load(); got != 6 {
+		t.Errorf("expected 2 more attempts, got %d", got-4)
+	}
+}
+
+func TestRetryTransport_serverBackoff(t *testing.T) {
+	tcs := map[string]struct {
+		status int
+		header string
+		body   string
+		want   time.Duration
+	}{
+		"retry-after seconds": {
+			status: http.StatusServiceUnavailable,
+			header: "7",
+			want:   7 * time.Second,
+		},
+		"message hint on rate limit": {
+			status: http.StatusTooManyRequests,
+			body:   `{"code":8,"message":"rate limit exceeded, try again in 12 seconds"}`,
+			want:   12 * time.Second,
+		},
+		"header takes precedence": {
+			status: http.StatusTooManyRequests,
+			header: "3",
+			body:   `{"message":"try again in 12 seconds"}`,
+			want:   3 * time.Second,
+		},
+		"message hint ignored for other codes": {
+			status: http.StatusServiceUnavailable,
+			body:   `{"message":"try again in 12 seconds"}`,
+		},
+		"no hint": {
+			status: http.StatusTooManyRequests,
+			body:   `{"message":"slow down"}`,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			resp := &http.Response{
+				StatusCode: tc.status,
+				Header:     http.Header{},
+				Body:       io.NopCloser(strings.NewReader(tc.body)),
+			}
+			if tc.header != "" {
+				resp.Header.Set("Retry-After", tc.header)
+			}
+
+			got := newRetryTransport(http.DefaultTransport, RetryConfig{}).serverBackoff(resp)
+			if got != tc.want {
+				t.Errorf("expected %s, got %s", tc.want, got)
+			}
+
+			// The body must still be readable after peeking at it.
+			body, _ := io.ReadAll(resp.Body)
+			if string(body) != tc.body {
+				t.Errorf("expected body %q to be preserved, got %q", tc.body, body)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tcs := map[string]struct {
+		value  string
+		want   time.Duration
+		wantOk bool
+	}{
+		"empty":    {},
+		"seconds":  {value: "30", want: 30 * time.Second, wantOk: true},
+		"negative": {value: "-1"},
+		"date":     {value: now.Add(90 * time.Second).Format(http.TimeFormat), want: 90 * time.Second, wantOk: true},
+		"past":     {value: now.Add(-time.Minute).Format(http.TimeFormat), want: 0, wantOk: true},
+		"invalid":  {value: "soon"},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			got, ok := parseRetryAfter(tc.value, now)
+			if got != tc.want || ok != tc.wantOk {
+				t.Errorf("parseRetryAfter(%q) = %s, %v; want %s, %v", tc.value, got, ok, tc.want, tc.wantOk)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+	"github.com/hashicorp/hcp-sdk-go/version"
+)
+
+// sourceChannelHeader is the request header HCP uses to attribute requests to
+// the tool that originated them.
+const sourceChannelHeader = "X-HCP-Source-Channel"
+
+// sourceChannelKey is the context key under which a per-request source
+// channel is stored.
+type sourceChannelKey struct{}
+
+// sourceChannelTransport is an http.RoundTripper that sets the source channel
+// header. The source channel is read from the request context, falling back to
+// the one the client was created with.
+//
+// It replaces the source channel support of the SDK, which fixes the header
+// when the runtime is created and therefore requires a new runtime, and a new
+// token, for every source channel.
+type sourceChannelTransport struct {
+	next          http.RoundTripper
+	sourceChannel string
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *sourceChannelTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	sc, ok := req.Context().Value(sourceChannelKey{}).(string)
+	if !ok {
+		sc = t.sourceChannel
+	}
+	if sc == "" {
+		return t.next.RoundTrip(req)
+	}
+
+	// A RoundTripper must not modify the caller's request.
+	req = req.Clone(req.Context())
+	req.Header.Set(sourceChannelHeader, fmt.Sprintf("%s hcp-go-sdk/%s", sc, version.Version))
+	return t.next.RoundTrip(req)
+}
+
+// sourceChannelRuntime is a runtime.ClientTransport that attaches a source
+// channel to the context of every operation submitted through it, for
+// sourceChannelTransport to send.
+type sourceChannelRuntime struct {
+	runtime.ClientTransport
+	sourceChannel string
+}
+
+// Submit implements runtime.ClientTransport.
+func (r *sourceChannelRuntime) Submit(op *runtime.ClientOperation) (interface{}, error) {
+	ctx := op.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// The operation belongs to the caller, so the context is set on a copy.
+	scOp := *op
+	scOp.Context = context.WithValue(ctx, sourceChannelKey{}, r.sourceChannel)
+	return r.ClientTransport.Submit(&scOp)
+}
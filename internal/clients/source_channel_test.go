@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httptransport "github.com/go-openapi/runtime/client"
+	"github.com/hashicorp/hcp-sdk-go/clients/cloud-resource-manager/stable/2019-12-10/client/project_service"
+	"github.com/hashicorp/hcp-sdk-go/version"
+)
+
+func TestClient_WithSourceChannel(t *testing.T) {
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get(sourceChannelHeader))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	rt := httptransport.New(strings.TrimPrefix(srv.URL, "http://"), "", []string{"http"})
+	rt.Transport = &sourceChannelTransport{
+		next:          http.DefaultTransport,
+		sourceChannel: "terraform-provider-hcp",
+	}
+
+	client := newClient(ClientConfig{SourceChannel: "terraform-provider-hcp"}, rt)
+	moduleClient := client.WithSourceChannel("terraform-provider-hcp terraform-module/a")
+	nestedClient := moduleClient.WithSourceChannel("terraform-provider-hcp terraform-module/b")
+
+	for _, c := range []*Client{client, moduleClient, nestedClient, client} {
+		params := project_service.NewProjectServiceGetParams()
+		params.ID = "project"
+		if _, err := c.Project.ProjectServiceGet(params, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	sdk := " hcp-go-sdk/" + version.Version
+	want := []string{
+		"terraform-provider-hcp" + sdk,
+		"terraform-provider-hcp terraform-module/a" + sdk,
+		"terraform-provider-hcp terraform-module/b" + sdk,
+		"terraform-provider-hcp" + sdk,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d requests, got %d", len(want), len(got))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("request %d: expected source channel %q, got %q", i, want[i], got[i])
+		}
+	}
+}
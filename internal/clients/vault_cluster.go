@@ -240,6 +240,38 @@ func UpdateVaultClusterConfig(
 	return updateResp.Payload, nil
 }
 
+// UpdateVaultClusterVersion will make a call to the Vault service to upgrade a Vault cluster to the given version
+func UpdateVaultClusterVersion(ctx context.Context, client *Client, loc *sharedmodels.HashicorpCloudLocationLocation,
+	clusterID string, version string) (*vaultmodels.HashicorpCloudVault20201125UpdateVersionResponse, error) {
+
+	locInternal := &vaultmodels.HashicorpCloudInternalLocationLocation{
+		OrganizationID: loc.OrganizationID,
+		ProjectID:      loc.ProjectID,
+		Region: &vaultmodels.HashicorpCloudInternalLocationRegion{
+			Provider: loc.Region.Provider,
+			Region:   loc.Region.Region,
+		},
+	}
+	updateParams := vault_service.NewUpdateVersionParams()
+	updateParams.Context = ctx
+	updateParams.ClusterID = clusterID
+	updateParams.LocationProjectID = loc.ProjectID
+	updateParams.LocationOrganizationID = loc.OrganizationID
+	updateParams.Version = version
+	updateParams.Body = &vaultmodels.HashicorpCloudVault20201125UpdateVersionRequest{
+		ClusterID: clusterID,
+		Location:  locInternal,
+		Version:   version,
+	}
+
+	updateResp, err := client.Vault.UpdateVersion(updateParams, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return updateResp.Payload, nil
+}
+
 // UpdateVaultPathsFilter will make a call to the Vault service to update the paths filter for a secondary cluster
 func UpdateVaultPathsFilter(ctx context.Context, client *Client, loc *sharedmodels.HashicorpCloudLocationLocation,
 	clusterID string, params vaultmodels.HashicorpCloudVault20201125ClusterPerformanceReplicationPathsFilter) (*vaultmodels.HashicorpCloudVault20201125UpdatePathsFilterResponse, error) {
@@ -294,6 +326,66 @@ func DeleteVaultPathsFilter(ctx context.Context, client *Client, loc *sharedmode
 	return deleteResp.Payload, nil
 }
 
+// LockVaultCluster will make a call to the Vault service to lock a Vault cluster
+func LockVaultCluster(ctx context.Context, client *Client, loc *sharedmodels.HashicorpCloudLocationLocation,
+	clusterID string) (*vaultmodels.HashicorpCloudVault20201125LockResponse, error) {
+
+	locInternal := &vaultmodels.HashicorpCloudInternalLocationLocation{
+		OrganizationID: loc.OrganizationID,
+		ProjectID:      loc.ProjectID,
+		Region: &vaultmodels.HashicorpCloudInternalLocationRegion{
+			Provider: loc.Region.Provider,
+			Region:   loc.Region.Region,
+		},
+	}
+	lockParams := vault_service.NewLockParams()
+	lockParams.Context = ctx
+	lockParams.ClusterID = clusterID
+	lockParams.LocationProjectID = loc.ProjectID
+	lockParams.LocationOrganizationID = loc.OrganizationID
+	lockParams.Body = &vaultmodels.HashicorpCloudVault20201125LockRequest{
+		ClusterID: clusterID,
+		Location:  locInternal,
+	}
+
+	lockResp, err := client.Vault.Lock(lockParams, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return lockResp.Payload, nil
+}
+
+// UnlockVaultCluster will make a call to the Vault service to unlock a Vault cluster
+func UnlockVaultCluster(ctx context.Context, client *Client, loc *sharedmodels.HashicorpCloudLocationLocation,
+	clusterID string) (*vaultmodels.HashicorpCloudVault20201125UnlockResponse, error) {
+
+	locInternal := &vaultmodels.HashicorpCloudInternalLocationLocation{
+		OrganizationID: loc.OrganizationID,
+		ProjectID:      loc.ProjectID,
+		Region: &vaultmodels.HashicorpCloudInternalLocationRegion{
+			Provider: loc.Region.Provider,
+			Region:   loc.Region.Region,
+		},
+	}
+	unlockParams := vault_service.NewUnlockParams()
+	unlockParams.Context = ctx
+	unlockParams.ClusterID = clusterID
+	unlockParams.LocationProjectID = loc.ProjectID
+	unlockParams.LocationOrganizationID = loc.OrganizationID
+	unlockParams.Body = &vaultmodels.HashicorpCloudVault20201125UnlockRequest{
+		ClusterID: clusterID,
+		Location:  locInternal,
+	}
+
+	unlockResp, err := client.Vault.Unlock(unlockParams, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return unlockResp.Payload, nil
+}
+
 // AddPlugin will make a call to the Vault service to add a plugin to a Vault cluster
 func AddPlugin(ctx context.Context, client *Client, loc *sharedmodels.HashicorpCloudLocationLocation, clusterID string,
 	request *vaultmodels.HashicorpCloudVault20201125AddPluginRequest) (vaultmodels.HashicorpCloudVault20201125AddPluginResponse, error) {
@@ -354,3 +446,166 @@ func ListPlugins(ctx context.Context, client *Client, loc *sharedmodels.Hashicor
 
 	return listPluginsResp.Payload, nil
 }
+
+// CreateVaultSnapshot will make a call to the Vault service to initiate the create Vault
+// snapshot workflow.
+func CreateVaultSnapshot(ctx context.Context, client *Client, cluster *vaultmodels.HashicorpCloudVault20201125Cluster,
+	snapshotName string) (*vaultmodels.HashicorpCloudVault20201125CreateSnapshotResponse, error) {
+
+	p := vault_service.NewCreateSnapshotParams()
+	p.Context = ctx
+	p.ResourceLocationOrganizationID = cluster.Location.OrganizationID
+	p.ResourceLocationProjectID = cluster.Location.ProjectID
+	p.Body = &vaultmodels.HashicorpCloudVault20201125CreateSnapshotRequest{
+		Name: snapshotName,
+		Resource: &vaultmodels.HashicorpCloudInternalLocationLink{
+			ID:       cluster.ID,
+			Type:     "hashicorp.vault.cluster",
+			Location: cluster.Location,
+		},
+	}
+
+	resp, err := client.Vault.CreateSnapshot(p, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Payload, nil
+}
+
+// GetVaultSnapshotByID gets a Vault snapshot by its ID.
+func GetVaultSnapshotByID(ctx context.Context, client *Client, loc *sharedmodels.HashicorpCloudLocationLocation,
+	snapshotID string) (*vaultmodels.HashicorpCloudVault20201125Snapshot, error) {
+
+	p := vault_service.NewGetSnapshotParams()
+	p.Context = ctx
+	p.LocationOrganizationID = loc.OrganizationID
+	p.LocationProjectID = loc.ProjectID
+	p.SnapshotID = snapshotID
+
+	resp, err := client.Vault.GetSnapshot(p, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Payload.Snapshot, nil
+}
+
+// DeleteVaultSnapshotByID will make a call to the Vault service to initiate the delete
+// Vault snapshot workflow.
+func DeleteVaultSnapshotByID(ctx context.Context, client *Client, loc *sharedmodels.HashicorpCloudLocationLocation,
+	snapshotID string) (*vaultmodels.HashicorpCloudVault20201125DeleteSnapshotResponse, error) {
+
+	p := vault_service.NewDeleteSnapshotParams()
+	p.Context = ctx
+	p.LocationOrganizationID = loc.OrganizationID
+	p.LocationProjectID = loc.ProjectID
+	p.SnapshotID = snapshotID
+
+	resp, err := client.Vault.DeleteSnapshot(p, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Payload, nil
+}
+
+// RestoreVaultSnapshot will make a call to the Vault service to initiate the
+// restore of a Vault snapshot on a cluster.
+func RestoreVaultSnapshot(ctx context.Context, client *Client, cluster *vaultmodels.HashicorpCloudVault20201125Cluster,
+	snapshotID string) (*vaultmodels.HashicorpCloudVault20201125RestoreSnapshotResponse, error) {
+
+	p := vault_service.NewRestoreSnapshotParams()
+	p.Context = ctx
+	p.ClusterID = cluster.ID
+	p.LocationOrganizationID = cluster.Location.OrganizationID
+	p.LocationProjectID = cluster.Location.ProjectID
+	p.Body = &vaultmodels.HashicorpCloudVault20201125RestoreSnapshotRequest{
+		ClusterID:  cluster.ID,
+		Location:   cluster.Location,
+		SnapshotID: snapshotID,
+	}
+
+	resp, err := client.Vault.RestoreSnapshot(p, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Payload, nil
+}
+
+// ListVaultSnapshots lists every snapshot of a Vault cluster, following
+// pagination.
+func ListVaultSnapshots(ctx context.Context, client *Client, loc *sharedmodels.HashicorpCloudLocationLocation,
+	clusterID string) ([]*vaultmodels.HashicorpCloudVault20201125Snapshot, error) {
+
+	resourceType := "hashicorp.vault.cluster"
+
+	p := vault_service.NewListSnapshotsParams()
+	p.Context = ctx
+	p.ResourceLocationOrganizationID = loc.OrganizationID
+	p.ResourceLocationProjectID = loc.ProjectID
+	p.ResourceID = &clusterID
+	p.ResourceType = &resourceType
+
+	var snapshots []*vaultmodels.HashicorpCloudVault20201125Snapshot
+	for {
+		resp, err := client.Vault.ListSnapshots(p, nil)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, resp.Payload.Snapshots...)
+
+		pagination := resp.Payload.Pagination
+		if pagination == nil || pagination.NextPageToken == "" {
+			return snapshots, nil
+		}
+		p.PaginationNextPageToken = &pagination.NextPageToken
+	}
+}
+
+// GetVaultReplicationStatus gets the performance replication status of a Vault
+// cluster.
+func GetVaultReplicationStatus(ctx context.Context, client *Client, loc *sharedmodels.HashicorpCloudLocationLocation,
+	clusterID string) (*vaultmodels.HashicorpCloudVault20201125GetReplicationStatusResponse, error) {
+
+	p := vault_service.NewGetReplicationStatusParams()
+	p.Context = ctx
+	p.ClusterID = clusterID
+	p.LocationOrganizationID = loc.OrganizationID
+	p.LocationProjectID = loc.ProjectID
+
+	resp, err := client.Vault.GetReplicationStatus(p, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Payload, nil
+}
+
+// ListVaultPerformanceReplicationSecondaries lists every performance
+// replication secondary of a primary Vault cluster, following pagination.
+func ListVaultPerformanceReplicationSecondaries(ctx context.Context, client *Client, loc *sharedmodels.HashicorpCloudLocationLocation,
+	clusterID string) ([]*vaultmodels.HashicorpCloudVault20201125Cluster, error) {
+
+	p := vault_service.NewListPerformanceReplicationSecondariesParams()
+	p.Context = ctx
+	p.ClusterID = clusterID
+	p.LocationOrganizationID = loc.OrganizationID
+	p.LocationProjectID = loc.ProjectID
+
+	var secondaries []*vaultmodels.HashicorpCloudVault20201125Cluster
+	for {
+		resp, err := client.Vault.ListPerformanceReplicationSecondaries(p, nil)
+		if err != nil {
+			return nil, err
+		}
+		secondaries = append(secondaries, resp.Payload.Secondaries...)
+
+		pagination := resp.Payload.Pagination
+		if pagination == nil || pagination.NextPageToken == "" {
+			return secondaries, nil
+		}
+		p.PaginationNextPageToken = &pagination.NextPageToken
+	}
+}
@@ -5,14 +5,8 @@ package clients
 
 import (
 	"context"
-	"fmt"
-	"net/http"
-	"regexp"
-	"strconv"
-	"time"
 
 	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
-	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/hashicorp/hcp-sdk-go/clients/cloud-vault-secrets/stable/2023-11-28/client/secret_service"
 	secretmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-vault-secrets/stable/2023-11-28/models"
@@ -128,26 +122,3 @@ func DeleteVaultSecretsAppSecret(ctx context.Context, client *Client, loc *share
 
 	return nil
 }
-
-func shouldRetryWithSleep(ctx context.Context, err ErrorWithCode, attemptNum int, expectedErrorCodes []int) bool {
-	if shouldRetryErrorCode(err.Code(), expectedErrorCodes) {
-		backOffDuration := getAPIBackoffDuration(err.Error())
-		tflog.Debug(ctx, fmt.Sprintf("error: %q, retrying in %d seconds, attempt: %d", http.StatusText(err.Code()), int64(backOffDuration.Seconds()), attemptNum+1))
-		time.Sleep(backOffDuration)
-		return true
-	}
-	return false
-}
-
-func getAPIBackoffDuration(serviceErrStr string) time.Duration {
-	re := regexp.MustCompile(`try again in (\d+) seconds`)
-	match := re.FindStringSubmatch(serviceErrStr)
-	backoffSeconds := 60
-	if len(match) > 1 {
-		backoffSecondsOverride, err := strconv.Atoi(match[1])
-		if err == nil {
-			backoffSeconds = backoffSecondsOverride
-		}
-	}
-	return time.Duration(backoffSeconds) * time.Second
-}
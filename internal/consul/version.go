@@ -48,7 +48,7 @@ func VersionsToString(versions []*consulmodels.HashicorpCloudConsul20210204Versi
 			continue
 		}
 
-		if *v.Status == consulmodels.HashicorpCloudConsul20210204VersionStatusRECOMMENDED {
+		if v.Status != nil && *v.Status == consulmodels.HashicorpCloudConsul20210204VersionStatusRECOMMENDED {
 			recommendedVersion = v.Version
 		} else {
 			otherVersions = append(otherVersions, v.Version)
@@ -113,3 +113,61 @@ func GetLatestPatch(version string, versions []*consulmodels.HashicorpCloudConsu
 
 	return ""
 }
+
+// IsVersionConstraint determines whether the given string is a version constraint, such as "~> 1.16",
+// rather than a single version.
+func IsVersionConstraint(version string) bool {
+	if _, err := semver.NewSemver(version); err == nil {
+		return false
+	}
+
+	_, err := semver.NewConstraint(version)
+	return err == nil
+}
+
+// GetLatestMatchingVersion returns the latest of the given versions that satisfies the version constraint.
+// Preview versions are not considered.
+//
+// E.g. Given the following slice of versions: ["1.11.1", "1.12.2", "1.13.2", "1.13.3", "1.14.0"]
+// GetLatestMatchingVersion("~> 1.13.0", versions) would return "1.13.3"
+// GetLatestMatchingVersion("~> 1.13", versions) would return "1.14.0"
+// GetLatestMatchingVersion("~> 1.10.0", versions) would return an error
+func GetLatestMatchingVersion(constraint string, versions []*consulmodels.HashicorpCloudConsul20210204Version) (string, error) {
+	constraints, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return "", fmt.Errorf("invalid version constraint (%s): %w", constraint, err)
+	}
+
+	// The latest matching version and its original representation.
+	var latest *semver.Version
+	var latestVersion string
+
+	var candidates []*consulmodels.HashicorpCloudConsul20210204Version
+	for _, v := range versions {
+		if v == nil || (v.Status != nil && *v.Status == consulmodels.HashicorpCloudConsul20210204VersionStatusPREVIEW) {
+			continue
+		}
+
+		current, err := semver.NewSemver(v.Version)
+		if err != nil {
+			// Ignore invalid versions.
+			continue
+		}
+		candidates = append(candidates, v)
+
+		if !constraints.Check(current) {
+			continue
+		}
+
+		if latest == nil || current.GreaterThan(latest) {
+			latest = current
+			latestVersion = v.Version
+		}
+	}
+
+	if latest == nil {
+		return "", fmt.Errorf("no available Consul version matches the constraint (%s); must match one of: [%s]", constraint, VersionsToString(candidates))
+	}
+
+	return latestVersion, nil
+}
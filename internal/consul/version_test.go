@@ -255,3 +255,117 @@ func Test_GetLatestPatch(t *testing.T) {
 		})
 	}
 }
+
+func Test_IsVersionConstraint(t *testing.T) {
+	tcs := map[string]struct {
+		input    string
+		expected bool
+	}{
+		"Version": {
+			input:    "1.16.0",
+			expected: false,
+		},
+		"PrefixedVersion": {
+			input:    "v1.16.0",
+			expected: false,
+		},
+		"PartialVersion": {
+			input:    "1.16",
+			expected: false,
+		},
+		"PessimisticConstraint": {
+			input:    "~> 1.16",
+			expected: true,
+		},
+		"RangeConstraint": {
+			input:    ">= 1.15, < 1.17",
+			expected: true,
+		},
+		"Invalid": {
+			input:    "invalid",
+			expected: false,
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			r.Equal(tc.expected, IsVersionConstraint(tc.input))
+		})
+	}
+}
+
+func Test_GetLatestMatchingVersion(t *testing.T) {
+	tcs := map[string]struct {
+		input       string
+		expected    string
+		expectedErr string
+	}{
+		"Invalid": {
+			input:       "invalid",
+			expectedErr: "invalid version constraint (invalid)",
+		},
+		"NotFound": {
+			input:       "~> 1.10.0",
+			expectedErr: "no available Consul version matches the constraint (~> 1.10.0); must match one of: [v1.14.0 (recommended), v1.13.2, v1.12.5, v1.13.3]",
+		},
+		"FoundPatch": {
+			input:    "~> 1.13.0",
+			expected: "v1.13.3",
+		},
+		"FoundMinor": {
+			input:    "~> 1.12",
+			expected: "v1.14.0",
+		},
+		"FoundRange": {
+			input:    ">= 1.12, < 1.14",
+			expected: "v1.13.3",
+		},
+		"IgnoresPreview": {
+			input:       "~> 1.15.0",
+			expectedErr: "no available Consul version matches the constraint (~> 1.15.0)",
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			versions := []*consulmodels.HashicorpCloudConsul20210204Version{
+				{
+					Version: "v1.14.0",
+					Status:  consulmodels.HashicorpCloudConsul20210204VersionStatusRECOMMENDED.Pointer(),
+				},
+				{
+					Version: "v1.13.2",
+					Status:  consulmodels.HashicorpCloudConsul20210204VersionStatusAVAILABLE.Pointer(),
+				},
+				{
+					Version: "v1.12.5",
+					Status:  consulmodels.HashicorpCloudConsul20210204VersionStatusAVAILABLE.Pointer(),
+				},
+				{
+					Version: "v1.15.0",
+					Status:  consulmodels.HashicorpCloudConsul20210204VersionStatusPREVIEW.Pointer(),
+				},
+				{
+					Version: "invalid",
+					Status:  consulmodels.HashicorpCloudConsul20210204VersionStatusAVAILABLE.Pointer(),
+				},
+				{
+					Version: "v1.13.3",
+					Status:  consulmodels.HashicorpCloudConsul20210204VersionStatusAVAILABLE.Pointer(),
+				},
+			}
+
+			version, err := GetLatestMatchingVersion(tc.input, versions)
+			if tc.expectedErr != "" {
+				r.ErrorContains(err, tc.expectedErr)
+				return
+			}
+			r.NoError(err)
+			r.Equal(tc.expected, version)
+		})
+	}
+}
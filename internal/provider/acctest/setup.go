@@ -29,7 +29,11 @@ var ProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, erro
 			providerserver.NewProtocol6(provider.NewFrameworkProvider(version.ProviderVersion)()),
 		}
 
-		return tf6muxserver.NewMuxServer(context.Background(), providers...)
+		muxServer, err := tf6muxserver.NewMuxServer(context.Background(), providers...)
+		if err != nil {
+			return nil, err
+		}
+		return provider.NewPlanGuardServer(muxServer.ProviderServer()), nil
 	},
 }
 
@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// deletionProtected reports whether the state of a resource has its
+// deletion_protection attribute set to true.
+func deletionProtected(state tftypes.Value) bool {
+	if !state.IsKnown() || state.IsNull() || !state.Type().Is(tftypes.Object{}) {
+		return false
+	}
+
+	var attrs map[string]tftypes.Value
+	if err := state.As(&attrs); err != nil {
+		return false
+	}
+
+	v, ok := attrs["deletion_protection"]
+	if !ok || !v.IsKnown() || v.IsNull() {
+		return false
+	}
+
+	var protected bool
+	if err := v.As(&protected); err != nil {
+		return false
+	}
+	return protected
+}
+
+func deletionProtectionDiagnostic(typeName, action string) *tfprotov6.Diagnostic {
+	return &tfprotov6.Diagnostic{
+		Severity: tfprotov6.DiagnosticSeverityWarning,
+		Summary:  "Resource is protected from deletion",
+		Detail: fmt.Sprintf("The %s resource has deletion_protection set to true, so it cannot be %s and the apply will fail. "+
+			"Set deletion_protection to false and apply the change first to allow it.", typeName, action),
+	}
+}
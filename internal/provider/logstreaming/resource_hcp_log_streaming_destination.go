@@ -64,6 +64,15 @@ func (r *resourceHCPLogStreamingDestination) Schema(_ context.Context, _ resourc
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"organization_id": schema.StringAttribute{
+				Description: "The ID of the HCP organization the streaming destination is created in. If unspecified, the organization configured on the provider is used.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"splunk_cloud": schema.SingleNestedAttribute{
 				Attributes: map[string]schema.Attribute{
 					"endpoint": schema.StringAttribute{
@@ -172,6 +181,7 @@ func (r *resourceHCPLogStreamingDestination) Configure(_ context.Context, req re
 type HCPLogStreamingDestination struct {
 	Name                   types.String `tfsdk:"name"`
 	StreamingDestinationID types.String `tfsdk:"streaming_destination_id"`
+	OrganizationID         types.String `tfsdk:"organization_id"`
 	SplunkCloud            types.Object `tfsdk:"splunk_cloud"`
 	CloudWatch             types.Object `tfsdk:"cloudwatch"`
 	Datadog                types.Object `tfsdk:"datadog"`
@@ -309,7 +319,8 @@ func (r *resourceHCPLogStreamingDestination) Create(ctx context.Context, req res
 		return
 	}
 
-	orgID := r.client.Config.OrganizationID
+	orgID, _ := r.client.Location(plan.OrganizationID, types.StringNull())
+	plan.OrganizationID = types.StringValue(orgID)
 
 	createParams := streaming_service.NewStreamingServiceCreateDestinationParams()
 	createParams.Context = ctx
@@ -397,7 +408,10 @@ func (r *resourceHCPLogStreamingDestination) Read(ctx context.Context, req resou
 		return
 	}
 
-	orgID := r.client.Config.OrganizationID
+	// Destinations created before organization_id was added use the
+	// provider's organization.
+	orgID, _ := r.client.Location(state.OrganizationID, types.StringNull())
+	state.OrganizationID = types.StringValue(orgID)
 
 	res, err := clients.GetLogStreamingDestination(ctx, r.client, orgID, state.StreamingDestinationID.ValueString())
 	if err != nil {
@@ -426,9 +440,11 @@ func (r *resourceHCPLogStreamingDestination) Update(ctx context.Context, req res
 		return
 	}
 
+	orgID, _ := r.client.Location(state.OrganizationID, types.StringNull())
+
 	var fieldMaskPaths []string
 	destination := &models.LogService20210330StreamingDestination{
-		OrganizationID: r.client.Config.OrganizationID,
+		OrganizationID: orgID,
 		ID:             state.StreamingDestinationID.ValueString(),
 	}
 
@@ -514,7 +530,8 @@ func (r *resourceHCPLogStreamingDestination) Delete(ctx context.Context, req res
 		return
 	}
 
-	err := clients.DeleteLogStreamingDestination(ctx, r.client, r.client.Config.OrganizationID, state.StreamingDestinationID.ValueString())
+	orgID, _ := r.client.Location(state.OrganizationID, types.StringNull())
+	err := clients.DeleteLogStreamingDestination(ctx, r.client, orgID, state.StreamingDestinationID.ValueString())
 	if err != nil {
 		var getErr *log_service.LogServiceGetStreamingDestinationDefault
 		if errors.As(err, &getErr) && getErr.IsCode(http.StatusNotFound) {
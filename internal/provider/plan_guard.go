@@ -0,0 +1,146 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// planGuardServer checks the plans of every resource, regardless of the
+// provider implementation serving it: it enforces the read_only provider
+// setting and warns about deleting resources with deletion_protection enabled.
+// The checks are done at the protocol level since the plugin SDK does not let
+// resources inspect the plan of a deletion.
+type planGuardServer struct {
+	tfprotov6.ProviderServer
+
+	readOnly      bool
+	resourceTypes map[string]tftypes.Type
+}
+
+// NewPlanGuardServer wraps the muxed provider server so that, when read_only is
+// enabled, planning any change to a resource fails, and planning the deletion
+// of a protected resource warns that the apply will fail.
+func NewPlanGuardServer(server tfprotov6.ProviderServer) tfprotov6.ProviderServer {
+	return &planGuardServer{ProviderServer: server}
+}
+
+func (s *planGuardServer) ConfigureProvider(ctx context.Context, req *tfprotov6.ConfigureProviderRequest) (*tfprotov6.ConfigureProviderResponse, error) {
+	resp, err := s.ProviderServer.ConfigureProvider(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+
+	schemaResp, err := s.ProviderServer.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		return resp, err
+	}
+
+	s.resourceTypes = make(map[string]tftypes.Type, len(schemaResp.ResourceSchemas))
+	for name, schema := range schemaResp.ResourceSchemas {
+		s.resourceTypes[name] = schema.ValueType()
+	}
+
+	readOnly, err := readOnlyFromConfig(req.Config, schemaResp.Provider.ValueType())
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov6.Diagnostic{
+			Severity: tfprotov6.DiagnosticSeverityError,
+			Summary:  "Invalid read_only setting",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	s.readOnly = readOnly
+
+	return resp, nil
+}
+
+func (s *planGuardServer) PlanResourceChange(ctx context.Context, req *tfprotov6.PlanResourceChangeRequest) (*tfprotov6.PlanResourceChangeResponse, error) {
+	resp, err := s.ProviderServer.PlanResourceChange(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			return resp, nil
+		}
+	}
+
+	action, prior, err := s.plannedAction(req, resp)
+	if err != nil || action == "" {
+		return resp, err
+	}
+
+	switch {
+	case s.readOnly:
+		resp.Diagnostics = append(resp.Diagnostics, readOnlyDiagnostic(req.TypeName, action))
+	case (action == "deleted" || action == "replaced") && deletionProtected(prior):
+		resp.Diagnostics = append(resp.Diagnostics, deletionProtectionDiagnostic(req.TypeName, action))
+	}
+
+	return resp, nil
+}
+
+// plannedAction returns the action Terraform will take on the resource, or an
+// empty string if the plan does not change it, along with the prior state.
+func (s *planGuardServer) plannedAction(req *tfprotov6.PlanResourceChangeRequest, resp *tfprotov6.PlanResourceChangeResponse) (string, tftypes.Value, error) {
+	typ, ok := s.resourceTypes[req.TypeName]
+	if !ok {
+		return "", tftypes.Value{}, fmt.Errorf("unknown resource type %q", req.TypeName)
+	}
+
+	prior, err := unmarshalState(req.PriorState, typ)
+	if err != nil {
+		return "", tftypes.Value{}, err
+	}
+	planned, err := unmarshalState(resp.PlannedState, typ)
+	if err != nil {
+		return "", tftypes.Value{}, err
+	}
+
+	switch {
+	case prior.IsNull() && planned.IsNull():
+		return "", prior, nil
+	case prior.IsNull():
+		return "created", prior, nil
+	case planned.IsNull():
+		return "deleted", prior, nil
+	case len(resp.RequiresReplace) > 0:
+		return "replaced", prior, nil
+	case !prior.Equal(planned):
+		return "updated", prior, nil
+	default:
+		return "", prior, nil
+	}
+}
+
+func unmarshalState(state *tfprotov6.DynamicValue, typ tftypes.Type) (tftypes.Value, error) {
+	if state == nil {
+		return tftypes.NewValue(typ, nil), nil
+	}
+
+	val, err := state.Unmarshal(typ)
+	if err != nil {
+		return tftypes.Value{}, fmt.Errorf("unable to read resource state: %w", err)
+	}
+	return val, nil
+}
+
+func (s *planGuardServer) ApplyResourceChange(ctx context.Context, req *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error) {
+	// Plans are already rejected, this only guards against applying a plan
+	// created by another provider configuration.
+	if s.readOnly {
+		return &tfprotov6.ApplyResourceChangeResponse{
+			NewState:    req.PriorState,
+			Diagnostics: []*tfprotov6.Diagnostic{readOnlyDiagnostic(req.TypeName, "changed")},
+		}, nil
+	}
+
+	return s.ProviderServer.ApplyResourceChange(ctx, req)
+}
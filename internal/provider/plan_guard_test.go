@@ -0,0 +1,189 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var (
+	testProviderType = tftypes.Object{AttributeTypes: map[string]tftypes.Type{"read_only": tftypes.Bool}}
+	testResourceType = tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"name":                tftypes.String,
+		"deletion_protection": tftypes.Bool,
+	}}
+)
+
+// planningServer is a provider server planning the proposed new state as is.
+type planningServer struct {
+	tfprotov6.ProviderServer
+}
+
+func (planningServer) ConfigureProvider(context.Context, *tfprotov6.ConfigureProviderRequest) (*tfprotov6.ConfigureProviderResponse, error) {
+	return &tfprotov6.ConfigureProviderResponse{}, nil
+}
+
+func (planningServer) GetProviderSchema(context.Context, *tfprotov6.GetProviderSchemaRequest) (*tfprotov6.GetProviderSchemaResponse, error) {
+	return &tfprotov6.GetProviderSchemaResponse{
+		Provider: &tfprotov6.Schema{Block: &tfprotov6.SchemaBlock{Attributes: []*tfprotov6.SchemaAttribute{
+			{Name: "read_only", Type: tftypes.Bool, Optional: true},
+		}}},
+		ResourceSchemas: map[string]*tfprotov6.Schema{
+			"hcp_test": {Block: &tfprotov6.SchemaBlock{Attributes: []*tfprotov6.SchemaAttribute{
+				{Name: "name", Type: tftypes.String, Required: true},
+				{Name: "deletion_protection", Type: tftypes.Bool, Optional: true},
+			}}},
+		},
+	}, nil
+}
+
+func (planningServer) PlanResourceChange(_ context.Context, req *tfprotov6.PlanResourceChangeRequest) (*tfprotov6.PlanResourceChangeResponse, error) {
+	return &tfprotov6.PlanResourceChangeResponse{PlannedState: req.ProposedNewState}, nil
+}
+
+func testDynamicValue(t *testing.T, typ tftypes.Type, val tftypes.Value) *tfprotov6.DynamicValue {
+	t.Helper()
+
+	dv, err := tfprotov6.NewDynamicValue(typ, val)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return &dv
+}
+
+func testResource(name *string, protected bool) tftypes.Value {
+	if name == nil {
+		return tftypes.NewValue(testResourceType, nil)
+	}
+	return tftypes.NewValue(testResourceType, map[string]tftypes.Value{
+		"name":                tftypes.NewValue(tftypes.String, *name),
+		"deletion_protection": tftypes.NewValue(tftypes.Bool, protected),
+	})
+}
+
+func TestPlanGuardServer(t *testing.T) {
+	a, b := "a", "b"
+
+	tcs := map[string]struct {
+		readOnly    tftypes.Value
+		env         string
+		prior       *string
+		protected   bool
+		proposed    *string
+		wantErr     string
+		wantWarning string
+		wantConfErr bool
+	}{
+		"create": {
+			readOnly: tftypes.NewValue(tftypes.Bool, true),
+			proposed: &a,
+			wantErr:  "cannot be created",
+		},
+		"update": {
+			readOnly: tftypes.NewValue(tftypes.Bool, true),
+			prior:    &a,
+			proposed: &b,
+			wantErr:  "cannot be updated",
+		},
+		"delete": {
+			readOnly: tftypes.NewValue(tftypes.Bool, true),
+			prior:    &a,
+			wantErr:  "cannot be deleted",
+		},
+		"no change": {
+			readOnly: tftypes.NewValue(tftypes.Bool, true),
+			prior:    &a,
+			proposed: &a,
+		},
+		"disabled": {
+			readOnly: tftypes.NewValue(tftypes.Bool, false),
+			env:      "true",
+			proposed: &a,
+		},
+		"environment": {
+			readOnly: tftypes.NewValue(tftypes.Bool, nil),
+			env:      "true",
+			proposed: &a,
+			wantErr:  "cannot be created",
+		},
+		"unknown": {
+			readOnly: tftypes.NewValue(tftypes.Bool, tftypes.UnknownValue),
+			proposed: &a,
+			wantErr:  "cannot be created",
+		},
+		"protected delete": {
+			readOnly:    tftypes.NewValue(tftypes.Bool, false),
+			prior:       &a,
+			protected:   true,
+			wantWarning: "cannot be deleted",
+		},
+		"protected update": {
+			readOnly:  tftypes.NewValue(tftypes.Bool, false),
+			prior:     &a,
+			protected: true,
+			proposed:  &b,
+		},
+		"protected read-only delete": {
+			readOnly:  tftypes.NewValue(tftypes.Bool, true),
+			prior:     &a,
+			protected: true,
+			wantErr:   "cannot be deleted",
+		},
+		"invalid environment": {
+			readOnly:    tftypes.NewValue(tftypes.Bool, nil),
+			env:         "yes please",
+			wantConfErr: true,
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			t.Setenv(envVarReadOnly, tc.env)
+			ctx := context.Background()
+			s := NewPlanGuardServer(planningServer{})
+
+			config := tftypes.NewValue(testProviderType, map[string]tftypes.Value{"read_only": tc.readOnly})
+			confResp, err := s.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+				Config: testDynamicValue(t, testProviderType, config),
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.wantConfErr {
+				if len(confResp.Diagnostics) == 0 {
+					t.Fatal("expected a configuration error")
+				}
+				return
+			}
+
+			resp, err := s.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+				TypeName:         "hcp_test",
+				PriorState:       testDynamicValue(t, testResourceType, testResource(tc.prior, tc.protected)),
+				ProposedNewState: testDynamicValue(t, testResourceType, testResource(tc.proposed, tc.protected)),
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			want, severity := tc.wantErr, tfprotov6.DiagnosticSeverityError
+			if tc.wantWarning != "" {
+				want, severity = tc.wantWarning, tfprotov6.DiagnosticSeverityWarning
+			}
+			if want == "" {
+				if len(resp.Diagnostics) != 0 {
+					t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics[0].Detail)
+				}
+				return
+			}
+			if len(resp.Diagnostics) != 1 || resp.Diagnostics[0].Severity != severity || !strings.Contains(resp.Diagnostics[0].Detail, want) {
+				t.Fatalf("expected a %s diagnostic containing %q, got %v", severity, want, resp.Diagnostics)
+			}
+		})
+	}
+}
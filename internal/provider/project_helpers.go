@@ -17,51 +17,54 @@ import (
 )
 
 // getProjectFromCredentials uses the configured client credentials to
-// fetch the associated organization and returns that organization's
-// single project.
+// fetch the associated organization, unless one is configured, and returns
+// that organization's single project. The project is nil if the organization
+// has none.
 // This differs from the provider.go implementation due to the diagnostics used
 // by the plugin framework.
-func getProjectFromCredentialsFramework(ctx context.Context, client *clients.Client) (project *models.HashicorpCloudResourcemanagerProject, diags diagnostic.Diagnostics) {
-	// Get the organization ID.
-	listOrgParams := organization_service.NewOrganizationServiceListParams()
-	listOrgResp, err := clients.RetryOrganizationServiceList(client, listOrgParams)
-	if err != nil {
-		diags.AddError(fmt.Sprintf("unable to fetch organization list: %v", err), "")
+func getProjectFromCredentialsFramework(ctx context.Context, client *clients.Client) (orgID string, project *models.HashicorpCloudResourcemanagerProject, diags diagnostic.Diagnostics) {
+	orgID = client.Config.OrganizationID
+	if orgID == "" {
+		// Get the organization ID.
+		listOrgParams := organization_service.NewOrganizationServiceListParams()
+		listOrgResp, err := client.Organization.OrganizationServiceList(listOrgParams, nil)
+		if err != nil {
+			diags.AddError(fmt.Sprintf("unable to fetch organization list: %v", err), "")
 
-		return nil, diags
-	}
-	orgLen := len(listOrgResp.Payload.Organizations)
-	if orgLen == 0 {
-		diags.AddError("The configured credentials do not have access to any organization.", "Please assign at least one organization to the configured credentials to use this provider.")
-		return nil, diags
-	}
-	if orgLen > 1 {
-		diags.AddError("There is more than one organization associated with the configured credentials.", "Please configure a specific project in the HCP provider config block.")
-		return nil, diags
-	}
+			return "", nil, diags
+		}
+		orgLen := len(listOrgResp.Payload.Organizations)
+		if orgLen == 0 {
+			diags.AddError("The configured credentials do not have access to any organization.", "Please assign at least one organization to the configured credentials to use this provider.")
+			return "", nil, diags
+		}
+		if orgLen > 1 {
+			diags.AddError("There is more than one organization associated with the configured credentials.", "Please configure a specific organization or project in the HCP provider config block.")
+			return "", nil, diags
+		}
 
-	orgID := listOrgResp.Payload.Organizations[0].ID
+		orgID = listOrgResp.Payload.Organizations[0].ID
+	}
 
 	// Get the project using the organization ID.
 	listProjParams := project_service.NewProjectServiceListParams()
 	listProjParams.ScopeID = &orgID
 	scopeType := string(models.HashicorpCloudResourcemanagerResourceIDResourceTypeORGANIZATION)
 	listProjParams.ScopeType = &scopeType
-	listProjResp, err := clients.RetryProjectServiceList(client, listProjParams)
+	listProjResp, err := client.Project.ProjectServiceList(listProjParams, nil)
 	if err != nil {
 		diags.AddError(fmt.Sprintf("unable to fetch project id: %v", err), "")
-		return nil, diags
+		return "", nil, diags
 	}
 	if len(listProjResp.Payload.Projects) == 0 {
-		diags.AddError("The configured credentials does not have access to any project.", "Please assign at least one project to the configured credentials to use this provider.")
-		return nil, diags
+		return orgID, nil, diags
 	}
 	if len(listProjResp.Payload.Projects) > 1 {
 		diags.AddWarning("There is more than one project associated with the organization of the configured credentials.", `The oldest project has been selected as the default. To configure which project is used as default, set a project in the HCP provider config block. Resources may also be configured with different projects.`)
-		return getOldestProject(listProjResp.Payload.Projects), diags
+		return orgID, getOldestProject(listProjResp.Payload.Projects), diags
 	}
 	project = listProjResp.Payload.Projects[0]
-	return project, diags
+	return orgID, project, diags
 }
 
 // getOldestProject retrieves the oldest project from a list based on its created_at time.
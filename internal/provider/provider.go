@@ -5,15 +5,20 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"regexp"
+	"time"
 
 	"github.com/hashicorp/hcp-sdk-go/clients/cloud-resource-manager/stable/2019-12-10/client/project_service"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -26,6 +31,7 @@ import (
 	"github.com/hashicorp/terraform-provider-hcp/internal/provider/logstreaming"
 	"github.com/hashicorp/terraform-provider-hcp/internal/provider/packer"
 	"github.com/hashicorp/terraform-provider-hcp/internal/provider/resourcemanager"
+	"github.com/hashicorp/terraform-provider-hcp/internal/provider/vault"
 	"github.com/hashicorp/terraform-provider-hcp/internal/provider/vaultradar"
 	"github.com/hashicorp/terraform-provider-hcp/internal/provider/vaultsecrets"
 	"github.com/hashicorp/terraform-provider-hcp/internal/provider/waypoint"
@@ -45,11 +51,22 @@ type ProviderFrameworkConfiguration struct {
 }
 
 type ProviderFrameworkModel struct {
-	ClientSecret     types.String `tfsdk:"client_secret"`
-	ClientID         types.String `tfsdk:"client_id"`
-	CredentialFile   types.String `tfsdk:"credential_file"`
-	ProjectID        types.String `tfsdk:"project_id"`
-	WorkloadIdentity types.List   `tfsdk:"workload_identity"`
+	ClientSecret          types.String  `tfsdk:"client_secret"`
+	ClientID              types.String  `tfsdk:"client_id"`
+	CredentialFile        types.String  `tfsdk:"credential_file"`
+	OrganizationID        types.String  `tfsdk:"organization_id"`
+	ProjectID             types.String  `tfsdk:"project_id"`
+	APIAddress            types.String  `tfsdk:"api_address"`
+	AuthURL               types.String  `tfsdk:"auth_url"`
+	PortalURL             types.String  `tfsdk:"portal_url"`
+	Profile               types.String  `tfsdk:"profile"`
+	ReadOnly              types.Bool    `tfsdk:"read_only"`
+	MaxConcurrentRequests types.Int64   `tfsdk:"max_concurrent_requests"`
+	RequestsPerSecond     types.Float64 `tfsdk:"requests_per_second"`
+	WorkloadIdentity      types.List    `tfsdk:"workload_identity"`
+	TLS                   types.List    `tfsdk:"tls"`
+	Retry                 types.List    `tfsdk:"retry"`
+	OperationWait         types.List    `tfsdk:"operation_wait"`
 }
 
 type WorkloadIdentityFrameworkModel struct {
@@ -58,6 +75,22 @@ type WorkloadIdentityFrameworkModel struct {
 	ResourceName types.String `tfsdk:"resource_name"`
 }
 
+type TLSFrameworkModel struct {
+	CAFile             types.String `tfsdk:"ca_file"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+}
+
+type OperationWaitFrameworkModel struct {
+	PollInterval         types.String `tfsdk:"poll_interval"`
+	MaxConsecutiveErrors types.Int64  `tfsdk:"max_consecutive_errors"`
+}
+
+type RetryFrameworkModel struct {
+	MaxAttempts          types.Int64  `tfsdk:"max_attempts"`
+	MaxBackoff           types.String `tfsdk:"max_backoff"`
+	RetryableStatusCodes types.List   `tfsdk:"retryable_status_codes"`
+}
+
 func (p *ProviderFramework) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
 	resp.TypeName = "hcp"
 	resp.Version = "dev"
@@ -82,10 +115,63 @@ func (p *ProviderFramework) Schema(ctx context.Context, req provider.SchemaReque
 					stringvalidator.AlsoRequires(path.MatchRoot("client_id")),
 				},
 			},
+			"organization_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "The default organization in which resources should be created. Required when the credentials have access to more than one organization and no `project_id` is set.",
+			},
 			"project_id": schema.StringAttribute{
 				Optional:    true,
 				Description: "The default project in which resources should be created.",
 			},
+			"profile": schema.StringAttribute{
+				Optional: true,
+				Description: "The name of the hcp CLI profile whose organization and project the provider defaults to. " +
+					"Defaults to the HCP_PROFILE environment variable, or to the active profile when no credentials are configured, " +
+					"in which case the provider authenticates with the hcp CLI user session. A selected profile also applies alongside configured credentials.",
+			},
+			"read_only": schema.BoolAttribute{
+				Optional: true,
+				Description: "When true, any plan that would create, update or delete a resource fails, while data sources " +
+					"and refreshes keep working. Defaults to the HCP_READ_ONLY environment variable.",
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The maximum number of requests the provider sends to the HCP APIs concurrently. Unlimited by default.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"requests_per_second": schema.Float64Attribute{
+				Optional:    true,
+				Description: "The maximum number of requests per second the provider sends to the HCP APIs. Unlimited by default.",
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0.01),
+				},
+			},
+			"api_address": schema.StringAttribute{
+				Optional:    true,
+				Description: "The address of the HCP API, as a host with an optional port (e.g. `api.cloud.hashicorp.com:443`). Overrides the HCP_API_ADDRESS environment variable.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[^/:\s]+(:\d+)?$`),
+						"must be a host with an optional port, without a scheme",
+					),
+				},
+			},
+			"auth_url": schema.StringAttribute{
+				Optional:    true,
+				Description: "The URL of the HCP auth server (e.g. `https://auth.idp.hashicorp.com`). Overrides the HCP_AUTH_URL environment variable.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^https://.+$`), "must be an https URL"),
+				},
+			},
+			"portal_url": schema.StringAttribute{
+				Optional:    true,
+				Description: "The URL of the HCP portal (e.g. `https://portal.cloud.hashicorp.com`).",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^https://.+$`), "must be an https URL"),
+				},
+			},
 			"credential_file": schema.StringAttribute{
 				Optional: true,
 				Description: "The path to an HCP credential file to use to authenticate the provider to HCP. " +
@@ -136,6 +222,74 @@ func (p *ProviderFramework) Schema(ctx context.Context, req provider.SchemaReque
 					listvalidator.SizeBetween(1, 1),
 				},
 			},
+			"tls": schema.ListNestedBlock{
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"ca_file": schema.StringAttribute{
+							Optional:    true,
+							Description: "The path to a PEM encoded bundle of CA certificates used to verify the endpoints instead of the system roots.",
+						},
+						"insecure_skip_verify": schema.BoolAttribute{
+							Optional:    true,
+							Description: "Disables the verification of the endpoint certificates. This should only be used for testing.",
+						},
+					},
+				},
+				Description: "Configures how the provider verifies the TLS certificates of the HCP API and auth endpoints.",
+				Validators: []validator.List{
+					listvalidator.SizeBetween(1, 1),
+				},
+			},
+			"operation_wait": schema.ListNestedBlock{
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"poll_interval": schema.StringAttribute{
+							Optional:    true,
+							Description: "The interval between two polls of an operation, as a Go duration string (e.g. `10s`). Defaults to `5s`.",
+						},
+						"max_consecutive_errors": schema.Int64Attribute{
+							Optional:    true,
+							Description: "The number of consecutive errors polling an operation after which waiting for it fails. Defaults to `4`.",
+							Validators: []validator.Int64{
+								int64validator.AtLeast(1),
+							},
+						},
+					},
+				},
+				Description: "Configures how the provider waits for long-running HCP operations, such as cluster creation, to complete.",
+				Validators: []validator.List{
+					listvalidator.SizeBetween(1, 1),
+				},
+			},
+			"retry": schema.ListNestedBlock{
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"max_attempts": schema.Int64Attribute{
+							Optional:    true,
+							Description: "The maximum number of attempts made for a single request, including the first one. Defaults to `10`.",
+							Validators: []validator.Int64{
+								int64validator.AtLeast(1),
+							},
+						},
+						"max_backoff": schema.StringAttribute{
+							Optional:    true,
+							Description: "The maximum delay between two attempts, as a Go duration string (e.g. `30s`). This also caps the delay requested by the API through the `Retry-After` header. Defaults to `60s`.",
+						},
+						"retryable_status_codes": schema.ListAttribute{
+							Optional:    true,
+							ElementType: types.Int64Type,
+							Description: "The HTTP status codes that cause a request to be retried. Requests that create, update or delete resources are only retried on `429` and `503`, as the API may already have applied them otherwise. Defaults to `[429, 502, 503, 504]`.",
+							Validators: []validator.List{
+								listvalidator.ValueInt64sAre(int64validator.Between(100, 599)),
+							},
+						},
+					},
+				},
+				Description: "Configures how requests to the HCP APIs that fail with a transient error are retried.",
+				Validators: []validator.List{
+					listvalidator.SizeBetween(1, 1),
+				},
+			},
 		},
 	}
 }
@@ -217,6 +371,13 @@ func (p *ProviderFramework) DataSources(ctx context.Context) []func() datasource
 	}, packer.DataSourceSchemaBuilders...)
 }
 
+func (p *ProviderFramework) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		// Vault
+		vault.NewVaultClusterAdminTokenEphemeralResource,
+	}
+}
+
 func NewFrameworkProvider(version string) func() provider.Provider {
 	return func() provider.Provider {
 		return &ProviderFramework{
@@ -234,8 +395,17 @@ func (p *ProviderFramework) Configure(ctx context.Context, req provider.Configur
 		ClientID:       data.ClientID.ValueString(),
 		ClientSecret:   data.ClientSecret.ValueString(),
 		CredentialFile: data.CredentialFile.ValueString(),
+		OrganizationID: data.OrganizationID.ValueString(),
 		ProjectID:      data.ProjectID.ValueString(),
+		APIAddress:     data.APIAddress.ValueString(),
+		AuthURL:        data.AuthURL.ValueString(),
+		PortalURL:      data.PortalURL.ValueString(),
+		Profile:        data.Profile.ValueString(),
 		SourceChannel:  "terraform-provider-hcp",
+		RateLimit: clients.RateLimitConfig{
+			MaxConcurrentRequests: int(data.MaxConcurrentRequests.ValueInt64()),
+			RequestsPerSecond:     data.RequestsPerSecond.ValueFloat64(),
+		},
 	}
 
 	// Read the workload_identity configuration.
@@ -254,24 +424,110 @@ func (p *ProviderFramework) Configure(ctx context.Context, req provider.Configur
 		}
 	}
 
-	client, err := clients.NewClient(clientConfig)
-	if err != nil {
-		resp.Diagnostics.AddError(fmt.Sprintf("unable to create HCP api client: %v", err), "")
-		return
+	// Read the tls configuration.
+	if len(data.TLS.Elements()) == 1 {
+		elements := make([]TLSFrameworkModel, 0, 1)
+		resp.Diagnostics.Append(data.TLS.ElementsAs(ctx, &elements, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		clientConfig.TLS = clients.TLSConfig{
+			CACertFile:         elements[0].CAFile.ValueString(),
+			InsecureSkipVerify: elements[0].InsecureSkipVerify.ValueBool(),
+		}
+	}
+
+	// Read the operation_wait configuration.
+	if len(data.OperationWait.Elements()) == 1 {
+		elements := make([]OperationWaitFrameworkModel, 0, 1)
+		resp.Diagnostics.Append(data.OperationWait.ElementsAs(ctx, &elements, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		var diags diag.Diagnostics
+		clientConfig, diags = readOperationWait(elements[0], clientConfig)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+	}
+
+	// Read the retry configuration.
+	if len(data.Retry.Elements()) == 1 {
+		elements := make([]RetryFrameworkModel, 0, 1)
+		resp.Diagnostics.Append(data.Retry.ElementsAs(ctx, &elements, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		var diags diag.Diagnostics
+		clientConfig, diags = readRetry(ctx, elements[0], clientConfig)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
 	}
 
 	// Attempt to source from the environment if unset.
+	if clientConfig.OrganizationID == "" {
+		clientConfig.OrganizationID = os.Getenv("HCP_ORGANIZATION_ID")
+	}
 	if clientConfig.ProjectID == "" {
 		clientConfig.ProjectID = os.Getenv("HCP_PROJECT_ID")
 	}
 
-	if clientConfig.ProjectID != "" {
+	// Fall back to the hcp CLI profile.
+	clientConfig, err := clients.ApplyProfile(clientConfig)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("unable to load hcp CLI profile: %v", err), "")
+		return
+	}
+
+	// The client is shared with the SDKv2 provider, so the default project is
+	// only resolved by whichever provider is configured first.
+	var resolveDiags diag.Diagnostics
+	client, err := clients.NewSharedClient(clientConfig, func(client *clients.Client) error {
+		resolveDiags = resolveProject(ctx, client)
+		if resolveDiags.HasError() {
+			return errors.New("unable to resolve the default project")
+		}
+		return nil
+	})
+	resp.Diagnostics.Append(resolveDiags...)
+	if resolveDiags.HasError() {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("unable to create HCP api client: %v", err), "")
+		return
+	}
+
+	var config ProviderFrameworkConfiguration
+	config.Client = client
+	resp.DataSourceData = client
+	resp.ResourceData = client
+	resp.EphemeralResourceData = client
+}
+
+// resolveProject sets the organization and project the client defaults to,
+// either from the configured project or from the credentials.
+func resolveProject(ctx context.Context, client *clients.Client) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if client.Config.ProjectID != "" {
 		getProjParams := project_service.NewProjectServiceGetParams()
-		getProjParams.ID = clientConfig.ProjectID
-		project, err := clients.RetryProjectServiceGet(client, getProjParams)
+		getProjParams.ID = client.Config.ProjectID
+		project, err := client.Project.ProjectServiceGet(getProjParams, nil)
 		if err != nil {
-			resp.Diagnostics.AddError(fmt.Sprintf("unable to fetch project %q: %v", clientConfig.ProjectID, err), "")
-			return
+			diags.AddError(fmt.Sprintf("unable to fetch project %q: %v", client.Config.ProjectID, err), "")
+			return diags
+		}
+
+		if client.Config.OrganizationID != "" && client.Config.OrganizationID != project.Payload.Project.Parent.ID {
+			diags.AddError(fmt.Sprintf("project %q does not belong to organization %q", client.Config.ProjectID, client.Config.OrganizationID), "")
+			return diags
 		}
 
 		client.Config.ProjectID = project.Payload.Project.ID
@@ -283,24 +539,24 @@ func (p *ProviderFramework) Configure(ctx context.Context, req provider.Configur
 		// user to set it. Once multiple projects are available, this helper issues a warning: when multiple projects exist within the org,
 		// a project ID should be set on the provider or on each resource. Otherwise, the oldest project will be used by default.
 		// This helper will eventually be deprecated after a migration period.
-		project, projDiags := getProjectFromCredentialsFramework(ctx, client)
+		orgID, project, projDiags := getProjectFromCredentialsFramework(ctx, client)
 		if projDiags != nil {
 			if !projDiags.HasError() {
-				resp.Diagnostics.Append(projDiags...)
+				diags.Append(projDiags...)
 			} else {
-				resp.Diagnostics.AddError("unable to get project from credentials", "")
-				return
+				diags.AddError("unable to get project from credentials", "")
+				return diags
 			}
 		}
 
-		client.Config.OrganizationID = project.Parent.ID
-		client.Config.ProjectID = project.ID
+		// Without a project, only organization scoped resources can be used.
+		client.Config.OrganizationID = orgID
+		if project != nil {
+			client.Config.ProjectID = project.ID
+		}
 	}
 
-	var config ProviderFrameworkConfiguration
-	config.Client = client
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	return diags
 }
 
 func readWorkloadIdentity(model WorkloadIdentityFrameworkModel, clientConfig clients.ClientConfig) (clients.ClientConfig, diag.Diagnostics) {
@@ -316,3 +572,45 @@ func readWorkloadIdentity(model WorkloadIdentityFrameworkModel, clientConfig cli
 	}
 	return clientConfig, diags
 }
+
+func readOperationWait(model OperationWaitFrameworkModel, clientConfig clients.ClientConfig) (clients.ClientConfig, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	clientConfig.OperationWait.MaxConsecutiveErrors = int(model.MaxConsecutiveErrors.ValueInt64())
+
+	if pi := model.PollInterval.ValueString(); pi != "" {
+		pollInterval, err := time.ParseDuration(pi)
+		if err != nil || pollInterval <= 0 {
+			diags.AddError("invalid operation_wait", fmt.Sprintf("`poll_interval` must be a positive duration, got %q", pi))
+		} else {
+			clientConfig.OperationWait.PollInterval = pollInterval
+		}
+	}
+
+	return clientConfig, diags
+}
+
+func readRetry(ctx context.Context, model RetryFrameworkModel, clientConfig clients.ClientConfig) (clients.ClientConfig, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	clientConfig.Retry.MaxAttempts = int(model.MaxAttempts.ValueInt64())
+
+	if mb := model.MaxBackoff.ValueString(); mb != "" {
+		maxBackoff, err := time.ParseDuration(mb)
+		if err != nil || maxBackoff <= 0 {
+			diags.AddError("invalid retry", fmt.Sprintf("`max_backoff` must be a positive duration, got %q", mb))
+		} else {
+			clientConfig.Retry.MaxBackoff = maxBackoff
+		}
+	}
+
+	if !model.RetryableStatusCodes.IsNull() && !model.RetryableStatusCodes.IsUnknown() {
+		var codes []int64
+		diags.Append(model.RetryableStatusCodes.ElementsAs(ctx, &codes, false)...)
+		for _, code := range codes {
+			clientConfig.Retry.RetryableStatusCodes = append(clientConfig.Retry.RetryableStatusCodes, int(code))
+		}
+	}
+
+	return clientConfig, diags
+}
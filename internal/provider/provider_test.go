@@ -4,9 +4,12 @@
 package provider
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 
@@ -75,3 +78,104 @@ func Test_readWorkloadIdentity(t *testing.T) {
 		})
 	}
 }
+
+func Test_readRetry(t *testing.T) {
+	tcs := map[string]struct {
+		model     RetryFrameworkModel
+		want      clients.ClientConfig
+		wantDiags diag.Diagnostics
+	}{
+		"empty block": {
+			model: RetryFrameworkModel{
+				MaxAttempts:          basetypes.NewInt64Null(),
+				MaxBackoff:           basetypes.NewStringNull(),
+				RetryableStatusCodes: basetypes.NewListNull(basetypes.Int64Type{}),
+			},
+		},
+		"all fields": {
+			model: RetryFrameworkModel{
+				MaxAttempts: basetypes.NewInt64Value(5),
+				MaxBackoff:  basetypes.NewStringValue("30s"),
+				RetryableStatusCodes: basetypes.NewListValueMust(basetypes.Int64Type{}, []attr.Value{
+					basetypes.NewInt64Value(429),
+					basetypes.NewInt64Value(503),
+				}),
+			},
+			want: clients.ClientConfig{
+				Retry: clients.RetryConfig{
+					MaxAttempts:          5,
+					MaxBackoff:           30 * time.Second,
+					RetryableStatusCodes: []int{429, 503},
+				},
+			},
+		},
+		"invalid max_backoff": {
+			model: RetryFrameworkModel{
+				MaxAttempts:          basetypes.NewInt64Null(),
+				MaxBackoff:           basetypes.NewStringValue("soon"),
+				RetryableStatusCodes: basetypes.NewListNull(basetypes.Int64Type{}),
+			},
+			wantDiags: diag.Diagnostics{
+				diag.NewErrorDiagnostic("invalid retry", "`max_backoff` must be a positive duration, got \"soon\""),
+			},
+		},
+	}
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			got, gotDiags := readRetry(context.Background(), tc.model, clients.ClientConfig{})
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected result (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantDiags, gotDiags); diff != "" {
+				t.Errorf("unexpected diagnostics (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_readOperationWait(t *testing.T) {
+	tcs := map[string]struct {
+		model     OperationWaitFrameworkModel
+		want      clients.ClientConfig
+		wantDiags diag.Diagnostics
+	}{
+		"empty block": {
+			model: OperationWaitFrameworkModel{
+				PollInterval:         basetypes.NewStringNull(),
+				MaxConsecutiveErrors: basetypes.NewInt64Null(),
+			},
+		},
+		"all fields": {
+			model: OperationWaitFrameworkModel{
+				PollInterval:         basetypes.NewStringValue("10s"),
+				MaxConsecutiveErrors: basetypes.NewInt64Value(8),
+			},
+			want: clients.ClientConfig{
+				OperationWait: clients.OperationWaitConfig{
+					PollInterval:         10 * time.Second,
+					MaxConsecutiveErrors: 8,
+				},
+			},
+		},
+		"invalid poll_interval": {
+			model: OperationWaitFrameworkModel{
+				PollInterval:         basetypes.NewStringValue("-1s"),
+				MaxConsecutiveErrors: basetypes.NewInt64Null(),
+			},
+			wantDiags: diag.Diagnostics{
+				diag.NewErrorDiagnostic("invalid operation_wait", "`poll_interval` must be a positive duration, got \"-1s\""),
+			},
+		},
+	}
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			got, gotDiags := readOperationWait(tc.model, clients.ClientConfig{})
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected result (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantDiags, gotDiags); diff != "" {
+				t.Errorf("unexpected diagnostics (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
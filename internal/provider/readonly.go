@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// envVarReadOnly enables the read-only mode when read_only is not configured.
+const envVarReadOnly = "HCP_READ_ONLY"
+
+// readOnlyFromConfig reads the read_only setting from the provider
+// configuration, falling back to HCP_READ_ONLY. An unknown value enables the
+// read-only mode, so that a protected workspace never plans changes by
+// accident.
+func readOnlyFromConfig(config *tfprotov6.DynamicValue, typ tftypes.Type) (bool, error) {
+	if config != nil {
+		val, err := config.Unmarshal(typ)
+		if err != nil {
+			return false, fmt.Errorf("unable to read the provider configuration: %w", err)
+		}
+
+		var attrs map[string]tftypes.Value
+		if val.IsKnown() && !val.IsNull() {
+			if err := val.As(&attrs); err != nil {
+				return false, fmt.Errorf("unable to read the provider configuration: %w", err)
+			}
+		}
+
+		if v, ok := attrs["read_only"]; ok && !v.IsNull() {
+			if !v.IsKnown() {
+				return true, nil
+			}
+
+			var readOnly bool
+			if err := v.As(&readOnly); err != nil {
+				return false, fmt.Errorf("unable to read read_only: %w", err)
+			}
+			return readOnly, nil
+		}
+	}
+
+	env := os.Getenv(envVarReadOnly)
+	if env == "" {
+		return false, nil
+	}
+
+	readOnly, err := strconv.ParseBool(env)
+	if err != nil {
+		return false, fmt.Errorf("%s must be a boolean, got %q", envVarReadOnly, env)
+	}
+	return readOnly, nil
+}
+
+func readOnlyDiagnostic(typeName, action string) *tfprotov6.Diagnostic {
+	return &tfprotov6.Diagnostic{
+		Severity: tfprotov6.DiagnosticSeverityError,
+		Summary:  "Provider is read-only",
+		Detail: fmt.Sprintf("The HCP provider is configured as read-only, so the %s resource cannot be %s. "+
+			"Unset read_only in the provider configuration, or the %s environment variable, to allow changes.", typeName, action, envVarReadOnly),
+	}
+}
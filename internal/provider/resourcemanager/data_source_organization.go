@@ -21,9 +21,10 @@ type DataSourceOrganization struct {
 }
 
 type DataSourceOrganizationModel struct {
-	Name         types.String `tfsdk:"name"`
-	ResourceName types.String `tfsdk:"resource_name"`
-	ResourceID   types.String `tfsdk:"resource_id"`
+	OrganizationID types.String `tfsdk:"organization_id"`
+	Name           types.String `tfsdk:"name"`
+	ResourceName   types.String `tfsdk:"resource_name"`
+	ResourceID     types.String `tfsdk:"resource_id"`
 }
 
 func NewOrganizationDataSource() datasource.DataSource {
@@ -36,8 +37,12 @@ func (d *DataSourceOrganization) Metadata(ctx context.Context, req datasource.Me
 
 func (d *DataSourceOrganization) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "The organization data source retrieves the HCP organization the provider is configured for.",
+		MarkdownDescription: "The organization data source retrieves the HCP organization the provider is configured for, or the given organization.",
 		Attributes: map[string]schema.Attribute{
+			"organization_id": schema.StringAttribute{
+				Description: "The ID of the HCP organization to retrieve. If unspecified, the organization configured on the provider is used.",
+				Optional:    true,
+			},
 			"name": schema.StringAttribute{
 				Description: "The organization's name.",
 				Computed:    true,
@@ -74,8 +79,8 @@ func (d *DataSourceOrganization) Read(ctx context.Context, req datasource.ReadRe
 	var data DataSourceOrganizationModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 
-	// Get the ID from the provider
-	id := d.client.Config.OrganizationID
+	// Get the ID from the configuration or the provider
+	id, _ := d.client.Location(data.OrganizationID, types.StringNull())
 
 	getParams := organization_service.NewOrganizationServiceGetParams()
 	getParams.ID = id
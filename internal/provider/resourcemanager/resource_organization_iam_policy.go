@@ -9,8 +9,12 @@ import (
 	"github.com/hashicorp/hcp-sdk-go/clients/cloud-resource-manager/stable/2019-12-10/client/organization_service"
 	"github.com/hashicorp/hcp-sdk-go/clients/cloud-resource-manager/stable/2019-12-10/models"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
 	"github.com/hashicorp/terraform-provider-hcp/internal/clients/iampolicy"
 	"github.com/hashicorp/terraform-provider-hcp/internal/customdiags"
@@ -27,6 +31,15 @@ func orgIAMSchema(binding bool) schema.Schema {
 
 	return schema.Schema{
 		MarkdownDescription: d,
+		Attributes: map[string]schema.Attribute{
+			"organization_id": schema.StringAttribute{
+				Description: "The ID of the HCP organization to apply the IAM Policy to. If unspecified, the organization configured on the provider is used.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
 	}
 }
 
@@ -39,6 +52,7 @@ func NewOrganizationIAMBindingResource() resource.Resource {
 }
 
 type orgIAMPolicyUpdater struct {
+	orgID  string
 	client *clients.Client
 	d      iampolicy.TerraformResourceData
 }
@@ -47,22 +61,28 @@ func newOrgIAMPolicyUpdater(
 	ctx context.Context,
 	d iampolicy.TerraformResourceData,
 	clients *clients.Client) (iampolicy.ResourceIamUpdater, diag.Diagnostics) {
-	var diags diag.Diagnostics
+
+	// Determine the organization ID
+	var orgID types.String
+	diags := d.GetAttribute(ctx, path.Root("organization_id"), &orgID)
+	resolvedOrgID, _ := clients.Location(orgID, types.StringNull())
+
 	return &orgIAMPolicyUpdater{
+		orgID:  resolvedOrgID,
 		client: clients,
 		d:      d,
 	}, diags
 }
 
 func (u *orgIAMPolicyUpdater) GetMutexKey() string {
-	return u.client.Config.OrganizationID
+	return u.orgID
 }
 
 // Fetch the existing IAM policy attached to a resource.
 func (u *orgIAMPolicyUpdater) GetResourceIamPolicy(ctx context.Context) (*models.HashicorpCloudResourcemanagerPolicy, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	params := organization_service.NewOrganizationServiceGetIamPolicyParams()
-	params.ID = u.client.Config.OrganizationID
+	params.ID = u.orgID
 	res, err := u.client.Organization.OrganizationServiceGetIamPolicy(params, nil)
 	if err != nil {
 		serviceErr, ok := err.(*organization_service.OrganizationServiceGetIamPolicyDefault)
@@ -81,7 +101,7 @@ func (u *orgIAMPolicyUpdater) GetResourceIamPolicy(ctx context.Context) (*models
 func (u *orgIAMPolicyUpdater) SetResourceIamPolicy(ctx context.Context, policy *models.HashicorpCloudResourcemanagerPolicy) (*models.HashicorpCloudResourcemanagerPolicy, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	params := organization_service.NewOrganizationServiceSetIamPolicyParams()
-	params.ID = u.client.Config.OrganizationID
+	params.ID = u.orgID
 	params.Body = organization_service.OrganizationServiceSetIamPolicyBody{
 		Policy: policy,
 	}
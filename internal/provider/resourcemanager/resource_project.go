@@ -125,7 +125,7 @@ func (r *resourceProject) Create(ctx context.Context, req resource.CreateRequest
 		},
 	}
 
-	res, err := clients.CreateProjectWithRetry(r.client, createParams)
+	res, err := r.client.Project.ProjectServiceCreate(createParams, nil)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating project", err.Error())
 		return
@@ -168,7 +168,7 @@ func (r *resourceProject) addToBillingAccount(ctx context.Context, projectID str
 
 	updateReq.Body.ProjectIds = append(updateReq.Body.ProjectIds, projectID)
 
-	_, err = clients.RetryBillingServiceUpdate(r.client, updateReq)
+	_, err = r.client.Billing.BillingAccountServiceUpdate(updateReq, nil)
 	if err != nil {
 		return fmt.Errorf("updating billing account failed: %v", err.Error())
 	}
@@ -220,7 +220,7 @@ func (r *resourceProject) Update(ctx context.Context, req resource.UpdateRequest
 			Name: plan.Name.ValueString(),
 		}
 
-		_, err := clients.SetProjectNameWithRetry(r.client, setNameReq)
+		_, err := r.client.Project.ProjectServiceSetName(setNameReq, nil)
 		if err != nil {
 			resp.Diagnostics.AddError("Error updating project name", err.Error())
 			return
@@ -235,7 +235,7 @@ func (r *resourceProject) Update(ctx context.Context, req resource.UpdateRequest
 			Description: plan.Description.ValueString(),
 		}
 
-		_, err := clients.SetProjectDescriptionWithRetry(r.client, setDescReq)
+		_, err := r.client.Project.ProjectServiceSetDescription(setDescReq, nil)
 		if err != nil {
 			resp.Diagnostics.AddError("Error updating project description", err.Error())
 			return
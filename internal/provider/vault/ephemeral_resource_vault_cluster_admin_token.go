@@ -0,0 +1,159 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+)
+
+// adminTokenExpiry is how long after its creation an admin token expires.
+const adminTokenExpiry = 6 * time.Hour
+
+type ephemeralVaultClusterAdminToken struct {
+	client *clients.Client
+}
+
+type ephemeralVaultClusterAdminTokenModel struct {
+	ClusterID types.String `tfsdk:"cluster_id"`
+	ProjectID types.String `tfsdk:"project_id"`
+	CreatedAt types.String `tfsdk:"created_at"`
+	ExpiresAt types.String `tfsdk:"expires_at"`
+	Token     types.String `tfsdk:"token"`
+}
+
+var _ ephemeral.EphemeralResourceWithConfigure = &ephemeralVaultClusterAdminToken{}
+
+func NewVaultClusterAdminTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &ephemeralVaultClusterAdminToken{}
+}
+
+func (r *ephemeralVaultClusterAdminToken) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vault_cluster_admin_token"
+}
+
+func (r *ephemeralVaultClusterAdminToken) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The Vault cluster admin token ephemeral resource generates an admin-level token for the HCP Vault cluster, " +
+			"without storing it in the Terraform plan or state. A new token is generated on every Terraform run.",
+		Attributes: map[string]schema.Attribute{
+			"cluster_id": schema.StringAttribute{
+				Description: "The ID of the HCP Vault cluster.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[-\da-zA-Z]{3,36}$`),
+						"must be between 3 and 36 characters in length and contains only letters, numbers or hyphens",
+					),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				Description: "The ID of the HCP project where the HCP Vault cluster is located. " +
+					"If not specified, the project specified in the HCP Provider config block will be used, if configured.",
+				Optional: true,
+			},
+			"created_at": schema.StringAttribute{
+				Description: "The time that the admin token was created.",
+				Computed:    true,
+			},
+			"expires_at": schema.StringAttribute{
+				Description: "The time that the admin token expires.",
+				Computed:    true,
+			},
+			"token": schema.StringAttribute{
+				Description: "The admin token of this HCP Vault cluster.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+func (r *ephemeralVaultClusterAdminToken) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+func (r *ephemeralVaultClusterAdminToken) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data ephemeralVaultClusterAdminTokenModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured HCP Client",
+			"Expected configured HCP client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	projectID := r.client.Config.ProjectID
+	if !data.ProjectID.IsNull() {
+		projectID = data.ProjectID.ValueString()
+	}
+	if projectID == "" {
+		resp.Diagnostics.AddError("unable to retrieve project ID", "Set project_id, or a project in the HCP Provider config block.")
+		return
+	}
+
+	loc := &sharedmodels.HashicorpCloudLocationLocation{
+		OrganizationID: r.client.Config.OrganizationID,
+		ProjectID:      projectID,
+	}
+	clusterID := data.ClusterID.ValueString()
+
+	tflog.Info(ctx, "reading Vault cluster", map[string]any{"cluster_id": clusterID, "project_id": projectID})
+	cluster, err := clients.GetVaultClusterByID(ctx, r.client, loc, clusterID)
+	if err != nil {
+		if clients.IsResponseCodeNotFound(err) {
+			resp.Diagnostics.AddError(fmt.Sprintf("unable to create admin token; Vault cluster (%s) not found", clusterID), "")
+			return
+		}
+
+		resp.Diagnostics.AddError(fmt.Sprintf("unable to check for presence of an existing Vault cluster (%s): %v", clusterID, err), "")
+		return
+	}
+
+	loc.Region = &sharedmodels.HashicorpCloudLocationRegion{
+		Provider: cluster.Location.Region.Provider,
+		Region:   cluster.Location.Region.Region,
+	}
+
+	tokenResp, err := clients.CreateVaultClusterAdminToken(ctx, r.client, loc, clusterID)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("error creating HCP Vault cluster admin token (cluster_id %q) (project_id %q): %v", clusterID, projectID, err), "")
+		return
+	}
+
+	createdAt := time.Now()
+	data.ProjectID = types.StringValue(projectID)
+	data.CreatedAt = types.StringValue(createdAt.Format(time.RFC3339))
+	data.ExpiresAt = types.StringValue(createdAt.Add(adminTokenExpiry).Format(time.RFC3339))
+	data.Token = types.StringValue(tokenResp.Token)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
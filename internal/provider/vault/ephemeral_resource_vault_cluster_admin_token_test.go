@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/client/network_service"
+	networkmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/models"
+	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
+	vaultmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-vault-service/stable/2020-11-25/models"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients/hcptest"
+)
+
+// TestVaultClusterAdminTokenEphemeral opens the ephemeral admin token against
+// the in-process fake HCP server.
+func TestVaultClusterAdminTokenEphemeral(t *testing.T) {
+	srv := hcptest.NewServer(t)
+	srv.Setenv(t)
+
+	client, err := clients.NewClient(srv.ClientConfig())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ctx := context.Background()
+	loc := srv.Location()
+
+	params := network_service.NewCreateParams()
+	params.NetworkLocationOrganizationID = loc.OrganizationID
+	params.NetworkLocationProjectID = loc.ProjectID
+	params.Body = &networkmodels.HashicorpCloudNetwork20200907CreateRequest{
+		Network: &networkmodels.HashicorpCloudNetwork20200907Network{
+			ID:        "test-hvn",
+			CidrBlock: "172.25.16.0/20",
+			Location: &sharedmodels.HashicorpCloudLocationLocation{
+				OrganizationID: loc.OrganizationID,
+				ProjectID:      loc.ProjectID,
+				Region:         &sharedmodels.HashicorpCloudLocationRegion{Provider: "aws", Region: "us-west-2"},
+			},
+		},
+	}
+	hvnResp, err := client.Network.Create(params, nil)
+	if err != nil {
+		t.Fatalf("unexpected error creating HVN: %v", err)
+	}
+	if err := clients.WaitForOperation(ctx, client, "create HVN", loc, hvnResp.Payload.Operation.ID); err != nil {
+		t.Fatalf("unexpected error waiting for HVN: %v", err)
+	}
+
+	clusterResp, err := clients.CreateVaultCluster(ctx, client, loc, &vaultmodels.HashicorpCloudVault20201125InputCluster{
+		ID: "test-vault",
+		Config: &vaultmodels.HashicorpCloudVault20201125InputClusterConfig{
+			Tier:          vaultmodels.HashicorpCloudVault20201125TierDEV.Pointer(),
+			NetworkConfig: &vaultmodels.HashicorpCloudVault20201125InputNetworkConfig{NetworkID: "test-hvn"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating Vault cluster: %v", err)
+	}
+	if err := clients.WaitForOperation(ctx, client, "create Vault cluster", loc, clusterResp.Operation.ID); err != nil {
+		t.Fatalf("unexpected error waiting for Vault cluster: %v", err)
+	}
+
+	r := NewVaultClusterAdminTokenEphemeralResource().(*ephemeralVaultClusterAdminToken)
+	r.Configure(ctx, ephemeral.ConfigureRequest{ProviderData: client}, &ephemeral.ConfigureResponse{})
+
+	schemaResp := &ephemeral.SchemaResponse{}
+	r.Schema(ctx, ephemeral.SchemaRequest{}, schemaResp)
+	typ := schemaResp.Schema.Type().TerraformType(ctx)
+
+	open := func(clusterID string) *ephemeral.OpenResponse {
+		config := tftypes.NewValue(typ, map[string]tftypes.Value{
+			"cluster_id": tftypes.NewValue(tftypes.String, clusterID),
+			"project_id": tftypes.NewValue(tftypes.String, nil),
+			"created_at": tftypes.NewValue(tftypes.String, nil),
+			"expires_at": tftypes.NewValue(tftypes.String, nil),
+			"token":      tftypes.NewValue(tftypes.String, nil),
+		})
+		resp := &ephemeral.OpenResponse{
+			Result: tfsdk.EphemeralResultData{Schema: schemaResp.Schema, Raw: tftypes.NewValue(typ, nil)},
+		}
+		r.Open(ctx, ephemeral.OpenRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config}}, resp)
+		return resp
+	}
+
+	resp := open("test-vault")
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error opening admin token: %v", resp.Diagnostics)
+	}
+
+	var data ephemeralVaultClusterAdminTokenModel
+	if diags := resp.Result.Get(ctx, &data); diags.HasError() {
+		t.Fatalf("unexpected error reading result: %v", diags)
+	}
+	if data.Token.ValueString() == "" {
+		t.Error("expected a token")
+	}
+	if got := data.ProjectID.ValueString(); got != loc.ProjectID {
+		t.Errorf("expected the provider project %q, got %q", loc.ProjectID, got)
+	}
+	if data.ExpiresAt.ValueString() <= data.CreatedAt.ValueString() {
+		t.Errorf("expected the token to expire after its creation, got %q and %q", data.CreatedAt, data.ExpiresAt)
+	}
+
+	resp = open("does-not-exist")
+	if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics[0].Summary(), "not found") {
+		t.Errorf("expected a not found error, got %v", resp.Diagnostics)
+	}
+}
@@ -166,7 +166,7 @@ func decorateOperation[T hvsResource](ctx context.Context, c *clients.Client, st
 		return diags
 	}
 
-	orgID, projID := c.Location(concreteIntegration.projectID())
+	orgID, projID := c.Location(types.StringNull(), concreteIntegration.projectID())
 	diags.Append(concreteIntegration.initModel(ctx, orgID, projID)...)
 	if diags.HasError() {
 		return diags
@@ -4,6 +4,7 @@
 package providersdkv2
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"encoding/json"
@@ -11,11 +12,13 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+	"gopkg.in/yaml.v3"
 )
 
 // defaultConsulAgentHelmConfigTimeoutDuration is the default timeout
@@ -25,13 +28,45 @@ var defaultConsulAgentHelmConfigTimeoutDuration = time.Minute * 5
 // ConsulConfig represents the Consul configuration that will be
 // decoded from a base64 formatted string.
 type ConsulConfig struct {
-	Datacenter string   `json:"datacenter"`
-	Encrypt    string   `json:"encrypt"`
-	RetryJoin  []string `json:"retry_join"`
+	Datacenter            string            `json:"datacenter"`
+	Encrypt               string            `json:"encrypt"`
+	EncryptVerifyIncoming *bool             `json:"encrypt_verify_incoming"`
+	EncryptVerifyOutgoing *bool             `json:"encrypt_verify_outgoing"`
+	RetryJoin             []string          `json:"retry_join"`
+	Ports                 map[string]int    `json:"ports"`
+	VerifyOutgoing        *bool             `json:"verify_outgoing"`
+	VerifyServerHostname  *bool             `json:"verify_server_hostname"`
+	AutoEncrypt           ConsulAutoEncrypt `json:"auto_encrypt"`
+	TLS                   ConsulTLS         `json:"tls"`
 }
 
+// ConsulAutoEncrypt represents the auto_encrypt stanza of a Consul configuration.
+type ConsulAutoEncrypt struct {
+	TLS bool `json:"tls"`
+}
+
+// ConsulTLS represents the tls stanza that replaces the top-level TLS
+// settings of a Consul configuration as of Consul 1.12.
+type ConsulTLS struct {
+	Defaults struct {
+		VerifyOutgoing *bool `json:"verify_outgoing"`
+	} `json:"defaults"`
+	InternalRPC struct {
+		VerifyServerHostname *bool `json:"verify_server_hostname"`
+	} `json:"internal_rpc"`
+}
+
+// consulK8sAgentlessVersion is the first version of the consul-k8s Helm chart
+// that connects to the Consul servers without running client agents.
+var consulK8sAgentlessVersion = version.Must(version.NewVersion("1.0.0"))
+
+// defaultConsulGRPCTLSPort is the gRPC TLS port of HCP Consul servers, used
+// when the client config does not specify one.
+const defaultConsulGRPCTLSPort = 8502
+
 // helmConfigTemplate is the template used to generate a helm
-// config for an AKS cluster based on given inputs.
+// config for an AKS cluster based on given inputs, for consul-k8s
+// chart versions before 1.0.0.
 //
 // see generateHelmConfig for details on the inputs passed in
 const helmConfigTemplate = `global:
@@ -65,6 +100,48 @@ client:
 connectInject:
   enabled: true`
 
+// helmConfigAgentlessTemplate is the template used to generate a helm
+// config based on given inputs, for consul-k8s chart versions 1.0.0
+// and later.
+//
+// see generateAgentlessHelmConfig for details on the inputs passed in
+const helmConfigAgentlessTemplate = `global:
+  enabled: false
+  name: consul
+  datacenter: %[1]s
+  acls:
+    manageSystemACLs: true
+    bootstrapToken:
+      secretName: %[2]s-bootstrap-token
+      secretKey: token
+  tls:
+    enabled: true
+    caCert:
+      secretName: %[2]s-hcp
+      secretKey: caCert
+  cloud:
+    enabled: true
+    resourceId:
+      secretName: %[2]s-hcp
+      secretKey: resourceId
+    clientId:
+      secretName: %[2]s-hcp
+      secretKey: clientId
+    clientSecret:
+      secretName: %[2]s-hcp
+      secretKey: clientSecret
+externalServers:
+  enabled: true
+  hosts: %[3]s
+  httpsPort: 443
+  grpcPort: %[5]d
+  useSystemRoots: true
+  k8sAuthMethodHost: https://%[4]s:443
+server:
+  enabled: false
+connectInject:
+  enabled: true`
+
 func dataSourceConsulAgentHelmConfig() *schema.Resource {
 	return &schema.Resource{
 		DeprecationMessage: "HashiCorp plans to sunset HashiCorp Consul Dedicated (HCD) in November 2025, more information about the EOL will be provided to existing customers directly",
@@ -88,12 +165,30 @@ func dataSourceConsulAgentHelmConfig() *schema.Resource {
 				ValidateDiagFunc: validateStringNotEmpty,
 			},
 			// Optional inputs
+			"chart_version": {
+				Description:      "The version of the consul-k8s Helm chart to generate the config for. Chart versions `1.0.0` and later connect to the HCP Consul cluster without client agents, and their config links the Kubernetes cluster to HCP. If not specified, the config is generated for a chart version before `1.0.0`.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validateSemVer,
+			},
 			"expose_gossip_ports": {
-				Description: "Denotes that the gossip ports should be exposed.",
+				Description: "Denotes that the gossip ports should be exposed. Only used for chart versions before `1.0.0`.",
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     false,
 			},
+			"override_values": {
+				Description: "Helm values, in YAML or JSON, to merge into the generated config. Maps are merged recursively; any other value replaces the generated one.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"output_format": {
+				Description:  "The format of the generated config: `yaml` or `json`. Defaults to `yaml`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "yaml",
+				ValidateFunc: validation.StringInSlice([]string{"yaml", "json"}, false),
+			},
 			"project_id": {
 				Description: `
 The ID of the HCP project where the HCP Consul cluster is located.
@@ -167,13 +262,36 @@ func dataSourceConsulAgentHelmConfigRead(ctx context.Context, d *schema.Resource
 		return diag.FromErr(fmt.Errorf("failed to json unmarshal consul config %v", err))
 	}
 
-	// generate helm config and set on data source
-	if err := d.Set("config", generateHelmConfig(
-		cluster.ID,
-		cluster.Config.ConsulConfig.Datacenter,
-		d.Get("kubernetes_endpoint").(string),
-		consulConfig.RetryJoin,
-		d.Get("expose_gossip_ports").(bool))); err != nil {
+	// generate helm config for the chart version
+	var config string
+	if chartVersion, ok := d.GetOk("chart_version"); ok && version.Must(version.NewVersion(chartVersion.(string))).GreaterThanOrEqual(consulK8sAgentlessVersion) {
+		grpcPort, ok := consulConfig.Ports["grpc_tls"]
+		if !ok {
+			grpcPort = defaultConsulGRPCTLSPort
+		}
+
+		config = generateAgentlessHelmConfig(
+			cluster.ID,
+			cluster.Config.ConsulConfig.Datacenter,
+			d.Get("kubernetes_endpoint").(string),
+			consulConfig.RetryJoin,
+			grpcPort)
+	} else {
+		config = generateHelmConfig(
+			cluster.ID,
+			cluster.Config.ConsulConfig.Datacenter,
+			d.Get("kubernetes_endpoint").(string),
+			consulConfig.RetryJoin,
+			d.Get("expose_gossip_ports").(bool))
+	}
+
+	config, err = formatHelmConfig(config, d.Get("override_values").(string), d.Get("output_format").(string))
+	if err != nil {
+		return diag.Errorf("unable to generate Consul agent Helm config: %v", err)
+	}
+
+	// set helm config on data source
+	if err := d.Set("config", config); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -195,11 +313,7 @@ func generateHelmConfig(name, datacenter, fqdn string, retryJoin []string, expos
 	// lowercase the name
 	lower := strings.ToLower(name)
 
-	// print retryJoin a double-quoted string safely escaped with Go syntax
-	rj := fmt.Sprintf("%q", retryJoin)
-
-	// replace any escaped double-quotes with single quotes
-	rj = strings.ReplaceAll(rj, "\"", "'")
+	rj := helmConfigHosts(retryJoin)
 
 	// trim off any leading `https://` protocol if present.
 	// this protocol will be prepended as expected when
@@ -223,3 +337,166 @@ func generateHelmConfig(name, datacenter, fqdn string, retryJoin []string, expos
 		rj,
 	)
 }
+
+// generateAgentlessHelmConfig will generate a helm config for consul-k8s chart
+// versions 1.0.0 and later based on the passed in name, data center, fqdn,
+// retry join and gRPC port.
+func generateAgentlessHelmConfig(name, datacenter, fqdn string, retryJoin []string, grpcPort int) string {
+	// see generateHelmConfig for the handling of the fqdn
+	return fmt.Sprintf(helmConfigAgentlessTemplate,
+		datacenter,
+		strings.ToLower(name),
+		helmConfigHosts(retryJoin),
+		strings.TrimPrefix(fqdn, "https://"),
+		grpcPort,
+	)
+}
+
+// helmConfigHosts formats hosts as a YAML flow sequence.
+func helmConfigHosts(hosts []string) string {
+	// print hosts a double-quoted string safely escaped with Go syntax
+	h := fmt.Sprintf("%q", hosts)
+
+	// replace any escaped double-quotes with single quotes
+	return strings.ReplaceAll(h, "\"", "'")
+}
+
+// formatHelmConfig merges the override values into the generated helm config
+// and encodes it in the output format. The generated config is returned as is
+// when there is nothing to merge and the output format is YAML.
+func formatHelmConfig(config, overrideValues, outputFormat string) (string, error) {
+	if overrideValues == "" && outputFormat == "yaml" {
+		return config, nil
+	}
+
+	var values yaml.Node
+	if err := yaml.Unmarshal([]byte(config), &values); err != nil {
+		return "", fmt.Errorf("unable to parse generated config: %w", err)
+	}
+
+	if overrideValues != "" {
+		var overrides yaml.Node
+		if err := yaml.Unmarshal([]byte(overrideValues), &overrides); err != nil {
+			return "", fmt.Errorf("unable to parse override_values: %w", err)
+		}
+
+		// empty override values decode to an empty document
+		if len(overrides.Content) > 0 {
+			if overrides.Content[0].Kind != yaml.MappingNode {
+				return "", fmt.Errorf("override_values must be a map of Helm values")
+			}
+
+			// the overrides are restyled, so that JSON values render as block YAML
+			resetHelmValuesStyle(overrides.Content[0])
+			mergeHelmValues(values.Content[0], overrides.Content[0])
+		}
+	}
+
+	var buf bytes.Buffer
+	switch outputFormat {
+	case "json":
+		var compact bytes.Buffer
+		if err := writeHelmValuesJSON(&compact, values.Content[0]); err != nil {
+			return "", err
+		}
+		if err := json.Indent(&buf, compact.Bytes(), "", "  "); err != nil {
+			return "", err
+		}
+	default:
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(&values); err != nil {
+			return "", err
+		}
+		if err := enc.Close(); err != nil {
+			return "", err
+		}
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// mergeHelmValues merges the src mapping node into the dst mapping node. Maps
+// are merged recursively; any other src value replaces the dst value.
+func mergeHelmValues(dst, src *yaml.Node) {
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		key, value := src.Content[i], src.Content[i+1]
+
+		merged := false
+		for j := 0; j+1 < len(dst.Content); j += 2 {
+			if dst.Content[j].Value != key.Value {
+				continue
+			}
+
+			if dst.Content[j+1].Kind == yaml.MappingNode && value.Kind == yaml.MappingNode {
+				mergeHelmValues(dst.Content[j+1], value)
+			} else {
+				dst.Content[j+1] = value
+			}
+			merged = true
+			break
+		}
+
+		if !merged {
+			dst.Content = append(dst.Content, key, value)
+		}
+	}
+}
+
+// resetHelmValuesStyle clears the style of a node and its children.
+func resetHelmValuesStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		resetHelmValuesStyle(c)
+	}
+}
+
+// writeHelmValuesJSON writes a node as JSON, keeping the order of map keys.
+func writeHelmValuesJSON(buf *bytes.Buffer, n *yaml.Node) error {
+	switch n.Kind {
+	case yaml.DocumentNode:
+		return writeHelmValuesJSON(buf, n.Content[0])
+	case yaml.AliasNode:
+		return writeHelmValuesJSON(buf, n.Alias)
+	case yaml.MappingNode:
+		buf.WriteByte('{')
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			key, err := json.Marshal(n.Content[i].Value)
+			if err != nil {
+				return err
+			}
+			buf.Write(key)
+			buf.WriteByte(':')
+			if err := writeHelmValuesJSON(buf, n.Content[i+1]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case yaml.SequenceNode:
+		buf.WriteByte('[')
+		for i, c := range n.Content {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeHelmValuesJSON(buf, c); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	default:
+		var v interface{}
+		if err := n.Decode(&v); err != nil {
+			return err
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("unable to encode %q as JSON: %w", n.Value, err)
+		}
+		buf.Write(b)
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providersdkv2
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden files in testdata")
+
+// TestConsulAgentHelmConfig compares the config generated for each chart
+// version and output format against the golden files in
+// testdata/consul_agent_helm_config. Run the test with -update to regenerate
+// them.
+func TestConsulAgentHelmConfig(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := context.Background()
+
+	createTestHVN(t, ctx, client)
+	createTestConsulCluster(t, ctx, client, map[string]interface{}{
+		"cluster_id": "Test-Consul",
+		"datacenter": "dc1",
+	})
+
+	overrides := `
+global:
+  acls:
+    bootstrapToken:
+      secretName: consul-bootstrap-token
+  cloud:
+    enabled: false
+meshGateway:
+  enabled: true
+  replicas: 2
+`
+
+	tcs := map[string]struct {
+		raw         map[string]interface{}
+		golden      string
+		expectedErr string
+	}{
+		"default": {
+			raw:    map[string]interface{}{},
+			golden: "default.yaml",
+		},
+		"legacy chart": {
+			raw: map[string]interface{}{
+				"chart_version":       "0.49.8",
+				"expose_gossip_ports": true,
+			},
+			golden: "0.49.8.yaml",
+		},
+		"legacy chart json": {
+			raw: map[string]interface{}{
+				"chart_version": "0.49.8",
+				"output_format": "json",
+			},
+			golden: "0.49.8.json",
+		},
+		"agentless chart": {
+			raw: map[string]interface{}{
+				"chart_version": "1.4.0",
+			},
+			golden: "1.4.0.yaml",
+		},
+		"agentless chart json": {
+			raw: map[string]interface{}{
+				"chart_version": "1.4.0",
+				"output_format": "json",
+			},
+			golden: "1.4.0.json",
+		},
+		"agentless chart overrides": {
+			raw: map[string]interface{}{
+				"chart_version":   "1.4.0",
+				"override_values": overrides,
+			},
+			golden: "1.4.0-overrides.yaml",
+		},
+		"agentless chart json overrides": {
+			raw: map[string]interface{}{
+				"chart_version":   "1.4.0",
+				"override_values": `{"connectInject": {"enabled": false, "replicas": 3}, "externalServers": {"hosts": ["consul.example.com"]}}`,
+				"output_format":   "json",
+			},
+			golden: "1.4.0-overrides.json",
+		},
+		"invalid overrides": {
+			raw: map[string]interface{}{
+				"override_values": "- not a map",
+			},
+			expectedErr: "override_values must be a map of Helm values",
+		},
+	}
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			tc.raw["cluster_id"] = "Test-Consul"
+			tc.raw["kubernetes_endpoint"] = "https://k8s.example.com"
+
+			d := schema.TestResourceDataRaw(t, dataSourceConsulAgentHelmConfig().Schema, tc.raw)
+			diags := dataSourceConsulAgentHelmConfigRead(ctx, d, client)
+			if tc.expectedErr != "" {
+				if !diags.HasError() || !strings.Contains(diags[0].Summary, tc.expectedErr) {
+					t.Errorf("expected error to contain %q, got %v", tc.expectedErr, diags)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected error reading Consul agent Helm config: %v", diags)
+			}
+
+			config := d.Get("config").(string)
+			golden := filepath.Join("testdata", "consul_agent_helm_config", tc.golden)
+			if *updateGolden {
+				if err := os.WriteFile(golden, []byte(config+"\n"), 0644); err != nil {
+					t.Fatalf("unexpected error updating golden file: %v", err)
+				}
+			}
+
+			expected, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("unexpected error reading golden file: %v", err)
+			}
+			if config+"\n" != string(expected) {
+				t.Errorf("config does not match %s:\n%s", golden, config)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providersdkv2
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+)
+
+func dataSourceConsulClusterClientConfig() *schema.Resource {
+	return &schema.Resource{
+		DeprecationMessage: "HashiCorp plans to sunset HashiCorp Consul Dedicated (HCD) in November 2025, more information about the EOL will be provided to existing customers directly",
+		Description: "The Consul cluster client config data source decodes the client configuration of an HCP Consul cluster " +
+			"into typed attributes, for bootstrapping Consul agents without decoding `consul_config_file` and `consul_ca_file` by hand.",
+		Timeouts: &schema.ResourceTimeout{
+			Default: &defaultConsulAgentHelmConfigTimeoutDuration,
+		},
+		ReadContext: dataSourceConsulClusterClientConfigRead,
+		Schema: map[string]*schema.Schema{
+			// Required inputs
+			"cluster_id": {
+				Description:      "The ID of the HCP Consul cluster.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateSlugID,
+			},
+			// Optional inputs
+			"project_id": {
+				Description: `
+The ID of the HCP project where the HCP Consul cluster is located.
+If not specified, the project specified in the HCP Provider config block will be used, if configured.
+If a project is not configured in the HCP Provider config block, the oldest project in the organization will be used.`,
+				Type:         schema.TypeString,
+				Computed:     true,
+				Optional:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			// Computed outputs
+			"datacenter": {
+				Description: "The Consul datacenter of the cluster.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"retry_join": {
+				Description: "The addresses Consul agents join the cluster through.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ports": {
+				Description: "The ports set in the client config, keyed by name, such as `grpc_tls`.",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+			"tls": {
+				Description: "The TLS settings of the client config.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"auto_encrypt": {
+							Description: "Whether agents request their TLS certificates from the servers.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"verify_outgoing": {
+							Description: "Whether agents verify the TLS certificates of the servers.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"verify_server_hostname": {
+							Description: "Whether agents verify the hostname in the TLS certificates of the servers.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"gossip_encryption": {
+				Description: "The gossip encryption settings of the client config.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Description: "The gossip encryption key.",
+							Type:        schema.TypeString,
+							Computed:    true,
+							Sensitive:   true,
+						},
+						"verify_incoming": {
+							Description: "Whether incoming gossip must be encrypted.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"verify_outgoing": {
+							Description: "Whether outgoing gossip is encrypted.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"ca_pem": {
+				Description: "The PEM encoded certificate authority of the cluster.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// dataSourceConsulClusterClientConfigRead decodes the Consul client config
+// files of an HCP cluster.
+func dataSourceConsulClusterClientConfigRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client)
+
+	clusterID := d.Get("cluster_id").(string)
+
+	projectID, err := GetProjectID(d.Get("project_id").(string), client.Config.ProjectID)
+	if err != nil {
+		return diag.Errorf("unable to retrieve project ID: %v", err)
+	}
+
+	loc := &models.HashicorpCloudLocationLocation{
+		OrganizationID: client.Config.OrganizationID,
+		ProjectID:      projectID,
+	}
+
+	// get the cluster's Consul client config files
+	clientConfigFiles, err := clients.GetConsulClientConfigFiles(ctx, client, loc, clusterID)
+	if err != nil {
+		if clients.IsResponseCodeNotFound(err) {
+			return diag.Errorf("unable to read Consul client config; Consul cluster (%s) not found", clusterID)
+		}
+
+		return diag.Errorf("unable to retrieve Consul cluster (%s) client config files: %v", clusterID, err)
+	}
+
+	// pull off the config string
+	configStr := clientConfigFiles.ConsulConfigFile.String()
+
+	// decode it
+	consulConfigJSON, err := base64.StdEncoding.DecodeString(configStr)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("unable to base64 decode Consul config (%v): %v", configStr, err))
+	}
+
+	// unmarshal from JSON
+	var consulConfig ConsulConfig
+	err = json.Unmarshal(consulConfigJSON, &consulConfig)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to json unmarshal consul config %v", err))
+	}
+
+	// The tls stanza takes precedence over the deprecated top-level settings.
+	verifyOutgoing := consulConfig.TLS.Defaults.VerifyOutgoing
+	if verifyOutgoing == nil {
+		verifyOutgoing = consulConfig.VerifyOutgoing
+	}
+	verifyServerHostname := consulConfig.TLS.InternalRPC.VerifyServerHostname
+	if verifyServerHostname == nil {
+		verifyServerHostname = consulConfig.VerifyServerHostname
+	}
+
+	var gossipEncryption []interface{}
+	if consulConfig.Encrypt != "" {
+		// Consul verifies gossip encryption both ways unless told otherwise.
+		gossipEncryption = []interface{}{
+			map[string]interface{}{
+				"key":             consulConfig.Encrypt,
+				"verify_incoming": consulConfigBool(consulConfig.EncryptVerifyIncoming, true),
+				"verify_outgoing": consulConfigBool(consulConfig.EncryptVerifyOutgoing, true),
+			},
+		}
+	}
+
+	attrs := map[string]interface{}{
+		"project_id": projectID,
+		"datacenter": consulConfig.Datacenter,
+		"retry_join": consulConfig.RetryJoin,
+		"ports":      consulConfig.Ports,
+		"tls": []interface{}{
+			map[string]interface{}{
+				"auto_encrypt":           consulConfig.AutoEncrypt.TLS,
+				"verify_outgoing":        consulConfigBool(verifyOutgoing, false),
+				"verify_server_hostname": consulConfigBool(verifyServerHostname, false),
+			},
+		},
+		"gossip_encryption": gossipEncryption,
+		"ca_pem":            string(clientConfigFiles.CaFile),
+	}
+	for k, v := range attrs {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	// build ID and set it
+	link := newLink(loc, ConsulClusterClientConfigDataSourceType, clusterID)
+	url, err := linkURL(link)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(url)
+
+	return nil
+}
+
+// consulConfigBool returns the value of a boolean Consul setting, or its
+// default if the setting is absent from the config.
+func consulConfigBool(v *bool, def bool) bool {
+	if v == nil {
+		return def
+	}
+	return *v
+}
@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providersdkv2
+
+import (
+	"context"
+	"encoding/pem"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// TestConsulClusterClientConfig decodes the client config of a cluster on the
+// in-process fake HCP server.
+func TestConsulClusterClientConfig(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := context.Background()
+
+	createTestHVN(t, ctx, client)
+	cluster := createTestConsulCluster(t, ctx, client, nil)
+
+	missing := schema.TestResourceDataRaw(t, dataSourceConsulClusterClientConfig().Schema, map[string]interface{}{
+		"cluster_id": "missing-consul",
+	})
+	if diags := dataSourceConsulClusterClientConfigRead(ctx, missing, client); !diags.HasError() {
+		t.Fatal("expected an error for a missing cluster, got none")
+	}
+
+	d := schema.TestResourceDataRaw(t, dataSourceConsulClusterClientConfig().Schema, map[string]interface{}{
+		"cluster_id": "test-consul",
+	})
+	if diags := dataSourceConsulClusterClientConfigRead(ctx, d, client); diags.HasError() {
+		t.Fatalf("unexpected error reading Consul client config: %v", diags)
+	}
+
+	if d.Get("datacenter").(string) != cluster.Get("datacenter").(string) {
+		t.Errorf("expected datacenter %q, got %q", cluster.Get("datacenter"), d.Get("datacenter"))
+	}
+	if retryJoin := d.Get("retry_join").([]interface{}); len(retryJoin) != 1 || retryJoin[0] == "" {
+		t.Errorf("unexpected retry_join: %v", retryJoin)
+	}
+	if port := d.Get("ports.grpc_tls"); port != 8502 {
+		t.Errorf("expected grpc_tls port 8502, got %v", port)
+	}
+
+	expected := map[string]interface{}{
+		"tls.0.auto_encrypt":                  true,
+		"tls.0.verify_outgoing":               true,
+		"tls.0.verify_server_hostname":        true,
+		"gossip_encryption.0.verify_incoming": true,
+		"gossip_encryption.0.verify_outgoing": true,
+	}
+	for k, v := range expected {
+		if got := d.Get(k); got != v {
+			t.Errorf("expected %s to be %v, got %v", k, v, got)
+		}
+	}
+	if d.Get("gossip_encryption.0.key").(string) == "" {
+		t.Error("expected gossip_encryption.0.key to be set")
+	}
+
+	block, _ := pem.Decode([]byte(d.Get("ca_pem").(string)))
+	if block == nil || block.Type != "CERTIFICATE" {
+		t.Errorf("expected ca_pem to be a PEM encoded certificate, got %q", d.Get("ca_pem"))
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providersdkv2
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strconv"
+	"time"
+
+	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+)
+
+func dataSourceConsulSnapshots() *schema.Resource {
+	return &schema.Resource{
+		DeprecationMessage: "HashiCorp plans to sunset HashiCorp Consul Dedicated (HCD) in November 2025, more information about the EOL will be provided to existing customers directly",
+		Description: "The Consul snapshots data source lists the snapshots of an HCP Consul cluster, oldest first. " +
+			"Snapshots currently have a retention policy of 30 days.",
+		ReadContext: dataSourceConsulSnapshotsRead,
+		Timeouts: &schema.ResourceTimeout{
+			Default: &defaultSnapshotTimeoutDuration,
+		},
+		Schema: map[string]*schema.Schema{
+			// Required inputs
+			"cluster_id": {
+				Description:      "The ID of the HCP Consul cluster.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateSlugID,
+			},
+			// Optional inputs
+			"project_id": {
+				Description: `
+The ID of the HCP project where the HCP Consul cluster is located.
+If not specified, the project specified in the HCP Provider config block will be used, if configured.
+If a project is not configured in the HCP Provider config block, the oldest project in the organization will be used.`,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsUUID,
+				Computed:     true,
+			},
+			// Computed outputs
+			"snapshots": {
+				Description: "The snapshots of the HCP Consul cluster.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"snapshot_id": {
+							Description: "The ID of the Consul snapshot.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"snapshot_name": {
+							Description: "The name of the snapshot.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"type": {
+							Description: "The type of the snapshot: `MANUAL`, `AUTOMATIC` or `SCHEDULED`.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"state": {
+							Description: "The state of the Consul snapshot.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"size": {
+							Description: "The size of the snapshot in bytes.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"consul_version": {
+							Description: "The version of Consul at the time of snapshot creation.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"created_at": {
+							Description: "The time that the snapshot was requested.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"finished_at": {
+							Description: "The time that the snapshot finished. Blank while the snapshot is being created.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"restored_at": {
+							Description: "The time that the snapshot was last restored. Blank if the snapshot has not been restored.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceConsulSnapshotsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client)
+
+	clusterID := d.Get("cluster_id").(string)
+
+	projectID, err := GetProjectID(d.Get("project_id").(string), client.Config.ProjectID)
+	if err != nil {
+		return diag.Errorf("unable to retrieve project ID: %v", err)
+	}
+
+	loc := &sharedmodels.HashicorpCloudLocationLocation{
+		OrganizationID: client.Config.OrganizationID,
+		ProjectID:      projectID,
+	}
+
+	log.Printf("[INFO] Listing snapshots for Consul cluster (%s) [project_id=%s, organization_id=%s]", clusterID, loc.ProjectID, loc.OrganizationID)
+
+	snapshots, err := clients.ListSnapshots(ctx, client, loc, clusterID)
+	if err != nil {
+		return diag.Errorf("unable to list snapshots of Consul cluster (%s): %v", clusterID, err)
+	}
+
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return time.Time(snapshots[i].CreatedAt).Before(time.Time(snapshots[j].CreatedAt))
+	})
+
+	flattened := make([]interface{}, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		s := map[string]interface{}{
+			"snapshot_id":   snapshot.ID,
+			"snapshot_name": snapshot.Name,
+			"created_at":    snapshot.CreatedAt.String(),
+		}
+		if snapshot.Type != nil {
+			s["type"] = string(*snapshot.Type)
+		}
+		if snapshot.State != nil {
+			s["state"] = string(*snapshot.State)
+		}
+		if !time.Time(snapshot.FinishedAt).IsZero() {
+			s["finished_at"] = snapshot.FinishedAt.String()
+		}
+		if snapshot.Meta != nil {
+			s["consul_version"] = snapshot.Meta.ProductVersion
+			if snapshot.Meta.Size != "" {
+				size, err := strconv.Atoi(snapshot.Meta.Size)
+				if err != nil {
+					return diag.Errorf("unable to parse size of Consul snapshot (%s): %v", snapshot.ID, err)
+				}
+				s["size"] = size
+			}
+			if snapshot.Meta.RestoredAt.String() != defaultRestoredAt {
+				s["restored_at"] = snapshot.Meta.RestoredAt.String()
+			}
+		}
+		flattened = append(flattened, s)
+	}
+
+	link := newLink(loc, ConsulClusterResourceType, clusterID)
+	url, err := linkURL(link)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(url)
+
+	if err := d.Set("project_id", projectID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("snapshots", flattened); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
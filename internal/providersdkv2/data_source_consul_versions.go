@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	consulmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-consul-service/stable/2021-02-04/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -30,6 +31,13 @@ func dataSourceConsulVersions() *schema.Resource {
 		},
 		ReadContext: dataSourceConsulVersionsRead,
 		Schema: map[string]*schema.Schema{
+			// Optional inputs
+			"version_constraint": {
+				Description:      "A version constraint, such as `~> 1.16`, to resolve against the available Consul versions.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validateSemVerOrConstraint,
+			},
 			// Computed outputs
 			"recommended": {
 				Description: "The recommended Consul version for HCP clusters.",
@@ -52,6 +60,11 @@ func dataSourceConsulVersions() *schema.Resource {
 				},
 				Computed: true,
 			},
+			"matching_version": {
+				Description: "The latest available Consul version matching `version_constraint`. Preview versions are not considered.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -95,6 +108,23 @@ func dataSourceConsulVersionsRead(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 
+	if constraint, ok := d.GetOk("version_constraint"); ok {
+		matchingVersion, err := consul.GetLatestMatchingVersion(constraint.(string), availableConsulVersions)
+		if err != nil {
+			return diag.Diagnostics{{
+				Severity:      diag.Error,
+				Summary:       "no matching Consul version",
+				Detail:        err.Error(),
+				AttributePath: cty.GetAttrPath("version_constraint"),
+			}}
+		}
+
+		err = d.Set("matching_version", matchingVersion)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	d.SetId(fmt.Sprintf("%x", md5.Sum([]byte(consul.VersionsToString(availableConsulVersions)))))
 
 	return nil
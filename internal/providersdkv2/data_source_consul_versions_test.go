@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providersdkv2
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// TestConsulVersionsConstraint resolves version constraints against the
+// versions of the in-process fake HCP server.
+func TestConsulVersionsConstraint(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	tcs := map[string]struct {
+		raw         map[string]interface{}
+		expected    string
+		expectedErr string
+	}{
+		"no constraint": {
+			raw:      map[string]interface{}{},
+			expected: "",
+		},
+		"version": {
+			raw:      map[string]interface{}{"version_constraint": "1.16.5"},
+			expected: "v1.16.5",
+		},
+		"pessimistic minor": {
+			raw:      map[string]interface{}{"version_constraint": "~> 1.15"},
+			expected: "v1.17.2",
+		},
+		"no match": {
+			raw:         map[string]interface{}{"version_constraint": "~> 1.14.0"},
+			expectedErr: "no available Consul version matches the constraint (~> 1.14.0); must match one of: [v1.17.2 (recommended), v1.16.5, v1.15.9]",
+		},
+	}
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, dataSourceConsulVersions().Schema, tc.raw)
+			diags := dataSourceConsulVersionsRead(context.Background(), d, client)
+			if tc.expectedErr != "" {
+				if !diags.HasError() || !strings.Contains(diags[0].Detail, tc.expectedErr) {
+					t.Errorf("expected error to contain %q, got %v", tc.expectedErr, diags)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected error reading Consul versions: %v", diags)
+			}
+			if got := d.Get("matching_version").(string); got != tc.expected {
+				t.Errorf("expected matching_version %q, got %q", tc.expected, got)
+			}
+			if got := d.Get("recommended").(string); got != "v1.17.2" {
+				t.Errorf("expected recommended v1.17.2, got %q", got)
+			}
+		})
+	}
+}
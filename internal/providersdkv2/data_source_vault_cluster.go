@@ -140,6 +140,11 @@ If a project is not configured in the HCP Provider config block, the oldest proj
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
+			"locked": {
+				Description: "Whether the Vault cluster is locked.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
 			"primary_link": {
 				Description: "The `self_link` of the HCP Vault Plus tier cluster which is the primary in the performance replication setup with this HCP Vault Plus tier cluster. If not specified, it is a standalone Plus tier HCP Vault cluster.",
 				Type:        schema.TypeString,
@@ -0,0 +1,180 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providersdkv2
+
+import (
+	"context"
+	"log"
+	"sort"
+
+	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+)
+
+func dataSourceVaultClusterReplicationStatus() *schema.Resource {
+	return &schema.Resource{
+		Description: "The Vault cluster replication status data source provides information about the performance replication of an HCP Vault cluster.",
+		ReadContext: dataSourceVaultClusterReplicationStatusRead,
+		Timeouts: &schema.ResourceTimeout{
+			Default: &defaultVaultClusterTimeout,
+		},
+		Schema: map[string]*schema.Schema{
+			// Required inputs
+			"cluster_id": {
+				Description:      "The ID of the HCP Vault cluster.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateSlugID,
+			},
+			// Optional inputs
+			"project_id": {
+				Description: `
+The ID of the HCP project where the HCP Vault cluster is located.
+If not specified, the project specified in the HCP Provider config block will be used, if configured.
+If a project is not configured in the HCP Provider config block, the oldest project in the organization will be used.`,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsUUID,
+				Computed:     true,
+			},
+			// Computed outputs
+			"organization_id": {
+				Description: "The ID of the HCP organization where the HCP Vault cluster is located.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"mode": {
+				Description: "The performance replication mode of the cluster: `PRIMARY` or `SECONDARY`. Empty if the cluster does not take part in performance replication.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"primary_link": {
+				Description: "The `self_link` of the primary cluster. Only set for secondaries.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"secondary_links": {
+				Description: "The `self_link` of each secondary cluster. Only set for primaries.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"paths_filter": {
+				Description: "The performance replication paths filter. Only set for secondaries.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"connection_status": {
+				Description: "The status of the replication connection: `CONNECTED` or `DISCONNECTED`.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"sync_progress": {
+				Description: "How far the replicated data is in sync: `STREAMING` once the secondary keeps up with the primary, `IN_PROGRESS` while it catches up, or `IDLE`.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceVaultClusterReplicationStatusRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client)
+
+	clusterID := d.Get("cluster_id").(string)
+
+	projectID, err := GetProjectID(d.Get("project_id").(string), client.Config.ProjectID)
+	if err != nil {
+		return diag.Errorf("unable to retrieve project ID: %v", err)
+	}
+
+	loc := &sharedmodels.HashicorpCloudLocationLocation{
+		OrganizationID: client.Config.OrganizationID,
+		ProjectID:      projectID,
+	}
+
+	log.Printf("[INFO] Reading replication status of Vault cluster (%s) [project_id=%s, organization_id=%s]", clusterID, loc.ProjectID, loc.OrganizationID)
+
+	cluster, err := clients.GetVaultClusterByID(ctx, client, loc, clusterID)
+	if err != nil {
+		return diag.Errorf("unable to fetch Vault cluster (%s): %v", clusterID, err)
+	}
+
+	var mode, primaryLink, connectionStatus, syncProgress string
+	var secondaryLinks, paths []string
+
+	if info := cluster.PerformanceReplicationInfo; info != nil {
+		if info.Mode != nil {
+			mode = string(*info.Mode)
+		}
+
+		if info.PrimaryClusterLink != nil {
+			primaryLink, err = vaultPrimaryLinkURL(info)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			if info.PathsFilter != nil {
+				paths = info.PathsFilter.Paths
+			}
+		} else {
+			secondaries, err := clients.ListVaultPerformanceReplicationSecondaries(ctx, client, loc, clusterID)
+			if err != nil {
+				return diag.Errorf("unable to list performance replication secondaries of Vault cluster (%s): %v", clusterID, err)
+			}
+			for _, secondary := range secondaries {
+				link, err := linkURL(newLink(vaultClusterSharedLocation(secondary), VaultClusterResourceType, secondary.ID))
+				if err != nil {
+					return diag.FromErr(err)
+				}
+				secondaryLinks = append(secondaryLinks, link)
+			}
+			sort.Strings(secondaryLinks)
+		}
+
+		status, err := clients.GetVaultReplicationStatus(ctx, client, loc, clusterID)
+		if err != nil {
+			return diag.Errorf("unable to fetch replication status of Vault cluster (%s): %v", clusterID, err)
+		}
+		if status.ConnectionStatus != nil {
+			connectionStatus = string(*status.ConnectionStatus)
+		}
+		if status.SyncProgress != nil {
+			syncProgress = string(*status.SyncProgress)
+		}
+	}
+
+	link := newLink(loc, VaultClusterResourceType, clusterID)
+	url, err := linkURL(link)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(url)
+
+	attrs := map[string]interface{}{
+		"project_id":        projectID,
+		"organization_id":   cluster.Location.OrganizationID,
+		"mode":              mode,
+		"primary_link":      primaryLink,
+		"secondary_links":   secondaryLinks,
+		"paths_filter":      paths,
+		"connection_status": connectionStatus,
+		"sync_progress":     syncProgress,
+	}
+	for k, v := range attrs {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
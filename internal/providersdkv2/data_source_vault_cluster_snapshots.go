@@ -0,0 +1,146 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providersdkv2
+
+import (
+	"context"
+	"log"
+	"sort"
+	"time"
+
+	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+)
+
+func dataSourceVaultClusterSnapshots() *schema.Resource {
+	return &schema.Resource{
+		Description: "The Vault cluster snapshots data source lists the snapshots of an HCP Vault cluster, oldest first.",
+		ReadContext: dataSourceVaultClusterSnapshotsRead,
+		Timeouts: &schema.ResourceTimeout{
+			Default: &defaultSnapshotTimeoutDuration,
+		},
+		Schema: map[string]*schema.Schema{
+			// Required inputs
+			"cluster_id": {
+				Description:      "The ID of the HCP Vault cluster.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateSlugID,
+			},
+			// Optional inputs
+			"project_id": {
+				Description: `
+The ID of the HCP project where the HCP Vault cluster is located.
+If not specified, the project specified in the HCP Provider config block will be used, if configured.
+If a project is not configured in the HCP Provider config block, the oldest project in the organization will be used.`,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsUUID,
+				Computed:     true,
+			},
+			// Computed outputs
+			"snapshots": {
+				Description: "The snapshots of the HCP Vault cluster.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"snapshot_id": {
+							Description: "The ID of the Vault snapshot.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"snapshot_name": {
+							Description: "The name of the snapshot.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"type": {
+							Description: "The type of the snapshot: `MANUAL`, `AUTOMATIC`, `SCHEDULED` or `BEFORE_UPGRADE`.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"state": {
+							Description: "The state of the Vault snapshot.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"vault_version": {
+							Description: "The version of Vault at the time of snapshot creation.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"created_at": {
+							Description: "The time that the snapshot was requested.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"finished_at": {
+							Description: "The time that the snapshot finished. Blank while the snapshot is being created.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceVaultClusterSnapshotsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client)
+
+	clusterID := d.Get("cluster_id").(string)
+
+	projectID, err := GetProjectID(d.Get("project_id").(string), client.Config.ProjectID)
+	if err != nil {
+		return diag.Errorf("unable to retrieve project ID: %v", err)
+	}
+
+	loc := &sharedmodels.HashicorpCloudLocationLocation{
+		OrganizationID: client.Config.OrganizationID,
+		ProjectID:      projectID,
+	}
+
+	log.Printf("[INFO] Listing snapshots for Vault cluster (%s) [project_id=%s, organization_id=%s]", clusterID, loc.ProjectID, loc.OrganizationID)
+
+	snapshots, err := clients.ListVaultSnapshots(ctx, client, loc, clusterID)
+	if err != nil {
+		return diag.Errorf("unable to list snapshots of Vault cluster (%s): %v", clusterID, err)
+	}
+
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return time.Time(snapshots[i].RequestedAt).Before(time.Time(snapshots[j].RequestedAt))
+	})
+
+	flattened := make([]interface{}, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		s := flattenVaultSnapshot(snapshot)
+		if snapshot.Type != nil {
+			s["type"] = string(*snapshot.Type)
+		}
+		flattened = append(flattened, s)
+	}
+
+	link := newLink(loc, VaultClusterResourceType, clusterID)
+	url, err := linkURL(link)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(url)
+
+	if err := d.Set("project_id", projectID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("snapshots", flattened); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -5,11 +5,8 @@ package providersdkv2
 
 import (
 	"context"
-	"log"
-	"strings"
 
 	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
-	vaultmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-vault-service/stable/2020-11-25/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -53,6 +50,12 @@ If a project is not configured in the HCP Provider config block, the oldest proj
 				ValidateFunc: validation.IsUUID,
 				Computed:     true,
 			},
+			// Computed outputs
+			"version": {
+				Description: "The registered version of the plugin.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -61,7 +64,6 @@ func dataSourceVaultPluginRead(ctx context.Context, d *schema.ResourceData, meta
 	clusterID := d.Get("cluster_id").(string)
 	pluginName := d.Get("plugin_name").(string)
 	pluginTypeString := d.Get("plugin_type").(string)
-	pluginType := vaultmodels.HashicorpCloudVault20201125PluginType(pluginTypeString)
 	client := meta.(*clients.Client)
 
 	projectID, err := GetProjectID(d.Get("project_id").(string), client.Config.ProjectID)
@@ -74,26 +76,15 @@ func dataSourceVaultPluginRead(ctx context.Context, d *schema.ResourceData, meta
 		ProjectID:      projectID,
 	}
 
-	log.Printf("[INFO] Listing plugins for Vault cluster (%s) [project_id=%s, organization_id=%s]", clusterID, loc.ProjectID, loc.OrganizationID)
-
-	pluginsResp, err := clients.ListPlugins(ctx, client, loc, clusterID)
+	plugin, err := getVaultPluginRegistration(ctx, client, loc, clusterID, pluginName, pluginTypeString)
 	if err != nil {
-		log.Printf("[ERROR] Vault cluster (%s) failed to list plugins", clusterID)
 		return diag.FromErr(err)
 	}
 
-	found := false
-	for _, plugin := range pluginsResp.Plugins {
-		if strings.EqualFold(pluginName, plugin.PluginName) && pluginType == *plugin.PluginType && plugin.IsRegistered {
-			found = true
-			d.SetId(vaultPluginResourceID(projectID, clusterID, pluginTypeString, pluginName))
-			break
-		}
-	}
-
 	// If Plugin found, update resource data.
-	if found {
-		if err := setVaultPluginResourceData(d, projectID, clusterID, pluginName, pluginTypeString); err != nil {
+	if plugin != nil {
+		d.SetId(vaultPluginResourceID(projectID, clusterID, pluginTypeString, pluginName))
+		if err := setVaultPluginResourceData(d, projectID, clusterID, pluginName, pluginTypeString, plugin.PluginVersion); err != nil {
 			return diag.FromErr(err)
 		}
 		return nil
@@ -0,0 +1,152 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providersdkv2
+
+import (
+	"context"
+	"log"
+	"sort"
+
+	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+)
+
+func dataSourceVaultPlugins() *schema.Resource {
+	return &schema.Resource{
+		Description: "The Vault plugins data source lists the plugins available on an HCP Vault cluster, whether they are registered or not.",
+		ReadContext: dataSourceVaultPluginsRead,
+		Timeouts: &schema.ResourceTimeout{
+			Default: &defaultVaultPluginTimeout,
+		},
+		Schema: map[string]*schema.Schema{
+			// Required inputs
+			"cluster_id": {
+				Description:      "The ID of the HCP Vault cluster.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateSlugID,
+			},
+			// Optional inputs
+			"project_id": {
+				Description: `
+The ID of the HCP project where the HCP Vault cluster is located.
+If not specified, the project specified in the HCP Provider config block will be used, if configured.
+If a project is not configured in the HCP Provider config block, the oldest project in the organization will be used.`,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsUUID,
+				Computed:     true,
+			},
+			// Computed outputs
+			"plugins": {
+				Description: "The plugins of the HCP Vault cluster, sorted by type and name.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"plugin_name": {
+							Description: "The name of the plugin.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"plugin_type": {
+							Description: "The type of the plugin: `SECRET`, `AUTH` or `DATABASE`.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"version": {
+							Description: "The version of the plugin.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"is_registered": {
+							Description: "Whether the plugin is registered on the cluster.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"status": {
+							Description: "The opt-in status of the plugin: `HCPV_ENABLED` or `HCPV_DISABLED` when managed by HCP, `CUSTOMER_ENABLED` or `CUSTOMER_DISABLED` when managed by the customer.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceVaultPluginsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client)
+
+	clusterID := d.Get("cluster_id").(string)
+
+	projectID, err := GetProjectID(d.Get("project_id").(string), client.Config.ProjectID)
+	if err != nil {
+		return diag.Errorf("unable to retrieve project ID: %v", err)
+	}
+
+	loc := &sharedmodels.HashicorpCloudLocationLocation{
+		OrganizationID: client.Config.OrganizationID,
+		ProjectID:      projectID,
+	}
+
+	log.Printf("[INFO] Listing plugins for Vault cluster (%s) [project_id=%s, organization_id=%s]", clusterID, loc.ProjectID, loc.OrganizationID)
+
+	pluginsResp, err := clients.ListPlugins(ctx, client, loc, clusterID)
+	if err != nil {
+		return diag.Errorf("unable to list plugins of Vault cluster (%s): %v", clusterID, err)
+	}
+
+	flattened := make([]map[string]interface{}, 0, len(pluginsResp.Plugins))
+	for _, plugin := range pluginsResp.Plugins {
+		var pluginType, status string
+		if plugin.PluginType != nil {
+			pluginType = string(*plugin.PluginType)
+		}
+		if plugin.OptIn != nil {
+			status = string(*plugin.OptIn)
+		}
+		flattened = append(flattened, map[string]interface{}{
+			"plugin_name":   plugin.PluginName,
+			"plugin_type":   pluginType,
+			"version":       plugin.PluginVersion,
+			"is_registered": plugin.IsRegistered,
+			"status":        status,
+		})
+	}
+
+	sort.SliceStable(flattened, func(i, j int) bool {
+		if flattened[i]["plugin_type"] != flattened[j]["plugin_type"] {
+			return flattened[i]["plugin_type"].(string) < flattened[j]["plugin_type"].(string)
+		}
+		return flattened[i]["plugin_name"].(string) < flattened[j]["plugin_name"].(string)
+	})
+
+	plugins := make([]interface{}, 0, len(flattened))
+	for _, p := range flattened {
+		plugins = append(plugins, p)
+	}
+
+	link := newLink(loc, VaultClusterResourceType, clusterID)
+	url, err := linkURL(link)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(url)
+
+	if err := d.Set("project_id", projectID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("plugins", plugins); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providersdkv2
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// deletionProtectionSchema returns the schema of the deletion_protection
+// attribute of a resource. The attribute only lives in the Terraform state.
+// It is computed rather than defaulted so that an imported resource stays
+// protected when the configuration omits the attribute.
+func deletionProtectionSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Description: fmt.Sprintf("Prevents the %s from being deleted, including as part of a replacement, while set to `true`. It must be set to `false` and applied before the %[1]s can be deleted. If not set, the %[1]s keeps its current protection, which is `false` for a created %[1]s and `true` for an imported %[1]s.", description),
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Computed:    true,
+	}
+}
+
+// setDeletionProtectionOnCreate records the deletion protection of a created
+// resource, which is disabled unless the configuration enables it.
+func setDeletionProtectionOnCreate(d *schema.ResourceData) error {
+	return d.Set("deletion_protection", d.Get("deletion_protection").(bool))
+}
+
+// checkDeletionProtection returns an error if deletion protection is enabled
+// for the resource.
+func checkDeletionProtection(d *schema.ResourceData, description, id string) diag.Diagnostics {
+	if !d.Get("deletion_protection").(bool) {
+		return nil
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("%s (%s) is protected from deletion", description, id),
+		Detail:   "deletion_protection is set to true. Set it to false and apply the change before deleting the resource.",
+	}}
+}
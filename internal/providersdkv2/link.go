@@ -40,10 +40,18 @@ const (
 	// ConsulSnapshotResourceType is the resource type of a Consul snapshot
 	ConsulSnapshotResourceType = "hashicorp.consul.snapshot"
 
+	// ConsulSnapshotRestoreResourceType is the resource type of the restore
+	// of a Consul snapshot
+	ConsulSnapshotRestoreResourceType = ConsulSnapshotResourceType + ".restore"
+
 	// ConsulClusterHelmConfigDataSourceType is the data source type of a Consul
 	// cluster Helm config
 	ConsulClusterHelmConfigDataSourceType = ConsulClusterResourceType + ".helm-config"
 
+	// ConsulClusterClientConfigDataSourceType is the data source type of a
+	// Consul cluster client config
+	ConsulClusterClientConfigDataSourceType = ConsulClusterResourceType + ".client-config"
+
 	// ConsulClusterAgentKubernetesSecretDataSourceType is the data source
 	// type of a Consul cluster agent Kubernetes secret
 	ConsulClusterAgentKubernetesSecretDataSourceType = ConsulClusterResourceType + ".agent-kubernetes-secret"
@@ -51,6 +59,29 @@ const (
 	// VaultClusterResourceType is the resource type of a Vault cluster
 	VaultClusterResourceType = "hashicorp.vault.cluster"
 
+	// VaultClusterMetricsConfigResourceType is the resource type of the
+	// metrics configuration of a Vault cluster
+	VaultClusterMetricsConfigResourceType = VaultClusterResourceType + ".metrics-config"
+
+	// VaultClusterAuditLogConfigResourceType is the resource type of the audit
+	// log configuration of a Vault cluster
+	VaultClusterAuditLogConfigResourceType = VaultClusterResourceType + ".audit-log-config"
+
+	// VaultClusterReplicationResourceType is the resource type of the
+	// performance replication of a secondary Vault cluster
+	VaultClusterReplicationResourceType = VaultClusterResourceType + ".replication"
+
+	// VaultClusterUpgradeResourceType is the resource type of a Vault
+	// cluster version upgrade
+	VaultClusterUpgradeResourceType = VaultClusterResourceType + ".upgrade"
+
+	// VaultSnapshotResourceType is the resource type of a Vault snapshot
+	VaultSnapshotResourceType = "hashicorp.vault.snapshot"
+
+	// VaultSnapshotRestoreResourceType is the resource type of the restore of
+	// a Vault snapshot
+	VaultSnapshotRestoreResourceType = VaultSnapshotResourceType + ".restore"
+
 	// BoundaryClusterResourceType is the resource type of a Boundary Cluster
 	BoundaryClusterResourceType = "hashicorp.boundary.cluster"
 )
@@ -5,7 +5,10 @@ package providersdkv2
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
+	"regexp"
 	"time"
 
 	"github.com/hashicorp/go-cty/cty"
@@ -24,21 +27,26 @@ func New() func() *schema.Provider {
 	return func() *schema.Provider {
 		p := &schema.Provider{
 			DataSourcesMap: map[string]*schema.Resource{
-				"hcp_aws_network_peering":            dataSourceAwsNetworkPeering(),
-				"hcp_aws_transit_gateway_attachment": dataSourceAwsTransitGatewayAttachment(),
-				"hcp_azure_peering_connection":       dataSourceAzurePeeringConnection(),
-				"hcp_boundary_cluster":               dataSourceBoundaryCluster(),
-				"hcp_consul_agent_helm_config":       dataSourceConsulAgentHelmConfig(),
-				"hcp_consul_agent_kubernetes_secret": dataSourceConsulAgentKubernetesSecret(),
-				"hcp_consul_cluster":                 dataSourceConsulCluster(),
-				"hcp_consul_versions":                dataSourceConsulVersions(),
-				"hcp_hvn":                            dataSourceHvn(),
-				"hcp_hvn_peering_connection":         dataSourceHvnPeeringConnection(),
-				"hcp_hvn_route":                      dataSourceHVNRoute(),
-				"hcp_packer_bucket_names":            dataSourcePackerBucketNames(),
-				"hcp_packer_run_task":                dataSourcePackerRunTask(),
-				"hcp_vault_cluster":                  dataSourceVaultCluster(),
-				"hcp_vault_plugin":                   dataSourceVaultPlugin(),
+				"hcp_aws_network_peering":              dataSourceAwsNetworkPeering(),
+				"hcp_aws_transit_gateway_attachment":   dataSourceAwsTransitGatewayAttachment(),
+				"hcp_azure_peering_connection":         dataSourceAzurePeeringConnection(),
+				"hcp_boundary_cluster":                 dataSourceBoundaryCluster(),
+				"hcp_consul_agent_helm_config":         dataSourceConsulAgentHelmConfig(),
+				"hcp_consul_agent_kubernetes_secret":   dataSourceConsulAgentKubernetesSecret(),
+				"hcp_consul_cluster":                   dataSourceConsulCluster(),
+				"hcp_consul_cluster_client_config":     dataSourceConsulClusterClientConfig(),
+				"hcp_consul_snapshots":                 dataSourceConsulSnapshots(),
+				"hcp_consul_versions":                  dataSourceConsulVersions(),
+				"hcp_hvn":                              dataSourceHvn(),
+				"hcp_hvn_peering_connection":           dataSourceHvnPeeringConnection(),
+				"hcp_hvn_route":                        dataSourceHVNRoute(),
+				"hcp_packer_bucket_names":              dataSourcePackerBucketNames(),
+				"hcp_packer_run_task":                  dataSourcePackerRunTask(),
+				"hcp_vault_cluster":                    dataSourceVaultCluster(),
+				"hcp_vault_cluster_replication_status": dataSourceVaultClusterReplicationStatus(),
+				"hcp_vault_cluster_snapshots":          dataSourceVaultClusterSnapshots(),
+				"hcp_vault_plugin":                     dataSourceVaultPlugin(),
+				"hcp_vault_plugins":                    dataSourceVaultPlugins(),
 			},
 			ResourcesMap: map[string]*schema.Resource{
 				"hcp_aws_network_peering":            resourceAwsNetworkPeering(),
@@ -48,6 +56,7 @@ func New() func() *schema.Provider {
 				"hcp_consul_cluster":                 resourceConsulCluster(),
 				"hcp_consul_cluster_root_token":      resourceConsulClusterRootToken(),
 				"hcp_consul_snapshot":                resourceConsulSnapshot(),
+				"hcp_consul_snapshot_restore":        resourceConsulSnapshotRestore(),
 				"hcp_hvn":                            resourceHvn(),
 				"hcp_hvn_peering_connection":         resourceHvnPeeringConnection(),
 				"hcp_hvn_route":                      resourceHvnRoute(),
@@ -56,6 +65,12 @@ func New() func() *schema.Provider {
 				"hcp_packer_run_task":                resourcePackerRunTask(),
 				"hcp_vault_cluster":                  resourceVaultCluster(),
 				"hcp_vault_cluster_admin_token":      resourceVaultClusterAdminToken(),
+				"hcp_vault_cluster_audit_log_config": resourceVaultClusterAuditLogConfig(),
+				"hcp_vault_cluster_metrics_config":   resourceVaultClusterMetricsConfig(),
+				"hcp_vault_cluster_replication":      resourceVaultClusterReplication(),
+				"hcp_vault_cluster_upgrade":          resourceVaultClusterUpgrade(),
+				"hcp_vault_cluster_snapshot":         resourceVaultClusterSnapshot(),
+				"hcp_vault_cluster_snapshot_restore": resourceVaultClusterSnapshotRestore(),
 				"hcp_vault_plugin":                   resourceVaultPlugin(),
 			},
 			Schema: map[string]*schema.Schema{
@@ -69,12 +84,61 @@ func New() func() *schema.Provider {
 					Optional:    true,
 					Description: "The OAuth2 Client Secret for API operations.",
 				},
+				"organization_id": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.IsUUID,
+					Description:  "The default organization in which resources should be created. Required when the credentials have access to more than one organization and no `project_id` is set.",
+				},
 				"project_id": {
 					Type:         schema.TypeString,
 					Optional:     true,
 					ValidateFunc: validation.IsUUID,
 					Description:  "The default project in which resources should be created.",
 				},
+				"profile": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Description: "The name of the hcp CLI profile whose organization and project the provider defaults to. " +
+						"Defaults to the HCP_PROFILE environment variable, or to the active profile when no credentials are configured, " +
+						"in which case the provider authenticates with the hcp CLI user session. A selected profile also applies alongside configured credentials.",
+				},
+				"read_only": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Description: "When true, any plan that would create, update or delete a resource fails, while data sources " +
+						"and refreshes keep working. Defaults to the HCP_READ_ONLY environment variable.",
+				},
+				"max_concurrent_requests": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+					Description:  "The maximum number of requests the provider sends to the HCP APIs concurrently. Unlimited by default.",
+				},
+				"requests_per_second": {
+					Type:         schema.TypeFloat,
+					Optional:     true,
+					ValidateFunc: validation.FloatAtLeast(0.01),
+					Description:  "The maximum number of requests per second the provider sends to the HCP APIs. Unlimited by default.",
+				},
+				"api_address": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[^/:\s]+(:\d+)?$`), "must be a host with an optional port, without a scheme"),
+					Description:  "The address of the HCP API, as a host with an optional port (e.g. `api.cloud.hashicorp.com:443`). Overrides the HCP_API_ADDRESS environment variable.",
+				},
+				"auth_url": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.IsURLWithHTTPS,
+					Description:  "The URL of the HCP auth server (e.g. `https://auth.idp.hashicorp.com`). Overrides the HCP_AUTH_URL environment variable.",
+				},
+				"portal_url": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.IsURLWithHTTPS,
+					Description:  "The URL of the HCP portal (e.g. `https://portal.cloud.hashicorp.com`).",
+				},
 				"credential_file": {
 					Type:     schema.TypeString,
 					Optional: true,
@@ -108,6 +172,74 @@ func New() func() *schema.Provider {
 						},
 					},
 				},
+				"tls": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "Configures how the provider verifies the TLS certificates of the HCP API and auth endpoints.",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"ca_file": {
+								Type:        schema.TypeString,
+								Optional:    true,
+								Description: "The path to a PEM encoded bundle of CA certificates used to verify the endpoints instead of the system roots.",
+							},
+							"insecure_skip_verify": {
+								Type:        schema.TypeBool,
+								Optional:    true,
+								Description: "Disables the verification of the endpoint certificates. This should only be used for testing.",
+							},
+						},
+					},
+				},
+				"operation_wait": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "Configures how the provider waits for long-running HCP operations, such as cluster creation, to complete.",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"poll_interval": {
+								Type:        schema.TypeString,
+								Optional:    true,
+								Description: "The interval between two polls of an operation, as a Go duration string (e.g. `10s`). Defaults to `5s`.",
+							},
+							"max_consecutive_errors": {
+								Type:         schema.TypeInt,
+								Optional:     true,
+								ValidateFunc: validation.IntAtLeast(1),
+								Description:  "The number of consecutive errors polling an operation after which waiting for it fails. Defaults to `4`.",
+							},
+						},
+					},
+				},
+				"retry": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "Configures how requests to the HCP APIs that fail with a transient error are retried.",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"max_attempts": {
+								Type:         schema.TypeInt,
+								Optional:     true,
+								ValidateFunc: validation.IntAtLeast(1),
+								Description:  "The maximum number of attempts made for a single request, including the first one. Defaults to `10`.",
+							},
+							"max_backoff": {
+								Type:        schema.TypeString,
+								Optional:    true,
+								Description: "The maximum delay between two attempts, as a Go duration string (e.g. `30s`). This also caps the delay requested by the API through the `Retry-After` header. Defaults to `60s`.",
+							},
+							"retryable_status_codes": {
+								Type:     schema.TypeList,
+								Optional: true,
+								Elem: &schema.Schema{
+									Type:         schema.TypeInt,
+									ValidateFunc: validation.IntBetween(100, 599),
+								},
+								Description: "The HTTP status codes that cause a request to be retried. Requests that create, update or delete resources are only retried on `429` and `503`, as the API may already have applied them otherwise. Defaults to `[429, 502, 503, 504]`.",
+							},
+						},
+					},
+				},
 			},
 			ProviderMetaSchema: map[string]*schema.Schema{
 				"module_name": {
@@ -132,8 +264,17 @@ func configure(p *schema.Provider) func(context.Context, *schema.ResourceData) (
 			ClientID:       d.Get("client_id").(string),
 			ClientSecret:   d.Get("client_secret").(string),
 			CredentialFile: d.Get("credential_file").(string),
+			OrganizationID: d.Get("organization_id").(string),
 			ProjectID:      d.Get("project_id").(string),
+			APIAddress:     d.Get("api_address").(string),
+			AuthURL:        d.Get("auth_url").(string),
+			PortalURL:      d.Get("portal_url").(string),
+			Profile:        d.Get("profile").(string),
 			SourceChannel:  p.UserAgent("terraform-provider-hcp", version.ProviderVersion),
+			RateLimit: clients.RateLimitConfig{
+				MaxConcurrentRequests: d.Get("max_concurrent_requests").(int),
+				RequestsPerSecond:     d.Get("requests_per_second").(float64),
+			},
 		}
 
 		// Read the workload_identity configuration
@@ -146,52 +287,117 @@ func configure(p *schema.Provider) func(context.Context, *schema.ResourceData) (
 			}
 		}
 
-		client, err := clients.NewClient(clientConfig)
-		if err != nil {
-			diags = append(diags, diag.Errorf("unable to create HCP api client: %v", err)...)
-			return nil, diags
+		// Read the tls configuration
+		if t, ok := d.GetOk("tls"); ok {
+			clientConfig = readTLS(t, clientConfig)
+		}
+
+		// Read the retry configuration
+		if r, ok := d.GetOk("retry"); ok {
+			var moreDiags diag.Diagnostics
+			clientConfig, moreDiags = readRetry(r, clientConfig)
+			diags = append(diags, moreDiags...)
+			if moreDiags.HasError() {
+				return nil, diags
+			}
+		}
+
+		// Read the operation_wait configuration
+		if o, ok := d.GetOk("operation_wait"); ok {
+			var moreDiags diag.Diagnostics
+			clientConfig, moreDiags = readOperationWait(o, clientConfig)
+			diags = append(diags, moreDiags...)
+			if moreDiags.HasError() {
+				return nil, diags
+			}
 		}
 
 		// Attempt to source from the environment if unset.
+		if clientConfig.OrganizationID == "" {
+			clientConfig.OrganizationID = os.Getenv("HCP_ORGANIZATION_ID")
+		}
 		if clientConfig.ProjectID == "" {
 			clientConfig.ProjectID = os.Getenv("HCP_PROJECT_ID")
 		}
 
-		if clientConfig.ProjectID != "" {
-			getProjParams := project_service.NewProjectServiceGetParams()
-			getProjParams.ID = clientConfig.ProjectID
-			project, err := clients.RetryProjectServiceGet(client, getProjParams)
-			if err != nil {
-				diags = append(diags, diag.Errorf("unable to fetch project %q: %v", clientConfig.ProjectID, err)...)
-				return nil, diags
+		// Fall back to the hcp CLI profile.
+		clientConfig, err := clients.ApplyProfile(clientConfig)
+		if err != nil {
+			diags = append(diags, diag.Errorf("unable to load hcp CLI profile: %v", err)...)
+			return nil, diags
+		}
+
+		// The client is shared with the plugin framework provider, so the
+		// default project is only resolved by whichever provider is
+		// configured first.
+		var resolveDiags diag.Diagnostics
+		client, err := clients.NewSharedClient(clientConfig, func(client *clients.Client) error {
+			resolveDiags = resolveProject(ctx, client)
+			if resolveDiags.HasError() {
+				return errors.New("unable to resolve the default project")
 			}
+			return nil
+		})
+		diags = append(diags, resolveDiags...)
+		if resolveDiags.HasError() {
+			return nil, diags
+		}
+		if err != nil {
+			diags = append(diags, diag.Errorf("unable to create HCP api client: %v", err)...)
+			return nil, diags
+		}
+
+		return client, diags
+	}
+}
 
-			client.Config.ProjectID = project.Payload.Project.ID
-			client.Config.OrganizationID = project.Payload.Project.Parent.ID
-
-		} else {
-			// For the initial release of the HCP TFP, since only one project was allowed per organization at the time,
-			// the provider handled used the single organization's single project by default, instead of requiring the
-			// user to set it. Once multiple projects are available, this helper issues a warning: when multiple projects exist within the org,
-			// a project ID should be set on the provider or on each resource. Otherwise, the oldest project will be used by default.
-			// This helper will eventually be deprecated after a migration period.
-			project, projDiags := getProjectFromCredentials(ctx, client)
-			if projDiags != nil {
-				if !projDiags.HasError() {
-					diags = append(diags, projDiags...)
-				} else {
-					projDiags = append(projDiags, diag.Errorf("unable to get project from credentials")...)
-					diags = append(diags, projDiags...)
-					return nil, diags
-				}
+// resolveProject sets the organization and project the client defaults to,
+// either from the configured project or from the credentials.
+func resolveProject(ctx context.Context, client *clients.Client) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if client.Config.ProjectID != "" {
+		getProjParams := project_service.NewProjectServiceGetParams()
+		getProjParams.ID = client.Config.ProjectID
+		project, err := client.Project.ProjectServiceGet(getProjParams, nil)
+		if err != nil {
+			diags = append(diags, diag.Errorf("unable to fetch project %q: %v", client.Config.ProjectID, err)...)
+			return diags
+		}
+
+		if client.Config.OrganizationID != "" && client.Config.OrganizationID != project.Payload.Project.Parent.ID {
+			diags = append(diags, diag.Errorf("project %q does not belong to organization %q", client.Config.ProjectID, client.Config.OrganizationID)...)
+			return diags
+		}
+
+		client.Config.ProjectID = project.Payload.Project.ID
+		client.Config.OrganizationID = project.Payload.Project.Parent.ID
+
+	} else {
+		// For the initial release of the HCP TFP, since only one project was allowed per organization at the time,
+		// the provider handled used the single organization's single project by default, instead of requiring the
+		// user to set it. Once multiple projects are available, this helper issues a warning: when multiple projects exist within the org,
+		// a project ID should be set on the provider or on each resource. Otherwise, the oldest project will be used by default.
+		// This helper will eventually be deprecated after a migration period.
+		orgID, project, projDiags := getProjectFromCredentials(ctx, client)
+		if projDiags != nil {
+			if !projDiags.HasError() {
+				diags = append(diags, projDiags...)
+			} else {
+				projDiags = append(projDiags, diag.Errorf("unable to get project from credentials")...)
+				diags = append(diags, projDiags...)
+				return diags
 			}
+		}
 
-			client.Config.OrganizationID = project.Parent.ID
+		// Without a project, only organization scoped resources can be used.
+		client.Config.OrganizationID = orgID
+		if project != nil {
 			client.Config.ProjectID = project.ID
 		}
-
-		return client, diags
 	}
+
+	return diags
 }
 
 func readWorkloadIdentity(v interface{}, clientConfig clients.ClientConfig) (clients.ClientConfig, diag.Diagnostics) {
@@ -220,46 +426,119 @@ func readWorkloadIdentity(v interface{}, clientConfig clients.ClientConfig) (cli
 	return clientConfig, diags
 }
 
-// getProjectFromCredentials uses the configured client credentials to
-// fetch the associated organization and returns that organization's
-// single project.
-func getProjectFromCredentials(ctx context.Context, client *clients.Client) (project *models.HashicorpCloudResourcemanagerProject, diags diag.Diagnostics) {
-	// Get the organization ID.
-	listOrgParams := organization_service.NewOrganizationServiceListParams()
-	listOrgResp, err := clients.RetryOrganizationServiceList(client, listOrgParams)
-	if err != nil {
-		diags = append(diags, diag.Errorf("unable to fetch organization list: %v", err)...)
-		return nil, diags
+func readTLS(v interface{}, clientConfig clients.ClientConfig) clients.ClientConfig {
+	if len(v.([]interface{})) == 1 && v.([]interface{})[0] != nil {
+		t := v.([]interface{})[0].(map[string]interface{})
+		if caFile, ok := t["ca_file"].(string); ok {
+			clientConfig.TLS.CACertFile = caFile
+		}
+		if insecure, ok := t["insecure_skip_verify"].(bool); ok {
+			clientConfig.TLS.InsecureSkipVerify = insecure
+		}
 	}
-	orgLen := len(listOrgResp.Payload.Organizations)
-	if orgLen == 0 {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "The configured credentials do not have access to any organization.",
-			Detail:   "Please assign at least one organization to the configured credentials to use this provider.",
-		})
-		return nil, diags
+	return clientConfig
+}
+
+func readRetry(v interface{}, clientConfig clients.ClientConfig) (clients.ClientConfig, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if len(v.([]interface{})) == 1 && v.([]interface{})[0] != nil {
+		r := v.([]interface{})[0].(map[string]interface{})
+		if ma, ok := r["max_attempts"].(int); ok && ma > 0 {
+			clientConfig.Retry.MaxAttempts = ma
+		}
+		if mb, ok := r["max_backoff"].(string); ok && mb != "" {
+			maxBackoff, err := time.ParseDuration(mb)
+			if err != nil || maxBackoff <= 0 {
+				diags = append(diags, diag.Diagnostic{
+					Severity:      diag.Error,
+					Summary:       "invalid retry",
+					Detail:        fmt.Sprintf("`max_backoff` must be a positive duration, got %q", mb),
+					AttributePath: cty.GetAttrPath("retry").IndexInt(0).GetAttr("max_backoff"),
+				})
+			} else {
+				clientConfig.Retry.MaxBackoff = maxBackoff
+			}
+		}
+		if codes, ok := r["retryable_status_codes"].([]interface{}); ok {
+			for _, code := range codes {
+				clientConfig.Retry.RetryableStatusCodes = append(clientConfig.Retry.RetryableStatusCodes, code.(int))
+			}
+		}
 	}
-	if orgLen > 1 {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "There is more than one organization associated with the configured credentials.",
-			Detail:   "Please configure a specific project in the HCP provider config block",
-		})
-		return nil, diags
+	return clientConfig, diags
+}
+
+func readOperationWait(v interface{}, clientConfig clients.ClientConfig) (clients.ClientConfig, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if len(v.([]interface{})) == 1 && v.([]interface{})[0] != nil {
+		o := v.([]interface{})[0].(map[string]interface{})
+		if pi, ok := o["poll_interval"].(string); ok && pi != "" {
+			pollInterval, err := time.ParseDuration(pi)
+			if err != nil || pollInterval <= 0 {
+				diags = append(diags, diag.Diagnostic{
+					Severity:      diag.Error,
+					Summary:       "invalid operation_wait",
+					Detail:        fmt.Sprintf("`poll_interval` must be a positive duration, got %q", pi),
+					AttributePath: cty.GetAttrPath("operation_wait").IndexInt(0).GetAttr("poll_interval"),
+				})
+			} else {
+				clientConfig.OperationWait.PollInterval = pollInterval
+			}
+		}
+		if me, ok := o["max_consecutive_errors"].(int); ok && me > 0 {
+			clientConfig.OperationWait.MaxConsecutiveErrors = me
+		}
 	}
+	return clientConfig, diags
+}
 
-	orgID := listOrgResp.Payload.Organizations[0].ID
+// getProjectFromCredentials uses the configured client credentials to
+// fetch the associated organization, unless one is configured, and returns
+// that organization's single project. The project is nil if the organization
+// has none.
+func getProjectFromCredentials(ctx context.Context, client *clients.Client) (orgID string, project *models.HashicorpCloudResourcemanagerProject, diags diag.Diagnostics) {
+	orgID = client.Config.OrganizationID
+	if orgID == "" {
+		// Get the organization ID.
+		listOrgParams := organization_service.NewOrganizationServiceListParams()
+		listOrgResp, err := client.Organization.OrganizationServiceList(listOrgParams, nil)
+		if err != nil {
+			diags = append(diags, diag.Errorf("unable to fetch organization list: %v", err)...)
+			return "", nil, diags
+		}
+		orgLen := len(listOrgResp.Payload.Organizations)
+		if orgLen == 0 {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "The configured credentials do not have access to any organization.",
+				Detail:   "Please assign at least one organization to the configured credentials to use this provider.",
+			})
+			return "", nil, diags
+		}
+		if orgLen > 1 {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "There is more than one organization associated with the configured credentials.",
+				Detail:   "Please configure a specific organization or project in the HCP provider config block",
+			})
+			return "", nil, diags
+		}
+
+		orgID = listOrgResp.Payload.Organizations[0].ID
+	}
 
 	// Get the project using the organization ID.
 	listProjParams := project_service.NewProjectServiceListParams()
 	listProjParams.ScopeID = &orgID
 	scopeType := string(models.HashicorpCloudResourcemanagerResourceIDResourceTypeORGANIZATION)
 	listProjParams.ScopeType = &scopeType
-	listProjResp, err := clients.RetryProjectServiceList(client, listProjParams)
+	listProjResp, err := client.Project.ProjectServiceList(listProjParams, nil)
 	if err != nil {
 		diags = append(diags, diag.Errorf("unable to fetch project id: %v", err)...)
-		return nil, diags
+		return "", nil, diags
+	}
+	if len(listProjResp.Payload.Projects) == 0 {
+		return orgID, nil, diags
 	}
 	if len(listProjResp.Payload.Projects) > 1 {
 		diags = append(diags, diag.Diagnostic{
@@ -267,10 +546,10 @@ func getProjectFromCredentials(ctx context.Context, client *clients.Client) (pro
 			Summary:  "There is more than one project associated with the organization of the configured credentials.",
 			Detail:   `The oldest project has been selected as the default. To configure which project is used as default, set a project in the HCP provider config block. Resources may also be configured with different projects.`,
 		})
-		return GetOldestProject(listProjResp.Payload.Projects), diags
+		return orgID, GetOldestProject(listProjResp.Payload.Projects), diags
 	}
 	project = listProjResp.Payload.Projects[0]
-	return project, diags
+	return orgID, project, diags
 }
 
 // GetOldestProject retrieves the oldest project from a list based on its created_at time.
@@ -9,9 +9,11 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/hashicorp/hcp-sdk-go/clients/cloud-resource-manager/stable/2019-12-10/client/project_service"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
@@ -21,6 +23,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
 	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients/hcptest"
 	"github.com/hashicorp/terraform-provider-hcp/internal/provider"
 	"github.com/hashicorp/terraform-provider-hcp/version"
 )
@@ -58,7 +61,11 @@ var testProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer,
 				return upgradedSdkProvider
 			},
 		}
-		return tf6muxserver.NewMuxServer(context.Background(), providers...)
+		muxServer, err := tf6muxserver.NewMuxServer(context.Background(), providers...)
+		if err != nil {
+			return nil, err
+		}
+		return provider.NewPlanGuardServer(muxServer.ProviderServer()), nil
 	},
 	"dummy": func() (tfprotov6.ProviderServer, error) {
 		// Upgrade the provider sdkv2 version to protocol 6
@@ -228,3 +235,197 @@ func Test_readWorkloadIdentity(t *testing.T) {
 		})
 	}
 }
+
+func Test_readRetry(t *testing.T) {
+	tcs := map[string]struct {
+		config    interface{}
+		want      clients.ClientConfig
+		wantDiags diag.Diagnostics
+	}{
+		"empty block": {
+			config: []interface{}{nil},
+		},
+		"all fields": {
+			config: []interface{}{
+				map[string]interface{}{
+					"max_attempts":           5,
+					"max_backoff":            "30s",
+					"retryable_status_codes": []interface{}{429, 503},
+				},
+			},
+			want: clients.ClientConfig{
+				Retry: clients.RetryConfig{
+					MaxAttempts:          5,
+					MaxBackoff:           30 * time.Second,
+					RetryableStatusCodes: []int{429, 503},
+				},
+			},
+		},
+		"invalid max_backoff": {
+			config: []interface{}{
+				map[string]interface{}{
+					"max_backoff": "soon",
+				},
+			},
+			wantDiags: diag.Diagnostics{
+				diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "invalid retry",
+					Detail:   "`max_backoff` must be a positive duration, got \"soon\"",
+				},
+			},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			ignoreAttributePath := cmpopts.IgnoreFields(diag.Diagnostic{}, "AttributePath")
+
+			got, gotDiags := readRetry(tc.config, clients.ClientConfig{})
+			if diff := cmp.Diff(tc.wantDiags, gotDiags, ignoreAttributePath); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_readOperationWait(t *testing.T) {
+	tcs := map[string]struct {
+		config    interface{}
+		want      clients.ClientConfig
+		wantDiags diag.Diagnostics
+	}{
+		"empty block": {
+			config: []interface{}{nil},
+		},
+		"all fields": {
+			config: []interface{}{
+				map[string]interface{}{
+					"poll_interval":          "10s",
+					"max_consecutive_errors": 8,
+				},
+			},
+			want: clients.ClientConfig{
+				OperationWait: clients.OperationWaitConfig{
+					PollInterval:         10 * time.Second,
+					MaxConsecutiveErrors: 8,
+				},
+			},
+		},
+		"invalid poll_interval": {
+			config: []interface{}{
+				map[string]interface{}{
+					"poll_interval": "-1s",
+				},
+			},
+			wantDiags: diag.Diagnostics{
+				diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "invalid operation_wait",
+					Detail:   "`poll_interval` must be a positive duration, got \"-1s\"",
+				},
+			},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			ignoreAttributePath := cmpopts.IgnoreFields(diag.Diagnostic{}, "AttributePath")
+
+			got, gotDiags := readOperationWait(tc.config, clients.ClientConfig{})
+			if diff := cmp.Diff(tc.wantDiags, gotDiags, ignoreAttributePath); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_resolveProject(t *testing.T) {
+	const otherOrganizationID = "11111111-1111-1111-1111-111111111111"
+
+	tcs := map[string]struct {
+		otherOrg     bool
+		otherProject bool
+		noProjects   bool
+		wantProject  string
+		wantErr      string
+	}{
+		"configured project": {
+			otherProject: true,
+			wantProject:  "other",
+		},
+		"configured organization": {
+			wantProject: "default",
+		},
+		"project outside the organization": {
+			otherOrg:     true,
+			otherProject: true,
+			wantErr:      "does not belong to organization",
+		},
+		"organization without projects": {
+			noProjects: true,
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			srv := hcptest.NewServer(t)
+			srv.Setenv(t)
+			projects := map[string]string{
+				"default": srv.ProjectID,
+				"other":   srv.AddProject("other"),
+			}
+
+			config := srv.ClientConfig()
+			config.OrganizationID = srv.OrganizationID
+			config.ProjectID = ""
+			if tc.otherProject {
+				config.OrganizationID = ""
+				config.ProjectID = projects["other"]
+			}
+			if tc.otherOrg {
+				config.OrganizationID = otherOrganizationID
+			}
+
+			client, err := clients.NewClient(config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tc.noProjects {
+				for _, id := range projects {
+					params := project_service.NewProjectServiceDeleteParams()
+					params.ID = id
+					if _, err := client.Project.ProjectServiceDelete(params, nil); err != nil {
+						t.Fatalf("unexpected error deleting project: %v", err)
+					}
+				}
+				srv.CompleteOperations()
+			}
+
+			diags := resolveProject(context.Background(), client)
+			if tc.wantErr != "" {
+				if !diags.HasError() || !strings.Contains(diags[0].Summary, tc.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantErr, diags)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if client.Config.OrganizationID != srv.OrganizationID {
+				t.Errorf("expected organization %q, got %q", srv.OrganizationID, client.Config.OrganizationID)
+			}
+			if want := projects[tc.wantProject]; client.Config.ProjectID != want {
+				t.Errorf("expected project %q, got %q", want, client.Config.ProjectID)
+			}
+		})
+	}
+}
@@ -74,6 +74,7 @@ func resourceBoundaryCluster() *schema.Resource {
 				Sensitive:        true,
 			},
 			// Optional inputs
+			"deletion_protection": deletionProtectionSchema("Boundary cluster"),
 			"project_id": {
 				Description: `
 The ID of the HCP project where the Boundary cluster is located.
@@ -284,6 +285,10 @@ func resourceBoundaryClusterCreate(ctx context.Context, d *schema.ResourceData,
 	}
 	d.SetId(url)
 
+	if err := setDeletionProtectionOnCreate(d); err != nil {
+		return diag.FromErr(err)
+	}
+
 	// Wait for the Boundary cluster to be created.
 	if err := clients.WaitForOperation(ctx, client, "create Boundary cluster", loc, createResp.Operation.ID); err != nil {
 		return diag.Errorf("unable to create Boundary cluster (%s): %v", createResp.ClusterID, err)
@@ -471,6 +476,10 @@ func resourceBoundaryClusterDelete(ctx context.Context, d *schema.ResourceData,
 	clusterID := link.ID
 	loc := link.Location
 
+	if diags := checkDeletionProtection(d, "Boundary cluster", clusterID); diags != nil {
+		return diags
+	}
+
 	log.Printf("[INFO] Deleting Boundary cluster (%s)", clusterID)
 
 	deleteResp, err := clients.DeleteBoundaryCluster(ctx, client, loc, clusterID)
@@ -527,6 +536,12 @@ func resourceBoundaryClusterImport(ctx context.Context, d *schema.ResourceData,
 
 	d.SetId(url)
 
+	// Imported resources are protected from deletion unless configured
+	// otherwise.
+	if err := d.Set("deletion_protection", true); err != nil {
+		return nil, err
+	}
+
 	return []*schema.ResourceData{d}, nil
 }
 
@@ -5,11 +5,13 @@ package providersdkv2
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/go-version"
 	consulmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-consul-service/stable/2021-02-04/models"
 	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
@@ -42,6 +44,7 @@ func resourceConsulCluster() *schema.Resource {
 		ReadContext:        resourceConsulClusterRead,
 		UpdateContext:      resourceConsulClusterUpdate,
 		DeleteContext:      resourceConsulClusterDelete,
+		CustomizeDiff:      resourceConsulClusterCustomizeDiff,
 		Timeouts: &schema.ResourceTimeout{
 			Default: &defaultConsulClusterTimeout,
 			Create:  &createUpdateConsulClusterTimeout,
@@ -86,10 +89,10 @@ func resourceConsulCluster() *schema.Resource {
 				ForceNew:    true,
 			},
 			"min_consul_version": {
-				Description:      "The minimum Consul patch version of the cluster. Allows only the rightmost version component to increment (E.g: `1.13.0` will allow installation of `1.13.2` and `1.13.3` etc., but not `1.14.0`). If not specified, it is defaulted to the version that is currently recommended by HCP.",
+				Description:      "The minimum Consul patch version of the cluster, or a version constraint such as `~> 1.16`. A version allows only the rightmost version component to increment (E.g: `1.13.0` will allow installation of `1.13.2` and `1.13.3` etc., but not `1.14.0`). A version constraint resolves to the latest available Consul version matching it. If not specified, it is defaulted to the version that is currently recommended by HCP.",
 				Type:             schema.TypeString,
 				Optional:         true,
-				ValidateDiagFunc: validateSemVer,
+				ValidateDiagFunc: validateSemVerOrConstraint,
 				DiffSuppressFunc: func(_, old, new string, _ *schema.ResourceData) bool {
 					// Suppress diff for non specified value
 					if new == "" {
@@ -99,12 +102,10 @@ func resourceConsulCluster() *schema.Resource {
 						return false
 					}
 
-					actualConsulVersion := version.Must(version.NewVersion(old))
-					currentTFVersion := version.Must(version.NewVersion(new))
 					log.Printf("[DEBUG] Actual Consul Version %v", old)
 					log.Printf("[DEBUG] Current TF Version %v", new)
-					// suppres diff if the specified min_consul_version is <= to the actual consul version
-					return currentTFVersion.LessThanOrEqual(actualConsulVersion)
+					// suppress diff if the actual consul version satisfies the specified min_consul_version
+					return consulVersionSatisfies(old, new)
 				},
 			},
 			"datacenter": {
@@ -145,6 +146,7 @@ func resourceConsulCluster() *schema.Resource {
 				ForceNew:    true,
 				Computed:    true,
 			},
+			"deletion_protection": deletionProtectionSchema("Consul cluster"),
 			"project_id": {
 				Description: `
 The ID of the HCP project where the HCP Consul cluster is located.
@@ -264,6 +266,68 @@ If a project is not configured in the HCP Provider config block, the oldest proj
 	}
 }
 
+// resourceConsulClusterCustomizeDiff resolves a min_consul_version constraint to
+// the Consul version the cluster will be created with or upgraded to, so that a
+// constraint matching no available version fails at plan time.
+func resourceConsulClusterCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("min_consul_version") {
+		return nil
+	}
+
+	oldVersion, newVersion := d.GetChange("min_consul_version")
+	minVersion := newVersion.(string)
+	if !consul.IsVersionConstraint(minVersion) {
+		return nil
+	}
+
+	// The cluster is left as is while its version satisfies the constraint.
+	if oldVersion.(string) != "" && consulVersionSatisfies(oldVersion.(string), minVersion) {
+		return nil
+	}
+
+	client := meta.(*clients.Client)
+
+	var versions []*consulmodels.HashicorpCloudConsul20210204Version
+	if d.Id() == "" {
+		// A project only known once applied is left to the checks done during apply.
+		if config := d.GetRawConfig(); !config.IsNull() && !config.GetAttr("project_id").IsKnown() {
+			return nil
+		}
+
+		projectID, err := GetProjectID(d.Get("project_id").(string), client.Config.ProjectID)
+		if err != nil {
+			return fmt.Errorf("unable to retrieve project ID: %v", err)
+		}
+
+		loc := &sharedmodels.HashicorpCloudLocationLocation{
+			OrganizationID: client.Config.OrganizationID,
+			ProjectID:      projectID,
+		}
+
+		versions, err = clients.GetAvailableHCPConsulVersionsForLocation(ctx, loc, client)
+		if err != nil {
+			return fmt.Errorf("error fetching available HCP Consul versions: %v", err)
+		}
+	} else {
+		link, err := buildLinkFromURL(d.Id(), ConsulClusterResourceType, client.Config.OrganizationID)
+		if err != nil {
+			return err
+		}
+
+		versions, err = clients.ListConsulUpgradeVersions(ctx, client, link.Location, link.ID)
+		if err != nil {
+			return fmt.Errorf("unable to list Consul upgrade versions (%s): %v", link.ID, err)
+		}
+	}
+
+	consulVersion, err := consul.GetLatestMatchingVersion(minVersion, versions)
+	if err != nil {
+		return cty.GetAttrPath("min_consul_version").NewError(err)
+	}
+
+	return d.SetNew("consul_version", input.NormalizeVersion(consulVersion))
+}
+
 func resourceConsulClusterCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*clients.Client)
 
@@ -297,7 +361,8 @@ func resourceConsulClusterCreate(ctx context.Context, d *schema.ResourceData, me
 	}
 
 	// Check for an existing Consul cluster
-	_, err = clients.GetConsulClusterByID(ctx, client, loc, clusterID)
+	var pendingOperationID string
+	existing, err := clients.GetConsulClusterByID(ctx, client, loc, clusterID)
 	if err != nil {
 		if !clients.IsResponseCodeNotFound(err) {
 			return diag.Errorf("unable to check for presence of an existing Consul cluster (%s): %v", clusterID, err)
@@ -306,7 +371,22 @@ func resourceConsulClusterCreate(ctx context.Context, d *schema.ResourceData, me
 		// a 404 indicates a Consul cluster was not found
 		log.Printf("[INFO] Consul cluster (%s) not found, proceeding with create", clusterID)
 	} else {
-		return diag.Errorf("a Consul cluster with cluster_id=%q in project_id=%q already exists - to be managed via Terraform this resource needs to be imported into the State.  Please see the resource documentation for hcp_consul_cluster for more information.", clusterID, loc.ProjectID)
+		// a cluster still being created was most likely left behind by an
+		// interrupted apply, in which case we resume waiting for it
+		if *existing.State == consulmodels.HashicorpCloudConsul20210204ClusterStatePENDING ||
+			*existing.State == consulmodels.HashicorpCloudConsul20210204ClusterStateCREATING {
+			op, err := clients.GetPendingOperation(ctx, client, loc, ConsulClusterResourceType, clusterID)
+			if err != nil {
+				return diag.Errorf("unable to check for a pending create of Consul cluster (%s): %v", clusterID, err)
+			}
+			if op != nil {
+				pendingOperationID = op.ID
+			}
+		}
+
+		if pendingOperationID == "" {
+			return diag.Errorf("a Consul cluster with cluster_id=%q in project_id=%q already exists - to be managed via Terraform this resource needs to be imported into the State.  Please see the resource documentation for hcp_consul_cluster for more information.", clusterID, loc.ProjectID)
+		}
 	}
 
 	// fetch available version from HCP
@@ -319,11 +399,9 @@ func resourceConsulClusterCreate(ctx context.Context, d *schema.ResourceData, me
 	consulVersion := consul.RecommendedVersion(availableConsulVersions)
 	v, ok := d.GetOk("min_consul_version")
 	if ok {
-		consulVersion = input.NormalizeVersion(v.(string))
-
-		// Attempt to get the latest patch version of the given min_consul_version.
-		if patch := consul.GetLatestPatch(consulVersion, availableConsulVersions); patch != "" {
-			consulVersion = input.NormalizeVersion(patch)
+		consulVersion, err = resolveMinConsulVersion(v.(string), availableConsulVersions)
+		if err != nil {
+			return diag.FromErr(err)
 		}
 	}
 
@@ -411,9 +489,15 @@ func resourceConsulClusterCreate(ctx context.Context, d *schema.ResourceData, me
 		Location:      loc,
 	}
 
-	payload, err := clients.CreateConsulCluster(ctx, client, loc, consulCuster)
-	if err != nil {
-		return diag.Errorf("unable to create Consul cluster (%s): %v", clusterID, err)
+	operationID := pendingOperationID
+	if operationID == "" {
+		payload, err := clients.CreateConsulCluster(ctx, client, loc, consulCuster)
+		if err != nil {
+			return diag.Errorf("unable to create Consul cluster (%s): %v", clusterID, err)
+		}
+		operationID = payload.Operation.ID
+	} else {
+		log.Printf("[INFO] Resuming pending create of Consul cluster (%s)", clusterID)
 	}
 
 	link := newLink(loc, ConsulClusterResourceType, clusterID)
@@ -424,17 +508,31 @@ func resourceConsulClusterCreate(ctx context.Context, d *schema.ResourceData, me
 
 	d.SetId(url)
 
+	if err := setDeletionProtectionOnCreate(d); err != nil {
+		return diag.FromErr(err)
+	}
+
 	// wait for the Consul cluster to be created
-	if err := clients.WaitForOperation(ctx, client, "create Consul cluster", loc, payload.Operation.ID); err != nil {
-		return diag.Errorf("unable to create Consul cluster (%s): %v", payload.Cluster.ID, err)
+	if err := clients.WaitForOperation(ctx, client, "create Consul cluster", loc, operationID); err != nil {
+		if errors.Is(ctx.Err(), context.Canceled) {
+			// the apply was interrupted while the operation carries on in HCP,
+			// so keep the cluster in the state without tainting it and let the
+			// next refresh resume waiting for it instead of replacing it
+			return diag.Diagnostics{{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Consul cluster (%s) is still being created", clusterID),
+				Detail:   fmt.Sprintf("The apply was interrupted while waiting for operation %s. The next plan or apply waits for it to finish. The root ACL token is only generated by an uninterrupted create; use hcp_consul_cluster_root_token to generate one.", operationID),
+			}}
+		}
+		return diag.Errorf("unable to create Consul cluster (%s): %v", clusterID, err)
 	}
 
-	log.Printf("[INFO] Created Consul cluster (%s)", payload.Cluster.ID)
+	log.Printf("[INFO] Created Consul cluster (%s)", clusterID)
 
 	// get the created Consul cluster
-	cluster, err := clients.GetConsulClusterByID(ctx, client, loc, payload.Cluster.ID)
+	cluster, err := clients.GetConsulClusterByID(ctx, client, loc, clusterID)
 	if err != nil {
-		return diag.Errorf("unable to retrieve Consul cluster (%s): %v", payload.Cluster.ID, err)
+		return diag.Errorf("unable to retrieve Consul cluster (%s): %v", clusterID, err)
 	}
 
 	if err := setConsulClusterResourceData(d, cluster); err != nil {
@@ -442,7 +540,7 @@ func resourceConsulClusterCreate(ctx context.Context, d *schema.ResourceData, me
 	}
 
 	// get the cluster's Consul client config files
-	clientConfigFiles, err := clients.GetConsulClientConfigFiles(ctx, client, loc, payload.Cluster.ID)
+	clientConfigFiles, err := clients.GetConsulClientConfigFiles(ctx, client, loc, clusterID)
 	if err != nil {
 		log.Printf("[WARN] unable to retrieve Consul cluster (%s) client config files: %v", clusterID, err)
 		return nil
@@ -453,9 +551,9 @@ func resourceConsulClusterCreate(ctx context.Context, d *schema.ResourceData, me
 	}
 
 	// create customer root ACL token
-	rootACLToken, err := clients.CreateCustomerRootACLToken(ctx, client, loc, payload.Cluster.ID)
+	rootACLToken, err := clients.CreateCustomerRootACLToken(ctx, client, loc, clusterID)
 	if err != nil {
-		return diag.Errorf("unable to create root ACL token for cluster (%s): %v", payload.Cluster.ID, err)
+		return diag.Errorf("unable to create root ACL token for cluster (%s): %v", clusterID, err)
 	}
 
 	// Only set root token keys after create
@@ -637,6 +735,27 @@ func resourceConsulClusterRead(ctx context.Context, d *schema.ResourceData, meta
 		return diag.Errorf("unable to fetch Consul cluster (%s): %v", clusterID, err)
 	}
 
+	// a cluster still being created was left behind by an interrupted apply,
+	// in which case we resume waiting for it
+	if *cluster.State == consulmodels.HashicorpCloudConsul20210204ClusterStatePENDING ||
+		*cluster.State == consulmodels.HashicorpCloudConsul20210204ClusterStateCREATING {
+		op, err := clients.GetPendingOperation(ctx, client, loc, ConsulClusterResourceType, clusterID)
+		if err != nil {
+			return diag.Errorf("unable to check for a pending create of Consul cluster (%s): %v", clusterID, err)
+		}
+		if op != nil {
+			log.Printf("[INFO] Resuming pending create of Consul cluster (%s)", clusterID)
+			if err := clients.WaitForOperation(ctx, client, "create Consul cluster", loc, op.ID); err != nil {
+				return diag.Errorf("unable to create Consul cluster (%s): %v", clusterID, err)
+			}
+
+			cluster, err = clients.GetConsulClusterByID(ctx, client, loc, clusterID)
+			if err != nil {
+				return diag.Errorf("unable to fetch Consul cluster (%s): %v", clusterID, err)
+			}
+		}
+	}
+
 	// we should only ever get a CodeNotFound response if the cluster is deleted. The below is precautionary
 	if *cluster.State == consulmodels.HashicorpCloudConsul20210204ClusterStateDELETED {
 		log.Printf("[WARN] Consul cluster (%s) was deleted", clusterID)
@@ -692,6 +811,11 @@ func resourceConsulClusterUpdate(ctx context.Context, d *schema.ResourceData, me
 	ipAllowlistChanged := d.HasChange("ip_allowlist")
 
 	if !sizeChanged && !versionChanged && !ipAllowlistChanged {
+		// deletion_protection only lives in the state
+		if d.HasChange("deletion_protection") {
+			return nil
+		}
+
 		return diag.Errorf("at least one of: [min_consul_version, size, ip_allowlist] is required in order to update the cluster")
 	}
 
@@ -713,19 +837,17 @@ func resourceConsulClusterUpdate(ctx context.Context, d *schema.ResourceData, me
 		if err != nil {
 			return diag.Errorf("unable to list Consul upgrade versions (%s): %v", clusterID, err)
 		}
-		version := d.Get("min_consul_version")
-		newConsulVersion := input.NormalizeVersion(version.(string))
-
-		// Attempt to get the latest patch version of the given min_consul_version.
-		if patch := consul.GetLatestPatch(newConsulVersion, upgradeVersions); patch != "" {
-			newConsulVersion = input.NormalizeVersion(patch)
-		}
 
 		// Check that there are any valid upgrade versions
 		if upgradeVersions == nil {
 			return diag.Errorf("no upgrade versions of Consul are available for this cluster; you may already be on the latest Consul version supported by HCP")
 		}
 
+		newConsulVersion, err := resolveMinConsulVersion(d.Get("min_consul_version").(string), upgradeVersions)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
 		// Validate that the upgrade version is valid
 		if !consul.IsValidVersion(newConsulVersion, upgradeVersions) {
 			return diag.Errorf("specified Consul version (%s) is unavailable; must be one of: [%s]", newConsulVersion, consul.VersionsToString(upgradeVersions))
@@ -810,6 +932,10 @@ func resourceConsulClusterDelete(ctx context.Context, d *schema.ResourceData, me
 	clusterID := link.ID
 	loc := link.Location
 
+	if diags := checkDeletionProtection(d, "Consul cluster", clusterID); diags != nil {
+		return diags
+	}
+
 	log.Printf("[INFO] Deleting Consul cluster (%s)", clusterID)
 
 	deleteResp, err := clients.DeleteConsulCluster(ctx, client, loc, clusterID)
@@ -866,6 +992,12 @@ func resourceConsulClusterImport(ctx context.Context, d *schema.ResourceData, me
 
 	d.SetId(url)
 
+	// Imported resources are protected from deletion unless configured
+	// otherwise.
+	if err := d.Set("deletion_protection", true); err != nil {
+		return nil, err
+	}
+
 	return []*schema.ResourceData{d}, nil
 }
 
@@ -888,3 +1020,52 @@ func buildIPAllowlistConsulCluster(cidrs []interface{}) ([]*consulmodels.Hashico
 
 	return ipAllowList, nil
 }
+
+// resolveMinConsulVersion resolves the min_consul_version of a cluster against
+// the given versions. A version resolves to its latest available patch, and a
+// version constraint to the latest available version matching it.
+func resolveMinConsulVersion(minVersion string, versions []*consulmodels.HashicorpCloudConsul20210204Version) (string, error) {
+	if consul.IsVersionConstraint(minVersion) {
+		consulVersion, err := consul.GetLatestMatchingVersion(minVersion, versions)
+		if err != nil {
+			return "", err
+		}
+
+		return input.NormalizeVersion(consulVersion), nil
+	}
+
+	consulVersion := input.NormalizeVersion(minVersion)
+
+	// Attempt to get the latest patch version of the given min_consul_version.
+	if patch := consul.GetLatestPatch(consulVersion, versions); patch != "" {
+		consulVersion = input.NormalizeVersion(patch)
+	}
+
+	return consulVersion, nil
+}
+
+// consulVersionSatisfies determines whether the actual Consul version of a
+// cluster satisfies its min_consul_version, which is either a minimum version
+// or a version constraint.
+func consulVersionSatisfies(actual, minVersion string) bool {
+	actualVersion, err := version.NewVersion(actual)
+	if err != nil {
+		return false
+	}
+
+	if consul.IsVersionConstraint(minVersion) {
+		constraints, err := version.NewConstraint(minVersion)
+		if err != nil {
+			return false
+		}
+
+		return constraints.Check(actualVersion)
+	}
+
+	minimumVersion, err := version.NewVersion(minVersion)
+	if err != nil {
+		return false
+	}
+
+	return minimumVersion.LessThanOrEqual(actualVersion)
+}
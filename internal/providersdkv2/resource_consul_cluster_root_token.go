@@ -9,9 +9,11 @@ import (
 	"fmt"
 	"log"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 
+	consulmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-consul-service/stable/2021-02-04/models"
 	"github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -28,7 +30,7 @@ var defaultRootTokenTimeoutDuration = time.Minute * 5
 const rootTokenKubernetesSecretTemplate = `apiVersion: v1
 kind: Secret
 metadata:
-  name: %s-bootstrap-token
+  name: %s%s
 type: Opaque
 data:
   token: %s`
@@ -41,7 +43,9 @@ func resourceConsulClusterRootToken() *schema.Resource {
 			"You can also generate this root token from the HCP Consul UI.",
 		CreateContext: resourceConsulClusterRootTokenCreate,
 		ReadContext:   resourceConsulClusterRootTokenRead,
+		UpdateContext: resourceConsulClusterRootTokenUpdate,
 		DeleteContext: resourceConsulClusterRootTokenDelete,
+		CustomizeDiff: resourceConsulClusterRootTokenCustomizeDiff,
 		Timeouts: &schema.ResourceTimeout{
 			Default: &defaultRootTokenTimeoutDuration,
 		},
@@ -66,6 +70,52 @@ If a project is not configured in the HCP Provider config block, the oldest proj
 				ValidateFunc: validation.IsUUID,
 				Computed:     true,
 			},
+			"rotate_triggers": {
+				Description: "A map of arbitrary string key/value pairs that will force recreation " +
+					"of the root token when they change, enabling rotation based on external conditions such " +
+					"as a rotating timestamp. Changing this forces a new resource to be created.",
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"rotation_period": {
+				Description: "How long after its creation the root token is rotated, in golang's time.Duration string format. " +
+					"Once the period has elapsed, `terraform plan` proposes to replace the resource. " +
+					"If not specified, the root token is only rotated when `rotate_triggers` change.",
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: func(i any, k string) (warnings []string, errors []error) {
+					rotationPeriod, err := time.ParseDuration(i.(string))
+					if err != nil {
+						errors = append(errors, fmt.Errorf("expected %s to be a valid duration, got %s", k, i))
+					} else if rotationPeriod <= 0 {
+						errors = append(errors, fmt.Errorf("expected %s to be positive, got %s", k, i))
+					}
+					return warnings, errors
+				},
+				DiffSuppressFunc: func(_, old, new string, _ *schema.ResourceData) bool {
+					oldTime, _ := time.ParseDuration(old)
+					newTime, _ := time.ParseDuration(new)
+					return newTime == oldTime
+				},
+			},
+			"kubernetes_secret_name": {
+				Description: "The name of the Kubernetes secret in `kubernetes_secret`. Defaults to `<cluster_id>-bootstrap-token`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`),
+					"must be a valid Kubernetes resource name"),
+			},
+			"kubernetes_secret_namespace": {
+				Description: "The namespace of the Kubernetes secret in `kubernetes_secret`. If not specified, the secret has no namespace.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`),
+					"must be a valid Kubernetes namespace"),
+			},
 			// Computed outputs
 			"accessor_id": {
 				Description: "The accessor ID of the root ACL token.",
@@ -84,6 +134,11 @@ If a project is not configured in the HCP Provider config block, the oldest proj
 				Computed:    true,
 				Sensitive:   true,
 			},
+			"created_at": {
+				Description: "The time that the root ACL token was created. For root ACL tokens created before this time was tracked, the time they were first refreshed.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -145,7 +200,14 @@ func resourceConsulClusterRootTokenCreate(ctx context.Context, d *schema.Resourc
 		return diag.FromErr(err)
 	}
 
-	err = d.Set("kubernetes_secret", generateKubernetesSecret(secretID, clusterID))
+	err = d.Set("kubernetes_secret", generateKubernetesSecret(secretID,
+		rootTokenKubernetesSecretName(d, clusterID),
+		d.Get("kubernetes_secret_namespace").(string)))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = d.Set("created_at", time.Now().UTC().Format(time.RFC3339))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -196,9 +258,65 @@ func resourceConsulClusterRootTokenRead(ctx context.Context, d *schema.ResourceD
 		)
 	}
 
+	// Root tokens created before created_at was tracked are rotated one
+	// rotation_period after they are first refreshed.
+	if d.Get("created_at").(string) == "" {
+		if err := d.Set("created_at", time.Now().UTC().Format(time.RFC3339)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return nil
 }
 
+// resourceConsulClusterRootTokenUpdate renders the Kubernetes secret again for the new secret name and namespace. The
+// new rotation_period is applied during the next plan.
+func resourceConsulClusterRootTokenUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clusterID := d.Get("cluster_id").(string)
+	if matchesID(clusterID) {
+		clusterID = filepath.Base(clusterID)
+	}
+
+	err := d.Set("kubernetes_secret", generateKubernetesSecret(d.Get("secret_id").(string),
+		rootTokenKubernetesSecretName(d, clusterID),
+		d.Get("kubernetes_secret_namespace").(string)))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceConsulClusterRootTokenRead(ctx, d, meta)
+}
+
+// resourceConsulClusterRootTokenCustomizeDiff replaces the root token once its rotation_period has elapsed, and
+// renders the Kubernetes secret again when its name or namespace change.
+func resourceConsulClusterRootTokenCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	if d.HasChanges("kubernetes_secret_name", "kubernetes_secret_namespace") {
+		if err := d.SetNewComputed("kubernetes_secret"); err != nil {
+			return err
+		}
+	}
+
+	createdAt := d.Get("created_at").(string)
+	elapsed, err := rootTokenRotationElapsed(d.Get("rotation_period").(string), createdAt)
+	if err != nil || !elapsed {
+		return err
+	}
+
+	log.Printf("[INFO] root ACL token for Consul cluster (%s) was created at %s, planning its rotation", d.Get("cluster_id").(string), createdAt)
+
+	for _, k := range []string{"accessor_id", "secret_id", "kubernetes_secret", "created_at"} {
+		if err := d.SetNewComputed(k); err != nil {
+			return err
+		}
+	}
+
+	return d.ForceNew("secret_id")
+}
+
 // resourceClusterRootTokenDelete will "delete" an existing token by creating a new one,
 // that will not be returned, and invalidating the previous token for the cluster.
 func resourceConsulClusterRootTokenDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -221,7 +339,7 @@ func resourceConsulClusterRootTokenDelete(ctx context.Context, d *schema.Resourc
 
 	log.Printf("[INFO] reading Consul cluster (%s) [project_id=%s, organization_id=%s]", clusterID, loc.ProjectID, loc.OrganizationID)
 
-	_, err = clients.GetConsulClusterByID(ctx, client, loc, clusterID)
+	cluster, err := clients.GetConsulClusterByID(ctx, client, loc, clusterID)
 	if err != nil {
 		if clients.IsResponseCodeNotFound(err) {
 			// No cluster exists, so this root token should be removed from state
@@ -238,6 +356,26 @@ func resourceConsulClusterRootTokenDelete(ctx context.Context, d *schema.Resourc
 		)
 	}
 
+	// Only a root token that is still the cluster's current one is revoked. A
+	// root token replaced since, e.g. by a rotation with create_before_destroy,
+	// is already invalid, and a new token generated here would invalidate its
+	// replacement instead. If the cluster cannot be asked, the root token is
+	// revoked so that destroying the resource never leaves it valid.
+	current, err := isCurrentConsulRootToken(ctx, client, cluster, d)
+	if err != nil {
+		log.Printf("[WARN] unable to check whether root ACL token (%s) is the current one of Consul cluster (%s), revoking it: %v",
+			d.Id(),
+			clusterID,
+			err,
+		)
+	} else if !current {
+		log.Printf("[INFO] root ACL token (%s) of Consul cluster (%s) was already replaced; removing root token.",
+			d.Id(),
+			clusterID,
+		)
+		return nil
+	}
+
 	// generate a new token to invalidate the previous one, but discard the response
 	_, err = clients.CreateCustomerRootACLToken(ctx, client, loc, clusterID)
 	if err != nil {
@@ -250,12 +388,71 @@ func resourceConsulClusterRootTokenDelete(ctx context.Context, d *schema.Resourc
 	return nil
 }
 
+// isCurrentConsulRootToken reports whether the root token of the resource is
+// still the current root token of the cluster, by comparing its accessor ID
+// with the one the cluster's Consul API reports for its secret ID.
+func isCurrentConsulRootToken(ctx context.Context, client *clients.Client, cluster *consulmodels.HashicorpCloudConsul20210204Cluster, d *schema.ResourceData) (bool, error) {
+	if cluster.DNSNames == nil {
+		return false, fmt.Errorf("the cluster has no address")
+	}
+
+	address := cluster.DNSNames.Public
+	if address == "" {
+		address = cluster.DNSNames.Private
+	}
+
+	accessorID, err := clients.GetConsulACLTokenAccessorID(ctx, client, "https://"+address, d.Get("secret_id").(string))
+	if err != nil {
+		return false, err
+	}
+
+	return accessorID != "" && accessorID == d.Get("accessor_id").(string), nil
+}
+
 // generateKubernetesSecret will generate a Kubernetes secret with
 // a base64 encoded root token secret as it's token.
-func generateKubernetesSecret(rootTokenSecretID, clusterID string) string {
+func generateKubernetesSecret(rootTokenSecretID, name, namespace string) string {
+	// the secret has no namespace unless one is given
+	var namespaceLine string
+	if namespace != "" {
+		namespaceLine = "\n  namespace: " + namespace
+	}
+
 	return fmt.Sprintf(rootTokenKubernetesSecretTemplate,
-		// lowercase the name
-		strings.ToLower(clusterID),
+		name,
+		namespaceLine,
 		// base64 encode the secret value
 		base64.StdEncoding.EncodeToString([]byte(rootTokenSecretID)))
 }
+
+// rootTokenKubernetesSecretName returns the name of the Kubernetes secret of
+// the root token, which defaults to one derived from the cluster ID.
+func rootTokenKubernetesSecretName(d *schema.ResourceData, clusterID string) string {
+	if name, ok := d.GetOk("kubernetes_secret_name"); ok {
+		return name.(string)
+	}
+
+	// lowercase the name
+	return fmt.Sprintf("%s-bootstrap-token", strings.ToLower(clusterID))
+}
+
+// rootTokenRotationElapsed returns whether the rotation period of a root token
+// created at createdAt has elapsed. It is false without a rotation period, or
+// for root tokens created before created_at was tracked.
+func rootTokenRotationElapsed(rotationPeriod, createdAt string) (bool, error) {
+	if rotationPeriod == "" || createdAt == "" {
+		return false, nil
+	}
+
+	period, err := time.ParseDuration(rotationPeriod)
+	if err != nil {
+		return false, err
+	}
+
+	created, err := time.Parse(time.RFC3339, createdAt)
+	if err != nil {
+		return false, err
+	}
+
+	return !time.Now().Before(created.Add(period)), nil
+}
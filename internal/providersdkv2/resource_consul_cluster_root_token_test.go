@@ -0,0 +1,143 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providersdkv2
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestConsulClusterRootTokenRotation rotates a root token against the
+// in-process fake HCP server.
+func TestConsulClusterRootTokenRotation(t *testing.T) {
+	client, srv := newTestClient(t)
+	ctx := context.Background()
+
+	createTestHVN(t, ctx, client)
+	createTestConsulCluster(t, ctx, client, map[string]interface{}{"cluster_id": "Test-Consul"})
+
+	r := resourceConsulClusterRootToken()
+
+	raw := map[string]interface{}{
+		"cluster_id":      "Test-Consul",
+		"rotation_period": "1h",
+		"rotate_triggers": map[string]interface{}{"rotation": "1"},
+	}
+	token := schema.TestResourceDataRaw(t, r.Schema, raw)
+	if diags := resourceConsulClusterRootTokenCreate(ctx, token, client); diags.HasError() {
+		t.Fatalf("unexpected error creating root token: %v", diags)
+	}
+
+	secret := base64.StdEncoding.EncodeToString([]byte(token.Get("secret_id").(string)))
+	expected := "apiVersion: v1\nkind: Secret\nmetadata:\n  name: test-consul-bootstrap-token\ntype: Opaque\ndata:\n  token: " + secret
+	if got := token.Get("kubernetes_secret").(string); got != expected {
+		t.Errorf("unexpected kubernetes_secret:\n%s", got)
+	}
+	if token.Get("created_at").(string) == "" {
+		t.Error("expected created_at to be set")
+	}
+
+	// Changing the secret name and namespace keeps the root token.
+	raw["kubernetes_secret_name"] = "consul-bootstrap-acl-token"
+	raw["kubernetes_secret_namespace"] = "consul"
+	diff, err := r.Diff(ctx, token.State(), sdkterraform.NewResourceConfigRaw(raw), client)
+	if err != nil {
+		t.Fatalf("unexpected error planning root token: %v", err)
+	}
+	if diff.RequiresNew() {
+		t.Fatal("expected the root token to be kept")
+	}
+	state, diags := r.Apply(ctx, token.State(), diff, client)
+	if diags.HasError() {
+		t.Fatalf("unexpected error updating root token: %v", diags)
+	}
+	expected = "apiVersion: v1\nkind: Secret\nmetadata:\n  name: consul-bootstrap-acl-token\n  namespace: consul\ntype: Opaque\ndata:\n  token: " + secret
+	if got := state.Attributes["kubernetes_secret"]; got != expected {
+		t.Errorf("unexpected kubernetes_secret:\n%s", got)
+	}
+
+	tcs := map[string]struct {
+		createdAt       time.Time
+		rotationPeriod  string
+		expectedReplace bool
+	}{
+		"within period": {
+			createdAt:      time.Now().Add(-30 * time.Minute),
+			rotationPeriod: "1h",
+		},
+		"period elapsed": {
+			createdAt:       time.Now().Add(-2 * time.Hour),
+			rotationPeriod:  "1h",
+			expectedReplace: true,
+		},
+		"no period": {
+			createdAt: time.Now().Add(-48 * time.Hour),
+		},
+	}
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			s := state.DeepCopy()
+			s.Attributes["created_at"] = tc.createdAt.UTC().Format(time.RFC3339)
+
+			config := map[string]interface{}{
+				"cluster_id":                  "Test-Consul",
+				"kubernetes_secret_name":      "consul-bootstrap-acl-token",
+				"kubernetes_secret_namespace": "consul",
+				"rotate_triggers":             map[string]interface{}{"rotation": "1"},
+			}
+			if tc.rotationPeriod != "" {
+				config["rotation_period"] = tc.rotationPeriod
+			}
+
+			diff, err := r.Diff(ctx, s, sdkterraform.NewResourceConfigRaw(config), client)
+			if err != nil {
+				t.Fatalf("unexpected error planning root token: %v", err)
+			}
+			if replace := diff != nil && diff.RequiresNew(); replace != tc.expectedReplace {
+				t.Errorf("expected replacement to be %t, got %t", tc.expectedReplace, replace)
+			}
+		})
+	}
+
+	// Changing rotate_triggers rotates the root token. With
+	// create_before_destroy, the new one is created first, which already
+	// revokes the old one.
+	raw["rotate_triggers"] = map[string]interface{}{"rotation": "2"}
+	diff, err = r.Diff(ctx, state, sdkterraform.NewResourceConfigRaw(raw), client)
+	if err != nil {
+		t.Fatalf("unexpected error planning root token: %v", err)
+	}
+	if !diff.RequiresNew() {
+		t.Fatal("expected the root token to be replaced")
+	}
+	replacement := schema.TestResourceDataRaw(t, r.Schema, raw)
+	if diags := resourceConsulClusterRootTokenCreate(ctx, replacement, client); diags.HasError() {
+		t.Fatalf("unexpected error creating root token: %v", diags)
+	}
+
+	// Destroying the replaced root token leaves its replacement valid.
+	if diags := resourceConsulClusterRootTokenDelete(ctx, token, client); diags.HasError() {
+		t.Fatalf("unexpected error deleting root token: %v", diags)
+	}
+	if got := srv.ConsulRootTokenAccessorID("Test-Consul"); got != replacement.Id() {
+		t.Errorf("expected the replacement root token (%s) to stay valid, got %q", replacement.Id(), got)
+	}
+
+	// Destroying the current root token revokes it, even once its
+	// rotation_period has elapsed.
+	if err := replacement.Set("created_at", time.Now().Add(-2*time.Hour).UTC().Format(time.RFC3339)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diags := resourceConsulClusterRootTokenDelete(ctx, replacement, client); diags.HasError() {
+		t.Fatalf("unexpected error deleting root token: %v", diags)
+	}
+	if got := srv.ConsulRootTokenAccessorID("Test-Consul"); got == replacement.Id() {
+		t.Error("expected the root token to be revoked")
+	}
+}
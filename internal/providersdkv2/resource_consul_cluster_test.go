@@ -7,9 +7,14 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
+	consulmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-consul-service/stable/2021-02-04/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
@@ -316,3 +321,134 @@ func testAccCheckConsulClusterDestroy(s *terraform.State) error {
 	}
 	return nil
 }
+
+// TestConsulClusterVersionConstraint resolves min_consul_version constraints
+// against the versions of the in-process fake HCP server.
+func TestConsulClusterVersionConstraint(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := context.Background()
+
+	createTestHVN(t, ctx, client)
+
+	r := resourceConsulCluster()
+
+	tcs := map[string]struct {
+		constraint  string
+		expected    string
+		expectedErr string
+	}{
+		"pessimistic patch": {
+			constraint: "~> 1.15.0",
+			expected:   "v1.15.9",
+		},
+		"pessimistic minor": {
+			constraint: "~> 1.15",
+			expected:   "v1.17.2",
+		},
+		"range": {
+			constraint: ">= 1.15, < 1.17",
+			expected:   "v1.16.5",
+		},
+		"no match": {
+			constraint:  "~> 1.14.0",
+			expectedErr: "must match one of: [v1.17.2 (recommended), v1.16.5, v1.15.9]",
+		},
+	}
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"cluster_id":         "test-consul",
+				"hvn_id":             "test-hvn",
+				"tier":               "development",
+				"min_consul_version": tc.constraint,
+			}
+			diff, err := r.Diff(ctx, nil, sdkterraform.NewResourceConfigRaw(raw), client)
+			if tc.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+					t.Errorf("expected error to contain %q, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error planning Consul cluster: %v", err)
+			}
+			if got := diff.Attributes["consul_version"].New; got != tc.expected {
+				t.Errorf("expected planned consul_version %q, got %q", tc.expected, got)
+			}
+		})
+	}
+
+	raw := map[string]interface{}{
+		"cluster_id":         "test-consul",
+		"hvn_id":             "test-hvn",
+		"tier":               "development",
+		"min_consul_version": "~> 1.16.0",
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	if diags := resourceConsulClusterCreate(ctx, d, client); diags.HasError() {
+		t.Fatalf("unexpected error creating Consul cluster: %v", diags)
+	}
+	if got := d.Get("consul_version").(string); got != "v1.16.5" {
+		t.Errorf("expected consul_version v1.16.5, got %q", got)
+	}
+
+	// A constraint the cluster already satisfies leaves it as is.
+	diff, err := r.Diff(ctx, d.State(), sdkterraform.NewResourceConfigRaw(raw), client)
+	if err != nil {
+		t.Fatalf("unexpected error planning Consul cluster: %v", err)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Errorf("expected no changes, got %v", diff.Attributes)
+	}
+
+	// Otherwise the cluster is upgraded to the latest matching version.
+	raw["min_consul_version"] = "~> 1.17.0"
+	diff, err = r.Diff(ctx, d.State(), sdkterraform.NewResourceConfigRaw(raw), client)
+	if err != nil {
+		t.Fatalf("unexpected error planning Consul cluster: %v", err)
+	}
+	if got := diff.Attributes["consul_version"].New; got != "v1.17.2" {
+		t.Errorf("expected planned consul_version v1.17.2, got %q", got)
+	}
+	state, diags := r.Apply(ctx, d.State(), diff, client)
+	if diags.HasError() {
+		t.Fatalf("unexpected error upgrading Consul cluster: %v", diags)
+	}
+	if got := state.Attributes["consul_version"]; got != "v1.17.2" {
+		t.Errorf("expected consul_version v1.17.2, got %q", got)
+	}
+}
+
+// TestConsulClusterCreate_resume checks that a create interrupted while
+// waiting for the operation keeps the cluster in the state, and that the next
+// refresh resumes waiting for the pending operation.
+func TestConsulClusterCreate_resume(t *testing.T) {
+	client, srv := newTestClient(t)
+	createTestHVN(t, context.Background(), client)
+
+	// Interrupt the create while the operation is still running.
+	srv.PendingPolls = 1
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(time.Second, cancel)
+
+	d := schema.TestResourceDataRaw(t, resourceConsulCluster().Schema, map[string]interface{}{
+		"cluster_id": "test-consul",
+		"hvn_id":     "test-hvn",
+		"tier":       "development",
+	})
+	diags := resourceConsulClusterCreate(ctx, d, client)
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Fatalf("expected a warning for the interrupted create, got %v", diags)
+	}
+	if d.Id() == "" {
+		t.Fatal("expected the interrupted cluster to be kept in the state")
+	}
+
+	// The next refresh waits for the operation that is still running.
+	if diags := resourceConsulClusterRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error reading Consul cluster: %v", diags)
+	}
+	if got := d.Get("state").(string); got != string(consulmodels.HashicorpCloudConsul20210204ClusterStateRUNNING) {
+		t.Errorf("expected cluster to be RUNNING, got %q", got)
+	}
+}
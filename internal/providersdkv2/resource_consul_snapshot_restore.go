@@ -0,0 +1,207 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providersdkv2
+
+import (
+	"context"
+	"log"
+
+	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+)
+
+func resourceConsulSnapshotRestore() *schema.Resource {
+	return &schema.Resource{
+		DeprecationMessage: "HashiCorp plans to sunset HashiCorp Consul Dedicated (HCD) in November 2025, more information about the EOL will be provided to existing customers directly",
+		Description: "The Consul snapshot restore resource restores a Consul snapshot on an HCP Consul cluster. " +
+			"The restore runs when the resource is created; destroying the resource does not revert it.",
+		CreateContext: resourceConsulSnapshotRestoreCreate,
+		ReadContext:   resourceConsulSnapshotRestoreRead,
+		DeleteContext: resourceConsulSnapshotRestoreDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: snapshotRestoreImport(ConsulSnapshotRestoreResourceType),
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create:  &snapshotCreateUpdateDeleteTimeoutDuration,
+			Default: &defaultSnapshotTimeoutDuration,
+		},
+		Schema: map[string]*schema.Schema{
+			// Required inputs
+			"cluster_id": {
+				Description:      "The ID of the HCP Consul cluster to restore the snapshot on.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateSlugID,
+			},
+			"snapshot_id": {
+				Description:      "The ID of the Consul snapshot to restore.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateStringNotEmpty,
+			},
+			// Optional inputs
+			"project_id": {
+				Description: `
+The ID of the HCP project where the HCP Consul cluster and the snapshot are located.
+If not specified, the project specified in the HCP Provider config block will be used, if configured.
+If a project is not configured in the HCP Provider config block, the oldest project in the organization will be used.`,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+				Computed:     true,
+			},
+			"take_snapshot": {
+				Description: "Whether to take a snapshot of the cluster before restoring. Defaults to `false`.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+			},
+			// computed outputs
+			"organization_id": {
+				Description: "The ID of the HCP organization where the project the HCP Consul cluster is located.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"restored_at": {
+				Description: "Timestamp of when the snapshot was last restored. Blank once the snapshot has been deleted.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceConsulSnapshotRestoreCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client)
+
+	clusterID := d.Get("cluster_id").(string)
+	snapshotID := d.Get("snapshot_id").(string)
+
+	projectID, err := GetProjectID(d.Get("project_id").(string), client.Config.ProjectID)
+	if err != nil {
+		return diag.Errorf("unable to retrieve project ID: %v", err)
+	}
+
+	loc := &sharedmodels.HashicorpCloudLocationLocation{
+		OrganizationID: client.Config.OrganizationID,
+		ProjectID:      projectID,
+	}
+
+	// Check for an existing Consul cluster
+	cluster, err := clients.GetConsulClusterByID(ctx, client, loc, clusterID)
+	if err != nil {
+		if !clients.IsResponseCodeNotFound(err) {
+			return diag.Errorf("unable to check for presence of an existing Consul cluster (%s): %v", clusterID, err)
+		}
+
+		// a 404 indicates a Consul cluster was not found
+		return diag.Errorf("unable to restore snapshot; no HCP Cluster found for Consul cluster (%s)", clusterID)
+	}
+
+	snapshotResp, err := clients.GetSnapshotByID(ctx, client, loc, snapshotID)
+	if err != nil {
+		if !clients.IsResponseCodeNotFound(err) {
+			return diag.Errorf("unable to fetch Consul snapshot (%s): %v", snapshotID, err)
+		}
+
+		return diag.Errorf("unable to restore snapshot; no Consul snapshot found with ID (%s)", snapshotID)
+	}
+
+	log.Printf("[INFO] Restoring Consul snapshot (%s) on Consul cluster (%s)", snapshotID, clusterID)
+
+	restoreResp, err := clients.RestoreSnapshot(ctx, client, cluster.Location, clusterID,
+		newLink(snapshotResp.Snapshot.Location, ConsulSnapshotResourceType, snapshotID), d.Get("take_snapshot").(bool))
+	if err != nil {
+		return diag.Errorf("unable to restore Consul snapshot (%s) on Consul cluster (%s): %v", snapshotID, clusterID, err)
+	}
+
+	link := newLink(loc, ConsulSnapshotRestoreResourceType, snapshotRestoreLinkID(clusterID, snapshotID))
+	url, err := linkURL(link)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(url)
+
+	// wait for the Consul snapshot to be restored
+	if err := clients.WaitForOperation(ctx, client, ConsulSnapshotRestoreResourceType, cluster.Location, restoreResp.Operation.ID); err != nil {
+		return diag.Errorf("unable to restore Consul snapshot (%s) on Consul cluster (%s): %v", snapshotID, clusterID, err)
+	}
+
+	log.Printf("[INFO] Restored Consul snapshot (%s) on Consul cluster (%s)", snapshotID, clusterID)
+
+	return resourceConsulSnapshotRestoreRead(ctx, d, meta)
+}
+
+func resourceConsulSnapshotRestoreRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client)
+
+	link, err := buildLinkFromURL(d.Id(), ConsulSnapshotRestoreResourceType, client.Config.OrganizationID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	clusterID, snapshotID, err := parseSnapshotRestoreLinkID(link.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	loc := link.Location
+
+	if _, err := clients.GetConsulClusterByID(ctx, client, loc, clusterID); err != nil {
+		if clients.IsResponseCodeNotFound(err) {
+			log.Printf("[WARN] Consul cluster (%s) not found, removing snapshot restore from state", clusterID)
+			d.SetId("")
+			return nil
+		}
+
+		return diag.Errorf("unable to fetch Consul cluster (%s): %v", clusterID, err)
+	}
+
+	attrs := map[string]interface{}{
+		"cluster_id":      clusterID,
+		"snapshot_id":     snapshotID,
+		"project_id":      loc.ProjectID,
+		"organization_id": loc.OrganizationID,
+	}
+	for k, v := range attrs {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	// The restore stays recorded once the snapshot expires, so that it is not
+	// attempted again.
+	restoredAt := ""
+	snapshotResp, err := clients.GetSnapshotByID(ctx, client, loc, snapshotID)
+	if err != nil {
+		if !clients.IsResponseCodeNotFound(err) {
+			return diag.Errorf("unable to fetch Consul snapshot (%s): %v", snapshotID, err)
+		}
+
+		log.Printf("[WARN] Consul snapshot (%s) not found, keeping its restore in state", snapshotID)
+	} else if snapshotMeta := snapshotResp.Snapshot.Meta; snapshotMeta != nil && snapshotMeta.RestoredAt.String() != defaultRestoredAt {
+		restoredAt = snapshotMeta.RestoredAt.String()
+	}
+
+	if err := d.Set("restored_at", restoredAt); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceConsulSnapshotRestoreDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// A restore cannot be undone, so the cluster is left as is.
+	log.Printf("[INFO] Removing restore of Consul snapshot (%s) from state, the Consul cluster (%s) is unchanged",
+		d.Get("snapshot_id").(string), d.Get("cluster_id").(string))
+
+	return nil
+}
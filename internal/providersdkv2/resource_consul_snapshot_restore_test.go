@@ -0,0 +1,132 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providersdkv2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestConsulSnapshotRestore restores a Consul snapshot against the in-process
+// fake HCP server and inspects the result with the snapshots data source.
+func TestConsulSnapshotRestore(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := context.Background()
+
+	createTestHVN(t, ctx, client)
+	createTestConsulCluster(t, ctx, client, nil)
+
+	snapshot := schema.TestResourceDataRaw(t, resourceConsulSnapshot().Schema, map[string]interface{}{
+		"cluster_id":    "test-consul",
+		"snapshot_name": "test-snapshot",
+	})
+	if diags := resourceConsulSnapshotCreate(ctx, snapshot, client); diags.HasError() {
+		t.Fatalf("unexpected error creating Consul snapshot: %v", diags)
+	}
+	snapshotID := snapshot.Get("snapshot_id").(string)
+
+	tcs := map[string]struct {
+		raw         map[string]interface{}
+		expectedErr bool
+	}{
+		"missing cluster": {
+			raw: map[string]interface{}{
+				"cluster_id":  "missing-consul",
+				"snapshot_id": snapshotID,
+			},
+			expectedErr: true,
+		},
+		"missing snapshot": {
+			raw: map[string]interface{}{
+				"cluster_id":  "test-consul",
+				"snapshot_id": "missing-snapshot",
+			},
+			expectedErr: true,
+		},
+		"restore": {
+			raw: map[string]interface{}{
+				"cluster_id":    "test-consul",
+				"snapshot_id":   snapshotID,
+				"take_snapshot": true,
+			},
+		},
+	}
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceConsulSnapshotRestore().Schema, tc.raw)
+			diags := resourceConsulSnapshotRestoreCreate(ctx, d, client)
+			if tc.expectedErr {
+				if !diags.HasError() {
+					t.Fatal("expected an error, got none")
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected error restoring Consul snapshot: %v", diags)
+			}
+			if d.Get("restored_at").(string) == "" {
+				t.Error("expected restored_at to be set")
+			}
+		})
+	}
+
+	ds := schema.TestResourceDataRaw(t, dataSourceConsulSnapshots().Schema, map[string]interface{}{
+		"cluster_id": "test-consul",
+	})
+	if diags := dataSourceConsulSnapshotsRead(ctx, ds, client); diags.HasError() {
+		t.Fatalf("unexpected error listing Consul snapshots: %v", diags)
+	}
+
+	// The restore took a snapshot of the cluster first.
+	snapshots := ds.Get("snapshots").([]interface{})
+	if len(snapshots) != 2 {
+		t.Fatalf("expected 2 snapshots, got %d", len(snapshots))
+	}
+	first := snapshots[0].(map[string]interface{})
+	if first["snapshot_id"] != snapshotID {
+		t.Errorf("expected the restored snapshot to be listed first, got %v", first["snapshot_id"])
+	}
+	if first["restored_at"] == "" {
+		t.Error("expected the restored snapshot to report restored_at")
+	}
+	if first["type"] != "MANUAL" || first["state"] != "READY" || first["size"] != 4096 {
+		t.Errorf("unexpected snapshot attributes: %v", first)
+	}
+
+	// The same snapshot restored on two clusters gives two restores.
+	createTestConsulCluster(t, ctx, client, map[string]interface{}{"cluster_id": "other-consul"})
+	restores := map[string]string{}
+	for _, clusterID := range []string{"test-consul", "other-consul"} {
+		restore := schema.TestResourceDataRaw(t, resourceConsulSnapshotRestore().Schema, map[string]interface{}{
+			"cluster_id":  clusterID,
+			"snapshot_id": snapshotID,
+		})
+		if diags := resourceConsulSnapshotRestoreCreate(ctx, restore, client); diags.HasError() {
+			t.Fatalf("unexpected error restoring Consul snapshot on %s: %v", clusterID, diags)
+		}
+		restores[restore.Id()] = clusterID
+	}
+	if len(restores) != 2 {
+		t.Errorf("expected the restores to have different IDs, got %v", restores)
+	}
+
+	r := resourceConsulSnapshotRestore()
+	imported, err := r.Importer.StateContext(ctx, r.Data(&sdkterraform.InstanceState{ID: "other-consul:" + snapshotID}), client)
+	if err != nil {
+		t.Fatalf("unexpected error importing Consul snapshot restore: %v", err)
+	}
+	restore := imported[0]
+	if diags := resourceConsulSnapshotRestoreRead(ctx, restore, client); diags.HasError() {
+		t.Fatalf("unexpected error reading Consul snapshot restore: %v", diags)
+	}
+	if got := restores[restore.Id()]; got != "other-consul" {
+		t.Errorf("expected the imported restore to match the other-consul one, got ID %q", restore.Id())
+	}
+	if got := restore.Get("cluster_id").(string); got != "other-consul" {
+		t.Errorf("expected cluster_id other-consul, got %q", got)
+	}
+}
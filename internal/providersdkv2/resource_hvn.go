@@ -35,6 +35,7 @@ func resourceHvn() *schema.Resource {
 
 		CreateContext: resourceHvnCreate,
 		ReadContext:   resourceHvnRead,
+		UpdateContext: resourceHvnUpdate,
 		DeleteContext: resourceHvnDelete,
 		Timeouts: &schema.ResourceTimeout{
 			Default: &hvnDefaultTimeout,
@@ -74,6 +75,7 @@ func resourceHvn() *schema.Resource {
 				},
 			},
 			// Optional inputs
+			"deletion_protection": deletionProtectionSchema("HVN"),
 			"cidr_block": {
 				Description:      "The CIDR range of the HVN. If this is not provided, the service will provide a default value.",
 				Type:             schema.TypeString,
@@ -179,6 +181,10 @@ func resourceHvnCreate(ctx context.Context, d *schema.ResourceData, meta interfa
 	}
 	d.SetId(url)
 
+	if err := setDeletionProtectionOnCreate(d); err != nil {
+		return diag.FromErr(err)
+	}
+
 	// Wait for HVN to be created
 	if err := clients.WaitForOperation(ctx, client, "create HVN", loc, createNetworkResponse.Payload.Operation.ID); err != nil {
 		return diag.Errorf("unable to create HVN (%s): %v", createNetworkResponse.Payload.Network.ID, err)
@@ -237,6 +243,12 @@ func resourceHvnRead(ctx context.Context, d *schema.ResourceData, meta interface
 	return nil
 }
 
+// resourceHvnUpdate only updates the state, since every other input requires a
+// new HVN.
+func resourceHvnUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceHvnRead(ctx, d, meta)
+}
+
 func resourceHvnDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*clients.Client)
 
@@ -248,6 +260,10 @@ func resourceHvnDelete(ctx context.Context, d *schema.ResourceData, meta interfa
 	hvnID := link.ID
 	loc := link.Location
 
+	if diags := checkDeletionProtection(d, "HVN", hvnID); diags != nil {
+		return diags
+	}
+
 	deleteParams := network_service.NewDeleteParams()
 	deleteParams.Context = ctx
 	deleteParams.ID = hvnID
@@ -361,5 +377,11 @@ func resourceHvnImport(ctx context.Context, d *schema.ResourceData, meta interfa
 
 	d.SetId(url)
 
+	// Imported resources are protected from deletion unless configured
+	// otherwise.
+	if err := d.Set("deletion_protection", true); err != nil {
+		return nil, err
+	}
+
 	return []*schema.ResourceData{d}, nil
 }
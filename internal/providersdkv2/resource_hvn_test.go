@@ -6,12 +6,16 @@ package providersdkv2
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients/hcptest"
 )
 
 var (
@@ -201,6 +205,104 @@ func TestAcc_Platform_AzureHvnOnly(t *testing.T) {
 	})
 }
 
+// TestAcc_Platform_HvnFakeServer runs the HVN lifecycle against the in-process
+// fake HCP server, so it needs no HCP credentials.
+func TestAcc_Platform_HvnFakeServer(t *testing.T) {
+	srv := hcptest.NewServer(t)
+	srv.Setenv(t)
+
+	resourceName := "hcp_hvn.test"
+	dataSourceName := "data.hcp_hvn.test"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testConfig(testAccAwsHvnConfig),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "hvn_id", hvnUniqueIDAws),
+					resource.TestCheckResourceAttr(resourceName, "organization_id", srv.OrganizationID),
+					resource.TestCheckResourceAttr(resourceName, "project_id", srv.ProjectID),
+					resource.TestCheckResourceAttr(resourceName, "state", "STABLE"),
+					resource.TestCheckResourceAttrSet(resourceName, "provider_account_id"),
+					resource.TestCheckResourceAttrPair(resourceName, "self_link", dataSourceName, "self_link"),
+				),
+			},
+		},
+	})
+}
+
+// TestHvnDelete_deletionProtection checks that a protected HVN is kept until
+// deletion_protection is disabled.
+func TestHvnDelete_deletionProtection(t *testing.T) {
+	client, srv := newTestClient(t)
+
+	d := schema.TestResourceDataRaw(t, resourceHvn().Schema, map[string]interface{}{
+		"hvn_id":              "test-hvn",
+		"cloud_provider":      "aws",
+		"region":              "us-west-2",
+		"deletion_protection": true,
+	})
+	if diags := resourceHvnCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error creating HVN: %v", diags)
+	}
+
+	diags := resourceHvnDelete(context.Background(), d, client)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "protected from deletion") {
+		t.Fatalf("expected a deletion protection error, got %v", diags)
+	}
+	if _, err := clients.GetHvnByID(context.Background(), client, srv.Location(), "test-hvn"); err != nil {
+		t.Fatalf("expected the protected HVN to be kept: %v", err)
+	}
+
+	if err := d.Set("deletion_protection", false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diags := resourceHvnDelete(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error deleting HVN: %v", diags)
+	}
+}
+
+// TestHvnImport_deletionProtection checks that an imported HVN stays protected
+// when the configuration does not set deletion_protection.
+func TestHvnImport_deletionProtection(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := context.Background()
+
+	raw := map[string]interface{}{
+		"hvn_id":         "test-hvn",
+		"cloud_provider": "aws",
+		"region":         "us-west-2",
+	}
+	created := schema.TestResourceDataRaw(t, resourceHvn().Schema, raw)
+	if diags := resourceHvnCreate(ctx, created, client); diags.HasError() {
+		t.Fatalf("unexpected error creating HVN: %v", diags)
+	}
+	if created.Get("deletion_protection").(bool) {
+		t.Error("expected a created HVN not to be protected")
+	}
+
+	r := resourceHvn()
+	imported, err := resourceHvnImport(ctx, r.Data(&sdkterraform.InstanceState{ID: "test-hvn"}), client)
+	if err != nil {
+		t.Fatalf("unexpected error importing HVN: %v", err)
+	}
+	d := imported[0]
+	if diags := resourceHvnRead(ctx, d, client); diags.HasError() {
+		t.Fatalf("unexpected error reading HVN: %v", diags)
+	}
+
+	diff, err := r.Diff(ctx, d.State(), sdkterraform.NewResourceConfigRaw(raw), client)
+	if err != nil {
+		t.Fatalf("unexpected error planning HVN: %v", err)
+	}
+	if diff != nil {
+		if attr, ok := diff.Attributes["deletion_protection"]; ok {
+			t.Errorf("expected the imported HVN to stay protected, got %q => %q", attr.Old, attr.New)
+		}
+	}
+}
+
 func testAccCheckHvnExists(name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[name]
@@ -5,11 +5,13 @@ package providersdkv2
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
 	vaultmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-vault-service/stable/2020-11-25/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -38,6 +40,7 @@ func resourceVaultCluster() *schema.Resource {
 		ReadContext:   resourceVaultClusterRead,
 		UpdateContext: resourceVaultClusterUpdate,
 		DeleteContext: resourceVaultClusterDelete,
+		CustomizeDiff: resourceVaultClusterCustomizeDiff,
 		Timeouts: &schema.ResourceTimeout{
 			Create:  &createUpdateVaultClusterTimeout,
 			Update:  &createUpdateVaultClusterTimeout,
@@ -64,6 +67,13 @@ func resourceVaultCluster() *schema.Resource {
 				ValidateDiagFunc: validateSlugID,
 			},
 			// Optional fields
+			"deletion_protection": deletionProtectionSchema("Vault cluster"),
+			"locked": {
+				Description: "Whether the Vault cluster is locked. A locked cluster rejects all requests until it is unlocked, which makes it useful to contain a security incident. The cluster is only locked or unlocked when this is set; if omitted, a lock applied outside of Terraform is left in place.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
 			"project_id": {
 				Description: `
 The ID of the HCP project where the Vault cluster is located.
@@ -138,14 +148,17 @@ If a project is not configured in the HCP Provider config block, the oldest proj
 				ForceNew:    true,
 			},
 			"paths_filter": {
-				Description: "The performance replication [paths filter](https://developer.hashicorp.com/vault/tutorials/cloud-ops/vault-replication-terraform). Applies to performance replication secondaries only and operates in \"deny\" mode only.",
-				Type:        schema.TypeList,
-				MinItems:    1,
+				Description: "The performance replication [paths filter](https://developer.hashicorp.com/vault/tutorials/cloud-ops/vault-replication-terraform). Applies to performance replication secondaries only and operates in \"deny\" mode only. " +
+					"Deprecated in favor of the `hcp_vault_cluster_replication` resource. Removing this attribute leaves the paths filter in place, so that it can be imported into that resource.",
+				Deprecated: "Use the hcp_vault_cluster_replication resource instead.",
+				Type:       schema.TypeList,
+				MinItems:   1,
 				Elem: &schema.Schema{
 					Type:             schema.TypeString,
 					ValidateDiagFunc: validateVaultPathsFilter,
 				},
 				Optional: true,
+				Computed: true,
 			},
 			"organization_id": {
 				Description: "The ID of the organization this HCP Vault cluster is located in.",
@@ -168,10 +181,12 @@ If a project is not configured in the HCP Provider config block, the oldest proj
 				Computed:    true,
 			},
 			"metrics_config": {
-				Description: "The metrics configuration for export. (https://developer.hashicorp.com/vault/tutorials/cloud-monitoring/vault-metrics-guide#metrics-streaming-configuration)",
-				Type:        schema.TypeList,
-				MaxItems:    1,
-				Optional:    true,
+				Description: "The metrics configuration for export. (https://developer.hashicorp.com/vault/tutorials/cloud-monitoring/vault-metrics-guide#metrics-streaming-configuration) " +
+					"Deprecated in favor of the `hcp_vault_cluster_metrics_config` resource. Removing this block leaves the metrics streaming in place, so that it can be imported into that resource.",
+				Deprecated: "Use the hcp_vault_cluster_metrics_config resource instead.",
+				Type:       schema.TypeList,
+				MaxItems:   1,
+				Optional:   true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"grafana_endpoint": {
@@ -325,10 +340,12 @@ If a project is not configured in the HCP Provider config block, the oldest proj
 				},
 			},
 			"audit_log_config": {
-				Description: "The audit logs configuration for export. (https://developer.hashicorp.com/vault/tutorials/cloud-monitoring/vault-metrics-guide#metrics-streaming-configuration)",
-				Type:        schema.TypeList,
-				MaxItems:    1,
-				Optional:    true,
+				Description: "The audit logs configuration for export. (https://developer.hashicorp.com/vault/tutorials/cloud-monitoring/vault-metrics-guide#metrics-streaming-configuration) " +
+					"Deprecated in favor of the `hcp_vault_cluster_audit_log_config` resource. Removing this block leaves the audit logs streaming in place, so that it can be imported into that resource.",
+				Deprecated: "Use the hcp_vault_cluster_audit_log_config resource instead.",
+				Type:       schema.TypeList,
+				MaxItems:   1,
+				Optional:   true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"grafana_endpoint": {
@@ -564,6 +581,58 @@ If a project is not configured in the HCP Provider config block, the oldest proj
 	}
 }
 
+// resourceVaultClusterCustomizeDiff validates the planned tier and the
+// features depending on it, so that invalid changes fail at plan time rather
+// than after an API round-trip. Values that are unknown at plan time are left
+// to the checks done during apply.
+func resourceVaultClusterCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	tierKnown := d.NewValueKnown("tier")
+	oldTier, newTier := d.GetChange("tier")
+	tier := strings.ToLower(newTier.(string))
+
+	if d.Id() != "" && tierKnown && d.HasChange("tier") && oldTier.(string) != "" {
+		if err := validateVaultTierTransition(strings.ToLower(oldTier.(string)), tier); err != nil {
+			return cty.GetAttrPath("tier").NewError(err)
+		}
+	}
+
+	primaryLinkKnown := d.NewValueKnown("primary_link")
+	primaryLink := d.Get("primary_link").(string)
+
+	if primaryLinkKnown && primaryLink != "" && tierKnown && tier != "" && !inPlusTier(strings.ToUpper(tier)) {
+		return cty.GetAttrPath("tier").NewErrorf("performance replication secondaries must be in a Plus tier, got %q", tier)
+	}
+
+	if paths := d.Get("paths_filter").([]interface{}); len(paths) > 0 && primaryLinkKnown && primaryLink == "" {
+		return cty.GetAttrPath("paths_filter").NewErrorf("only performance replication secondaries may specify a paths_filter; set primary_link as well")
+	}
+
+	// A tier change can invalidate an unchanged configuration, e.g. when
+	// scaling down to the Starter tier. The tier is compared like its
+	// DiffSuppressFunc does, and a configuration only reported by HCP is
+	// left to it.
+	tierChanged := !strings.EqualFold(oldTier.(string), tier)
+	if config := d.GetRawConfig(); !config.IsNull() {
+		if mvu := config.GetAttr("major_version_upgrade_config"); mvu.IsKnown() && (mvu.IsNull() || mvu.LengthInt() == 0) {
+			tierChanged = false
+		}
+	}
+	if tierKnown && (tierChanged || d.HasChange("major_version_upgrade_config")) {
+		configs := d.Get("major_version_upgrade_config").([]interface{})
+		if len(configs) > 0 && configs[0] != nil {
+			mvuTier := vaultmodels.HashicorpCloudVault20201125TierDEV
+			if tier != "" {
+				mvuTier = vaultmodels.HashicorpCloudVault20201125Tier(strings.ToUpper(tier))
+			}
+			if _, diags := getValidMajorVersionUpgradeConfig(configs[0].(map[string]interface{}), mvuTier); diags.HasError() {
+				return cty.GetAttrPath("major_version_upgrade_config").IndexInt(0).NewErrorf("%s", diags[0].Summary)
+			}
+		}
+	}
+
+	return nil
+}
+
 func resourceVaultClusterCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	client := meta.(*clients.Client)
@@ -613,7 +682,8 @@ func resourceVaultClusterCreate(ctx context.Context, d *schema.ResourceData, met
 	}
 
 	// Check for an existing Vault cluster.
-	_, err = clients.GetVaultClusterByID(ctx, client, loc, clusterID)
+	var pendingOperationID string
+	existing, err := clients.GetVaultClusterByID(ctx, client, loc, clusterID)
 	if err != nil {
 		if !clients.IsResponseCodeNotFound(err) {
 			return diag.Errorf("unable to check for presence of an existing Vault cluster (%s): %v", clusterID, err)
@@ -622,7 +692,22 @@ func resourceVaultClusterCreate(ctx context.Context, d *schema.ResourceData, met
 		// A 404 indicates a Vault cluster was not found.
 		log.Printf("[INFO] Vault cluster (%s) not found, proceeding with create", clusterID)
 	} else {
-		return diag.Errorf("a Vault cluster with cluster_id=%q in project_id=%q already exists - to be managed via Terraform this resource needs to be imported into the State.  Please see the resource documentation for hcp_vault_cluster for more information.", clusterID, loc.ProjectID)
+		// A cluster still being created was most likely left behind by an
+		// interrupted apply, in which case we resume waiting for it.
+		if *existing.State == vaultmodels.HashicorpCloudVault20201125ClusterStatePENDING ||
+			*existing.State == vaultmodels.HashicorpCloudVault20201125ClusterStateCREATING {
+			op, err := clients.GetPendingOperation(ctx, client, loc, VaultClusterResourceType, clusterID)
+			if err != nil {
+				return diag.Errorf("unable to check for a pending create of Vault cluster (%s): %v", clusterID, err)
+			}
+			if op != nil {
+				pendingOperationID = op.ID
+			}
+		}
+
+		if pendingOperationID == "" {
+			return diag.Errorf("a Vault cluster with cluster_id=%q in project_id=%q already exists - to be managed via Terraform this resource needs to be imported into the State.  Please see the resource documentation for hcp_vault_cluster for more information.", clusterID, loc.ProjectID)
+		}
 	}
 
 	// If no min_vault_version is set, an empty version is passed and the backend will set a default version.
@@ -742,9 +827,15 @@ func resourceVaultClusterCreate(ctx context.Context, d *schema.ResourceData, met
 		vaultCluster.Config.AuditLogExportConfig = auditConfig
 	}
 
-	payload, err := clients.CreateVaultCluster(ctx, client, loc, vaultCluster)
-	if err != nil {
-		return diag.Errorf("unable to create Vault cluster (%s): %v", clusterID, err)
+	operationID := pendingOperationID
+	if operationID == "" {
+		payload, err := clients.CreateVaultCluster(ctx, client, loc, vaultCluster)
+		if err != nil {
+			return diag.Errorf("unable to create Vault cluster (%s): %v", clusterID, err)
+		}
+		operationID = payload.Operation.ID
+	} else {
+		log.Printf("[INFO] Resuming pending create of Vault cluster (%s)", clusterID)
 	}
 
 	link := newLink(loc, VaultClusterResourceType, clusterID)
@@ -754,18 +845,32 @@ func resourceVaultClusterCreate(ctx context.Context, d *schema.ResourceData, met
 	}
 	d.SetId(url)
 
+	if err := setDeletionProtectionOnCreate(d); err != nil {
+		return diag.FromErr(err)
+	}
+
 	// Wait for the Vault cluster to be created.
-	if err := clients.WaitForOperation(ctx, client, "create Vault cluster", loc, payload.Operation.ID); err != nil {
-		return diag.Errorf("unable to create Vault cluster (%s): %v", payload.ClusterID, err)
+	if err := clients.WaitForOperation(ctx, client, "create Vault cluster", loc, operationID); err != nil {
+		if errors.Is(ctx.Err(), context.Canceled) {
+			// The apply was interrupted while the operation carries on in
+			// HCP. Keep the cluster in the state without tainting it, so that
+			// the next refresh resumes waiting for it instead of replacing it.
+			return diag.Diagnostics{{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Vault cluster (%s) is still being created", clusterID),
+				Detail:   fmt.Sprintf("The apply was interrupted while waiting for operation %s. The next plan or apply waits for it to finish.", operationID),
+			}}
+		}
+		return diag.Errorf("unable to create Vault cluster (%s): %v", clusterID, err)
 	}
 
-	log.Printf("[INFO] Created Vault cluster (%s)", payload.ClusterID)
+	log.Printf("[INFO] Created Vault cluster (%s)", clusterID)
 
 	// Get the created Vault cluster.
-	cluster, err := clients.GetVaultClusterByID(ctx, client, loc, payload.ClusterID)
+	cluster, err := clients.GetVaultClusterByID(ctx, client, loc, clusterID)
 
 	if err != nil {
-		return diag.Errorf("unable to retrieve Vault cluster (%s): %v", payload.ClusterID, err)
+		return diag.Errorf("unable to retrieve Vault cluster (%s): %v", clusterID, err)
 	}
 	clusterRegionShared := &sharedmodels.HashicorpCloudLocationRegion{}
 	if cluster.Location.Region != nil {
@@ -781,15 +886,27 @@ func resourceVaultClusterCreate(ctx context.Context, d *schema.ResourceData, met
 	// If we pass the major version upgrade configuration we need to update it after the creation of the cluster,
 	// since the cluster is created by default to automatic upgrade
 	if mvuConfig != nil {
-		_, err := clients.UpdateVaultMajorVersionUpgradeConfig(ctx, client, clusterLocationShared, payload.ClusterID, mvuConfig)
+		_, err := clients.UpdateVaultMajorVersionUpgradeConfig(ctx, client, clusterLocationShared, clusterID, mvuConfig)
 		if err != nil {
-			return diag.Errorf("error updating Vault cluster major version upgrade config (%s): %v", payload.ClusterID, err)
+			return diag.Errorf("error updating Vault cluster major version upgrade config (%s): %v", clusterID, err)
 		}
 
 		// refresh the created Vault cluster.
-		cluster, err = clients.GetVaultClusterByID(ctx, client, loc, payload.ClusterID)
+		cluster, err = clients.GetVaultClusterByID(ctx, client, loc, clusterID)
 		if err != nil {
-			return diag.Errorf("unable to retrieve Vault cluster (%s): %v", payload.ClusterID, err)
+			return diag.Errorf("unable to retrieve Vault cluster (%s): %v", clusterID, err)
+		}
+	}
+
+	if d.Get("locked").(bool) {
+		if diagErr := updateVaultClusterLock(ctx, client, clusterLocationShared, clusterID, true); diagErr != nil {
+			return diagErr
+		}
+
+		// refresh the created Vault cluster.
+		cluster, err = clients.GetVaultClusterByID(ctx, client, loc, clusterID)
+		if err != nil {
+			return diag.Errorf("unable to retrieve Vault cluster (%s): %v", clusterID, err)
 		}
 	}
 
@@ -824,6 +941,27 @@ func resourceVaultClusterRead(ctx context.Context, d *schema.ResourceData, meta
 		return diag.Errorf("unable to fetch Vault cluster (%s): %v", clusterID, err)
 	}
 
+	// A cluster still being created was left behind by an interrupted apply,
+	// in which case we resume waiting for it.
+	if *cluster.State == vaultmodels.HashicorpCloudVault20201125ClusterStatePENDING ||
+		*cluster.State == vaultmodels.HashicorpCloudVault20201125ClusterStateCREATING {
+		op, err := clients.GetPendingOperation(ctx, client, loc, VaultClusterResourceType, clusterID)
+		if err != nil {
+			return diag.Errorf("unable to check for a pending create of Vault cluster (%s): %v", clusterID, err)
+		}
+		if op != nil {
+			log.Printf("[INFO] Resuming pending create of Vault cluster (%s)", clusterID)
+			if err := clients.WaitForOperation(ctx, client, "create Vault cluster", loc, op.ID); err != nil {
+				return diag.Errorf("unable to create Vault cluster (%s): %v", clusterID, err)
+			}
+
+			cluster, err = clients.GetVaultClusterByID(ctx, client, loc, clusterID)
+			if err != nil {
+				return diag.Errorf("unable to fetch Vault cluster (%s): %v", clusterID, err)
+			}
+		}
+	}
+
 	// The Vault cluster was already deleted, remove from state.
 	if *cluster.State == vaultmodels.HashicorpCloudVault20201125ClusterStateDELETING {
 		log.Printf("[WARN] Vault cluster (%s) failed to provision, removing from state", clusterID)
@@ -874,7 +1012,7 @@ func resourceVaultClusterUpdate(ctx context.Context, d *schema.ResourceData, met
 	}
 
 	// Confirm at least one modifiable field has changed
-	if !d.HasChanges("tier", "public_endpoint", "proxy_endpoint", "ip_allowlist", "paths_filter", "metrics_config", "audit_log_config", "major_version_upgrade_config") {
+	if !d.HasChanges("tier", "public_endpoint", "proxy_endpoint", "ip_allowlist", "paths_filter", "metrics_config", "audit_log_config", "major_version_upgrade_config", "locked") {
 		return nil
 	}
 
@@ -884,46 +1022,44 @@ func resourceVaultClusterUpdate(ctx context.Context, d *schema.ResourceData, met
 		return diagErr
 	}
 
-	if d.HasChange("tier") || d.HasChange("public_endpoint") || d.HasChange("proxy_endpoint") || d.HasChange("ip_allowlist") || d.HasChange("metrics_config") || d.HasChange("audit_log_config") {
+	// A locked cluster rejects other updates, so unlock it first.
+	locked := d.Get("locked").(bool)
+	if d.HasChange("locked") && !locked {
+		if diagErr := updateVaultClusterLock(ctx, client, clusterLocationShared, clusterID, false); diagErr != nil {
+			return diagErr
+		}
+	}
+
+	if d.HasChange("tier") || d.HasChange("public_endpoint") || d.HasChange("proxy_endpoint") || d.HasChange("ip_allowlist") || hasObservabilityConfigChange("metrics_config", d) || hasObservabilityConfigChange("audit_log_config", d) {
 		diagErr := updateVaultClusterConfig(ctx, client, d, cluster, clusterID)
 		if diagErr != nil {
 			return diagErr
 		}
 	}
 
-	if d.HasChange("paths_filter") {
-		if paths, ok := d.GetOk("paths_filter"); ok {
-			// paths_filter is present. Check that it is a secondary, then update.
-			if _, ok := d.GetOk("primary_link"); !ok {
-				return diag.Errorf("only performance replication secondaries may specify a paths_filter")
-			}
-
-			// Invoke update paths filter endpoint.
-			pathStrings := getPathStrings(paths)
-			mode := vaultmodels.HashicorpCloudVault20201125ClusterPerformanceReplicationPathsFilterModeDENY
-			updateResp, err := clients.UpdateVaultPathsFilter(ctx, client, clusterLocationShared, clusterID, vaultmodels.HashicorpCloudVault20201125ClusterPerformanceReplicationPathsFilter{
-				Mode:  &mode,
-				Paths: pathStrings,
-			})
-			if err != nil {
-				return diag.Errorf("error updating Vault cluster paths filter (%s): %v", clusterID, err)
-			}
+	// paths_filter is computed, so it only changes when it is set in the
+	// configuration. Removing it leaves the paths filter in place, as it may be
+	// managed by an hcp_vault_cluster_replication resource.
+	if paths, ok := d.GetOk("paths_filter"); ok && d.HasChange("paths_filter") {
+		// Check that it is a secondary, then update.
+		if _, ok := d.GetOk("primary_link"); !ok {
+			return diag.Errorf("only performance replication secondaries may specify a paths_filter")
+		}
 
-			// Wait for the update paths filter operation.
-			if err := clients.WaitForOperation(ctx, client, "update Vault cluster paths filter", clusterLocationShared, updateResp.Operation.ID); err != nil {
-				return diag.Errorf("unable to update Vault cluster paths filter (%s): %v", clusterID, err)
-			}
-		} else {
-			// paths_filter is not present. Delete the paths_filter.
-			deleteResp, err := clients.DeleteVaultPathsFilter(ctx, client, clusterLocationShared, clusterID)
-			if err != nil {
-				return diag.Errorf("error deleting Vault cluster paths filter (%s): %v", clusterID, err)
-			}
+		// Invoke update paths filter endpoint.
+		pathStrings := getPathStrings(paths)
+		mode := vaultmodels.HashicorpCloudVault20201125ClusterPerformanceReplicationPathsFilterModeDENY
+		updateResp, err := clients.UpdateVaultPathsFilter(ctx, client, clusterLocationShared, clusterID, vaultmodels.HashicorpCloudVault20201125ClusterPerformanceReplicationPathsFilter{
+			Mode:  &mode,
+			Paths: pathStrings,
+		})
+		if err != nil {
+			return diag.Errorf("error updating Vault cluster paths filter (%s): %v", clusterID, err)
+		}
 
-			// Wait for the delete paths filter operation.
-			if err := clients.WaitForOperation(ctx, client, "delete Vault cluster paths filter", clusterLocationShared, deleteResp.Operation.ID); err != nil {
-				return diag.Errorf("unable to delete Vault cluster paths filter (%s): %v", clusterID, err)
-			}
+		// Wait for the update paths filter operation.
+		if err := clients.WaitForOperation(ctx, client, "update Vault cluster paths filter", clusterLocationShared, updateResp.Operation.ID); err != nil {
+			return diag.Errorf("unable to update Vault cluster paths filter (%s): %v", clusterID, err)
 		}
 	}
 
@@ -934,6 +1070,13 @@ func resourceVaultClusterUpdate(ctx context.Context, d *schema.ResourceData, met
 		}
 	}
 
+	// Lock the cluster last, once the other updates went through.
+	if d.HasChange("locked") && locked {
+		if diagErr := updateVaultClusterLock(ctx, client, clusterLocationShared, clusterID, true); diagErr != nil {
+			return diagErr
+		}
+	}
+
 	// Get the updated Vault cluster.
 	cluster, err = clients.GetVaultClusterByID(ctx, client, loc, clusterID)
 
@@ -959,6 +1102,10 @@ func resourceVaultClusterDelete(ctx context.Context, d *schema.ResourceData, met
 	clusterID := link.ID
 	loc := link.Location
 
+	if diags := checkDeletionProtection(d, "Vault cluster", clusterID); diags != nil {
+		return diags
+	}
+
 	log.Printf("[INFO] Deleting Vault cluster (%s)", clusterID)
 
 	deleteResp, err := clients.DeleteVaultCluster(ctx, client, loc, clusterID)
@@ -1018,7 +1165,7 @@ func updateVaultClusterConfig(ctx context.Context, client *clients.Client, d *sc
 
 			// Because of (a), check that the scaling operation is necessary.
 			// If the cluster has the same tier but the metrics/audit_log changed, we want to update the cluster anyway to change the info.
-			if *cluster.Config.Tier == vaultmodels.HashicorpCloudVault20201125Tier(*destTier) && !d.HasChange("metrics_config") && !d.HasChange("audit_log_config") {
+			if *cluster.Config.Tier == vaultmodels.HashicorpCloudVault20201125Tier(*destTier) && !hasObservabilityConfigChange("metrics_config", d) && !hasObservabilityConfigChange("audit_log_config", d) {
 				return nil
 			} else {
 				printPlusScalingWarningMsg()
@@ -1026,7 +1173,7 @@ func updateVaultClusterConfig(ctx context.Context, client *clients.Client, d *sc
 				if primaryLink != "" {
 					// Because of (b), if the cluster is a secondary, issue the actual API request to the primary.
 					isSecondary = true
-					if d.HasChange("metrics_config") || d.HasChange("audit_log_config") {
+					if hasObservabilityConfigChange("metrics_config", d) || hasObservabilityConfigChange("audit_log_config", d) {
 						updateResp, err := clients.UpdateVaultClusterConfig(ctx, client, clusterSharedLoc, cluster.ID, destTier, publicIpsEnabled, httpProxyOption, metricsConfig, auditConfig, ipAllowlist)
 						if err != nil {
 							return diag.Errorf("error updating Vault cluster (%s): %v", clusterID, err)
@@ -1148,6 +1295,12 @@ func setVaultClusterResourceData(d *schema.ResourceData, cluster *vaultmodels.Ha
 		return err
 	}
 
+	locked := *cluster.State == vaultmodels.HashicorpCloudVault20201125ClusterStateLOCKING ||
+		*cluster.State == vaultmodels.HashicorpCloudVault20201125ClusterStateLOCKED
+	if err := d.Set("locked", locked); err != nil {
+		return err
+	}
+
 	publicEndpoint := cluster.Config.NetworkConfig.PublicIpsEnabled
 	if err := d.Set("public_endpoint", publicEndpoint); err != nil {
 		return err
@@ -1157,12 +1310,19 @@ func setVaultClusterResourceData(d *schema.ResourceData, cluster *vaultmodels.Ha
 		return err
 	}
 
-	if err := d.Set("metrics_config", flattenObservabilityConfig(cluster.Config.MetricsConfig, d, "metrics_config")); err != nil {
-		return err
+	// The streaming configuration is only read back when it is set on this
+	// resource, since it may be managed by the hcp_vault_cluster_metrics_config
+	// and hcp_vault_cluster_audit_log_config resources instead.
+	if _, ok := d.GetOk("metrics_config"); ok {
+		if err := d.Set("metrics_config", flattenObservabilityConfig(cluster.Config.MetricsConfig, d, "metrics_config")); err != nil {
+			return err
+		}
 	}
 
-	if err := d.Set("audit_log_config", flattenObservabilityConfig(cluster.Config.AuditLogExportConfig, d, "audit_log_config")); err != nil {
-		return err
+	if _, ok := d.GetOk("audit_log_config"); ok {
+		if err := d.Set("audit_log_config", flattenObservabilityConfig(cluster.Config.AuditLogExportConfig, d, "audit_log_config")); err != nil {
+			return err
+		}
 	}
 
 	if err := d.Set("major_version_upgrade_config", flattenMajorVersionUpgradeConfig(cluster.Config.MajorVersionUpgradeConfig, d)); err != nil {
@@ -1252,17 +1412,14 @@ func setVaultClusterResourceData(d *schema.ResourceData, cluster *vaultmodels.Ha
 				return err
 			}
 		}
+	}
 
-		if prInfo.PathsFilter != nil && prInfo.PathsFilter.Paths != nil {
-			if err := d.Set("paths_filter", prInfo.PathsFilter.Paths); err != nil {
-				return err
-			}
-		} else {
-			err = d.Set("paths_filter", nil)
-			if err != nil {
-				return err
-			}
-		}
+	var pathsFilter []string
+	if prInfo := cluster.PerformanceReplicationInfo; prInfo != nil && prInfo.PathsFilter != nil {
+		pathsFilter = prInfo.PathsFilter.Paths
+	}
+	if err := d.Set("paths_filter", pathsFilter); err != nil {
+		return err
 	}
 
 	return nil
@@ -1408,12 +1565,39 @@ func flattenObservabilityConfig(config *vaultmodels.HashicorpCloudVault20201125O
 	return []interface{}{configMap}
 }
 
-func getObservabilityConfig(propertyName string, d *schema.ResourceData) (*vaultmodels.HashicorpCloudVault20201125ObservabilityConfig, diag.Diagnostics) {
+// hasObservabilityConfigChange reports whether the streaming configuration
+// block changed and is set. A block that is not set is left alone, as the
+// streaming may be managed by a standalone resource.
+func hasObservabilityConfigChange(propertyName string, d *schema.ResourceData) bool {
 	if !d.HasChange(propertyName) {
+		return false
+	}
+	_, ok := d.GetOk(propertyName)
+	return ok
+}
+
+func getObservabilityConfig(propertyName string, d *schema.ResourceData) (*vaultmodels.HashicorpCloudVault20201125ObservabilityConfig, diag.Diagnostics) {
+	if !hasObservabilityConfigChange(propertyName, d) {
 		return nil, nil
 	}
 
-	emptyConfig := vaultmodels.HashicorpCloudVault20201125ObservabilityConfig{
+	// An empty block deletes the configuration.
+	config, _ := d.Get(propertyName).([]interface{})[0].(map[string]interface{})
+	observabilityConfig, diags := getValidObservabilityConfig(config)
+	if diags.HasError() {
+		return nil, diags
+	}
+	if observabilityConfig == nil {
+		return emptyObservabilityConfig(), nil
+	}
+
+	return observabilityConfig, nil
+}
+
+// emptyObservabilityConfig returns the configuration that disables streaming
+// to every destination.
+func emptyObservabilityConfig() *vaultmodels.HashicorpCloudVault20201125ObservabilityConfig {
+	return &vaultmodels.HashicorpCloudVault20201125ObservabilityConfig{
 		Grafana:       &vaultmodels.HashicorpCloudVault20201125Grafana{},
 		Splunk:        &vaultmodels.HashicorpCloudVault20201125Splunk{},
 		Datadog:       &vaultmodels.HashicorpCloudVault20201125Datadog{},
@@ -1422,22 +1606,6 @@ func getObservabilityConfig(propertyName string, d *schema.ResourceData) (*vault
 		Newrelic:      &vaultmodels.HashicorpCloudVault20201125NewRelic{},
 		HTTP:          &vaultmodels.HashicorpCloudVault20201125HTTP{},
 	}
-
-	// If we don't find the property we return the empty object to be updated and delete the configuration.
-	configParam, ok := d.GetOk(propertyName)
-	if !ok {
-		return &emptyConfig, nil
-	}
-	configIfaceArr, ok := configParam.([]interface{})
-	if !ok || len(configIfaceArr) == 0 {
-		return &emptyConfig, nil
-	}
-	config, ok := configIfaceArr[0].(map[string]interface{})
-	if !ok {
-		return &emptyConfig, nil
-	}
-
-	return getValidObservabilityConfig(config)
 }
 
 // if http observability information is provided, this function ensures that authentication fields are valid and returns the authentication method used
@@ -1760,9 +1928,85 @@ func resourceVaultClusterImport(ctx context.Context, d *schema.ResourceData, met
 
 	d.SetId(url)
 
+	// Imported resources are protected from deletion unless configured
+	// otherwise.
+	if err := d.Set("deletion_protection", true); err != nil {
+		return nil, err
+	}
+
 	return []*schema.ResourceData{d}, nil
 }
 
+// updateVaultClusterLock locks or unlocks the Vault cluster and waits for the
+// operation to complete.
+func updateVaultClusterLock(ctx context.Context, client *clients.Client, loc *sharedmodels.HashicorpCloudLocationLocation, clusterID string, locked bool) diag.Diagnostics {
+	if locked {
+		log.Printf("[INFO] Locking Vault cluster (%s)", clusterID)
+
+		lockResp, err := clients.LockVaultCluster(ctx, client, loc, clusterID)
+		if err != nil {
+			return diag.Errorf("error locking Vault cluster (%s): %v", clusterID, err)
+		}
+
+		if err := clients.WaitForOperation(ctx, client, "lock Vault cluster", loc, lockResp.Operation.ID); err != nil {
+			return diag.Errorf("unable to lock Vault cluster (%s): %v", clusterID, err)
+		}
+
+		return nil
+	}
+
+	log.Printf("[INFO] Unlocking Vault cluster (%s)", clusterID)
+
+	unlockResp, err := clients.UnlockVaultCluster(ctx, client, loc, clusterID)
+	if err != nil {
+		return diag.Errorf("error unlocking Vault cluster (%s): %v", clusterID, err)
+	}
+
+	if err := clients.WaitForOperation(ctx, client, "unlock Vault cluster", loc, unlockResp.Operation.ID); err != nil {
+		return diag.Errorf("unable to unlock Vault cluster (%s): %v", clusterID, err)
+	}
+
+	return nil
+}
+
+// vaultTierFamilies ranks the families of HCP Vault tiers from the least to
+// the most capable.
+var vaultTierFamilies = map[string]int{
+	"dev":      0,
+	"starter":  1,
+	"standard": 2,
+	"plus":     3,
+}
+
+// vaultTierSizes ranks the sizes of the HCP Vault tiers.
+var vaultTierSizes = map[string]int{
+	"small":  0,
+	"medium": 1,
+	"large":  2,
+}
+
+// validateVaultTierTransition checks offline that a cluster can be scaled from
+// one tier to another, with both tiers given in lower case.
+func validateVaultTierTransition(from, to string) error {
+	if from == to {
+		return nil
+	}
+
+	fromFamily, fromSize, _ := strings.Cut(from, "_")
+	toFamily, toSize, _ := strings.Cut(to, "_")
+
+	switch {
+	case toFamily == "dev":
+		return fmt.Errorf("cannot scale down from %q to the Development tier", from)
+	case fromFamily == "dev" && toFamily == "plus":
+		return fmt.Errorf("cannot scale from the Development tier to %q; scale to a Standard tier first", to)
+	case vaultTierFamilies[toFamily] < vaultTierFamilies[fromFamily] && vaultTierSizes[toSize] < vaultTierSizes[fromSize]:
+		return fmt.Errorf("cannot scale down from %q to %q; scale the tier family and the size down in separate steps", from, to)
+	}
+
+	return nil
+}
+
 func inPlusTier(tier string) bool {
 	return tier == string(vaultmodels.HashicorpCloudVault20201125TierPLUSSMALL) ||
 		tier == string(vaultmodels.HashicorpCloudVault20201125TierPLUSMEDIUM) ||
@@ -23,15 +23,22 @@ var defaultVaultAdminTokenTimeout = time.Minute * 5
 // adminTokenExpiry is the length of the time in seconds before a generated admin token expires.
 var adminTokenExpiry = time.Second * 3600 * 6
 
+// defaultAdminTokenRenewBefore is how long before its expiry an admin token is
+// replaced by default.
+var defaultAdminTokenRenewBefore = time.Minute * 5
+
 func resourceVaultClusterAdminToken() *schema.Resource {
 	return &schema.Resource{
 		Description:   "The Vault cluster admin token resource generates an admin-level token for the HCP Vault cluster.",
 		CreateContext: resourceVaultClusterAdminTokenCreate,
 		ReadContext:   resourceVaultClusterAdminTokenRead,
+		UpdateContext: resourceVaultClusterAdminTokenUpdate,
 		DeleteContext: resourceVaultClusterAdminTokenDelete,
+		CustomizeDiff: resourceVaultClusterAdminTokenCustomizeDiff,
 		Timeouts: &schema.ResourceTimeout{
 			Create: &defaultVaultAdminTokenTimeout,
 			Read:   &defaultVaultAdminTokenTimeout,
+			Update: &defaultVaultAdminTokenTimeout,
 			Delete: &defaultVaultAdminTokenTimeout,
 		},
 		Schema: map[string]*schema.Schema{
@@ -55,12 +62,39 @@ If a project is not configured in the HCP Provider config block, the oldest proj
 				ValidateFunc: validation.IsUUID,
 				Computed:     true,
 			},
+			"renew_before": {
+				Description: "How long before its expiry the admin token is replaced, in golang's time.Duration string format. " +
+					"Once inside this window, `terraform plan` proposes to replace the resource, so that configurations using the token never run with an expired one. " +
+					"Admin tokens expire six hours after their creation. Defaults to `5m0s`.",
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  defaultAdminTokenRenewBefore.String(),
+				ValidateFunc: func(i any, k string) (warnings []string, errors []error) {
+					renewBefore, err := time.ParseDuration(i.(string))
+					if err != nil {
+						errors = append(errors, fmt.Errorf("expected %s to be a valid duration, got %s", k, i))
+					} else if renewBefore < 0 || renewBefore >= adminTokenExpiry {
+						errors = append(errors, fmt.Errorf("expected %s to be between 0s and %s, got %s", k, adminTokenExpiry, i))
+					}
+					return warnings, errors
+				},
+				DiffSuppressFunc: func(_, old, new string, _ *schema.ResourceData) bool {
+					oldTime, _ := time.ParseDuration(old)
+					newTime, _ := time.ParseDuration(new)
+					return newTime == oldTime
+				},
+			},
 			// computed outputs
 			"created_at": {
 				Description: "The time that the admin token was created.",
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
+			"expires_at": {
+				Description: "The time that the admin token expires.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
 			"token": {
 				Description: "The admin token of this HCP Vault cluster.",
 				Type:        schema.TypeString,
@@ -120,7 +154,13 @@ func resourceVaultClusterAdminTokenCreate(ctx context.Context, d *schema.Resourc
 		return diag.FromErr(err)
 	}
 
-	err = d.Set("created_at", time.Now().Format(time.RFC3339))
+	createdAt := time.Now()
+	err = d.Set("created_at", createdAt.Format(time.RFC3339))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = d.Set("expires_at", createdAt.Add(adminTokenExpiry).Format(time.RFC3339))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -134,8 +174,8 @@ func resourceVaultClusterAdminTokenCreate(ctx context.Context, d *schema.Resourc
 }
 
 // resourceVaultClusterAdminTokenRead cannot read the admin token from the API as it is not persisted in
-// any way that it can be fetched. Instead this operation first verifies the existence of the associated Vault cluster
-// and then refreshes the token if it is close to expiring or expired.
+// any way that it can be fetched. Instead this operation verifies the existence of the associated Vault cluster.
+// Tokens close to expiring are replaced at plan time by resourceVaultClusterAdminTokenCustomizeDiff.
 func resourceVaultClusterAdminTokenRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*clients.Client)
 
@@ -152,7 +192,7 @@ func resourceVaultClusterAdminTokenRead(ctx context.Context, d *schema.ResourceD
 
 	log.Printf("[INFO] reading Vault cluster (%s) [project_id=%s, organization_id=%s]", clusterID, loc.ProjectID, loc.OrganizationID)
 
-	cluster, err := clients.GetVaultClusterByID(ctx, client, loc, clusterID)
+	_, err = clients.GetVaultClusterByID(ctx, client, loc, clusterID)
 	if err != nil {
 		if clients.IsResponseCodeNotFound(err) {
 			// No cluster exists, so this admin token should be removed from state.
@@ -171,24 +211,9 @@ func resourceVaultClusterAdminTokenRead(ctx context.Context, d *schema.ResourceD
 		)
 	}
 
-	loc.Region = &models.HashicorpCloudLocationRegion{
-		Provider: cluster.Location.Region.Provider,
-		Region:   cluster.Location.Region.Region,
-	}
-
-	// If the token already exists, this block verifies if it is close to expiration and should be refreshed.
-	createdAt := d.Get("created_at").(string)
-	if createdAt != "" {
-		log.Printf("[INFO] existing admin token found for Vault cluster (%s) [project_id=%s, organization_id=%s]",
-			clusterID,
-			loc.ProjectID,
-			loc.OrganizationID,
-		)
-
-		// The refresh window starts five minutes before the 6h expiry.
-		expiry := adminTokenExpiry - (time.Second * 60 * 5)
-
-		t, err := time.Parse(time.RFC3339, createdAt)
+	// Tokens created before expires_at was tracked only record their creation time.
+	if d.Get("expires_at").(string) == "" {
+		expiresAt, err := adminTokenExpiresAt("", d.Get("created_at").(string))
 		if err != nil {
 			return diag.Errorf("error verifying HCP Vault cluster admin token (cluster_id %q) (project_id %q): %+v",
 				clusterID,
@@ -197,30 +222,8 @@ func resourceVaultClusterAdminTokenRead(ctx context.Context, d *schema.ResourceD
 			)
 		}
 
-		// If the token is less than five minutes from the 6h expiry, it's time to regenerate.
-		if time.Now().Unix() > t.Add(expiry).Unix() {
-			log.Printf("[INFO] refreshing admin token for Vault cluster (%s) [project_id=%s, organization_id=%s]",
-				clusterID,
-				loc.ProjectID,
-				loc.OrganizationID,
-			)
-
-			tokenResp, err := clients.CreateVaultClusterAdminToken(ctx, client, loc, clusterID)
-			if err != nil {
-				return diag.Errorf("error creating HCP Vault cluster admin token (cluster_id %q) (project_id %q): %+v",
-					clusterID,
-					loc.ProjectID,
-					err,
-				)
-			}
-
-			err = d.Set("token", tokenResp.Token)
-			if err != nil {
-				return diag.FromErr(err)
-			}
-
-			err = d.Set("created_at", time.Now().Format(time.RFC3339))
-			if err != nil {
+		if !expiresAt.IsZero() {
+			if err := d.Set("expires_at", expiresAt.Format(time.RFC3339)); err != nil {
 				return diag.FromErr(err)
 			}
 		}
@@ -229,6 +232,61 @@ func resourceVaultClusterAdminTokenRead(ctx context.Context, d *schema.ResourceD
 	return nil
 }
 
+// resourceVaultClusterAdminTokenUpdate only saves the new renew_before, which is applied during the next plan.
+func resourceVaultClusterAdminTokenUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceVaultClusterAdminTokenRead(ctx, d, meta)
+}
+
+// resourceVaultClusterAdminTokenCustomizeDiff replaces the admin token once it is within renew_before of its expiry.
+func resourceVaultClusterAdminTokenCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	expiresAt, err := adminTokenExpiresAt(d.Get("expires_at").(string), d.Get("created_at").(string))
+	if err != nil || expiresAt.IsZero() {
+		return err
+	}
+
+	renewBefore, err := time.ParseDuration(d.Get("renew_before").(string))
+	if err != nil {
+		return err
+	}
+
+	if time.Now().Before(expiresAt.Add(-renewBefore)) {
+		return nil
+	}
+
+	log.Printf("[INFO] admin token for Vault cluster (%s) expires at %s, planning its replacement", d.Get("cluster_id").(string), expiresAt.Format(time.RFC3339))
+
+	for _, k := range []string{"token", "created_at", "expires_at"} {
+		if err := d.SetNewComputed(k); err != nil {
+			return err
+		}
+	}
+
+	return d.ForceNew("token")
+}
+
+// adminTokenExpiresAt returns the expiry time of an admin token, falling back to its creation time for tokens created
+// before expires_at was tracked. It returns the zero time if neither is known.
+func adminTokenExpiresAt(expiresAt, createdAt string) (time.Time, error) {
+	if expiresAt != "" {
+		return time.Parse(time.RFC3339, expiresAt)
+	}
+
+	if createdAt == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, createdAt)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return t.Add(adminTokenExpiry), nil
+}
+
 // resourceVaultClusterAdminTokenDelete will remove the token from state but there is currently no way to invalidate an existing token.
 func resourceVaultClusterAdminTokenDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	d.SetId("")
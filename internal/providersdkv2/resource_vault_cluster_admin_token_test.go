@@ -0,0 +1,99 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providersdkv2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestVaultClusterAdminTokenRenewal checks the admin token is replaced at plan
+// time once it is within renew_before of its expiry.
+func TestVaultClusterAdminTokenRenewal(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := context.Background()
+
+	createTestHVN(t, ctx, client)
+	createTestVaultCluster(t, ctx, client, "dev", nil)
+
+	r := resourceVaultClusterAdminToken()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"cluster_id": "test-vault",
+	})
+	if diags := resourceVaultClusterAdminTokenCreate(ctx, d, client); diags.HasError() {
+		t.Fatalf("unexpected error creating admin token: %v", diags)
+	}
+
+	createdAt, err := time.Parse(time.RFC3339, d.Get("created_at").(string))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expiresAt, err := time.Parse(time.RFC3339, d.Get("expires_at").(string))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := expiresAt.Sub(createdAt); got != adminTokenExpiry {
+		t.Errorf("expected the token to expire %s after its creation, got %s", adminTokenExpiry, got)
+	}
+
+	expiring := d.State()
+	expiring.Attributes["expires_at"] = time.Now().Add(time.Hour).Format(time.RFC3339)
+
+	legacy := d.State()
+	delete(legacy.Attributes, "expires_at")
+	legacy.Attributes["created_at"] = time.Now().Add(-adminTokenExpiry).Format(time.RFC3339)
+
+	tcs := map[string]struct {
+		state           *sdkterraform.InstanceState
+		renewBefore     string
+		expectedReplace bool
+	}{
+		"fresh token": {
+			state:       d.State(),
+			renewBefore: "5m",
+		},
+		"expiring token": {
+			state:       expiring,
+			renewBefore: "30m",
+		},
+		"expiring token within renew_before": {
+			state:           expiring,
+			renewBefore:     "2h",
+			expectedReplace: true,
+		},
+		"expired token without expires_at": {
+			state:           legacy,
+			renewBefore:     "5m",
+			expectedReplace: true,
+		},
+	}
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			diff, err := r.Diff(ctx, tc.state, sdkterraform.NewResourceConfigRaw(map[string]interface{}{
+				"cluster_id":   "test-vault",
+				"renew_before": tc.renewBefore,
+			}), client)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := diff != nil && diff.RequiresNew(); got != tc.expectedReplace {
+				t.Errorf("expected replacement %t, got %t", tc.expectedReplace, got)
+			}
+		})
+	}
+
+	// A legacy token gets its expiry backfilled on read.
+	legacyData := r.Data(legacy)
+	if diags := resourceVaultClusterAdminTokenRead(ctx, legacyData, client); diags.HasError() {
+		t.Fatalf("unexpected error reading admin token: %v", diags)
+	}
+	if legacyData.Get("expires_at").(string) == "" {
+		t.Error("expected expires_at to be set from created_at")
+	}
+}
@@ -4,10 +4,14 @@
 package providersdkv2
 
 import (
+	"context"
+	"errors"
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/go-cty/cty"
 	vaultmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-vault-service/stable/2020-11-25/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestGetValidObservabilityConfig(t *testing.T) {
@@ -230,3 +234,159 @@ func TestGetValidMajorVersionUpgradeConfig(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateVaultTierTransition(t *testing.T) {
+	tcs := map[string]struct {
+		from, to      string
+		expectedError string
+	}{
+		"same tier":               {from: "standard_small", to: "standard_small"},
+		"scale up size":           {from: "standard_small", to: "standard_large"},
+		"scale down size":         {from: "plus_large", to: "plus_small"},
+		"dev to standard":         {from: "dev", to: "standard_small"},
+		"standard to plus":        {from: "standard_large", to: "plus_small"},
+		"plus to standard":        {from: "plus_medium", to: "standard_medium"},
+		"plus to larger standard": {from: "plus_small", to: "standard_large"},
+		"standard to dev": {
+			from:          "standard_small",
+			to:            "dev",
+			expectedError: "cannot scale down from \"standard_small\" to the Development tier",
+		},
+		"dev to plus": {
+			from:          "dev",
+			to:            "plus_small",
+			expectedError: "cannot scale from the Development tier to \"plus_small\"",
+		},
+		"cross-family scale-down": {
+			from:          "plus_medium",
+			to:            "standard_small",
+			expectedError: "cannot scale down from \"plus_medium\" to \"standard_small\"",
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			err := validateVaultTierTransition(tc.from, tc.to)
+			if tc.expectedError == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+				t.Fatalf("expected error containing %q, got %v", tc.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestResourceVaultClusterCustomizeDiff(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "/project/11eabb9f-d2ee-9c80-9483-0242ac110013/hashicorp.vault.cluster/test-vault",
+		Attributes: map[string]string{
+			"cluster_id": "test-vault",
+			"hvn_id":     "test-hvn",
+			"tier":       "PLUS_MEDIUM",
+		},
+	}
+
+	standardState := &terraform.InstanceState{
+		ID: "/project/11eabb9f-d2ee-9c80-9483-0242ac110013/hashicorp.vault.cluster/test-vault",
+		Attributes: map[string]string{
+			"cluster_id":                     "test-vault",
+			"hvn_id":                         "test-hvn",
+			"tier":                           "STANDARD_SMALL",
+			"major_version_upgrade_config.#": "1",
+			"major_version_upgrade_config.0.upgrade_type": "MANUAL",
+		},
+	}
+
+	tcs := map[string]struct {
+		state        *terraform.InstanceState
+		config       map[string]interface{}
+		expectedPath cty.Path
+	}{
+		"valid create": {
+			config: map[string]interface{}{"tier": "plus_small", "min_vault_version": "1.15.0"},
+		},
+		"valid scale down": {
+			state:  state,
+			config: map[string]interface{}{"tier": "plus_small"},
+		},
+		"invalid tier transition": {
+			state:        state,
+			config:       map[string]interface{}{"tier": "standard_small"},
+			expectedPath: cty.GetAttrPath("tier"),
+		},
+		"secondary on standard tier": {
+			config: map[string]interface{}{
+				"tier":         "standard_small",
+				"primary_link": "/project/11eabb9f-d2ee-9c80-9483-0242ac110013/hashicorp.vault.cluster/primary",
+			},
+			expectedPath: cty.GetAttrPath("tier"),
+		},
+		"paths filter without primary": {
+			config: map[string]interface{}{
+				"tier":         "plus_small",
+				"paths_filter": []interface{}{"path/a"},
+			},
+			expectedPath: cty.GetAttrPath("paths_filter"),
+		},
+		"major version upgrade config on dev": {
+			config: map[string]interface{}{
+				"tier": "dev",
+				"major_version_upgrade_config": []interface{}{
+					map[string]interface{}{"upgrade_type": "MANUAL"},
+				},
+			},
+			expectedPath: cty.GetAttrPath("major_version_upgrade_config").IndexInt(0),
+		},
+		"scheduled upgrade without window": {
+			config: map[string]interface{}{
+				"tier": "standard_small",
+				"major_version_upgrade_config": []interface{}{
+					map[string]interface{}{"upgrade_type": "SCHEDULED"},
+				},
+			},
+			expectedPath: cty.GetAttrPath("major_version_upgrade_config").IndexInt(0),
+		},
+		"scale down to starter with major version upgrade config": {
+			state: standardState,
+			config: map[string]interface{}{
+				"tier": "starter_small",
+				"major_version_upgrade_config": []interface{}{
+					map[string]interface{}{"upgrade_type": "MANUAL"},
+				},
+			},
+			expectedPath: cty.GetAttrPath("major_version_upgrade_config").IndexInt(0),
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"cluster_id": "test-vault",
+				"hvn_id":     "test-hvn",
+			}
+			for k, v := range tc.config {
+				raw[k] = v
+			}
+
+			_, err := resourceVaultCluster().Diff(context.Background(), tc.state, terraform.NewResourceConfigRaw(raw), nil)
+			if tc.expectedPath == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			var pathErr cty.PathError
+			if !errors.As(err, &pathErr) {
+				t.Fatalf("expected an error on %#v, got %v", tc.expectedPath, err)
+			}
+			if !pathErr.Path.Equals(tc.expectedPath) {
+				t.Fatalf("expected an error on %#v, got one on %#v: %v", tc.expectedPath, pathErr.Path, err)
+			}
+		})
+	}
+}
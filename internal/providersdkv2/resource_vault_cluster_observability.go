@@ -0,0 +1,893 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providersdkv2
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
+	vaultmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-vault-service/stable/2020-11-25/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+)
+
+// defaultVaultObservabilityTimeout is the amount of time that can elapse
+// before an observability configuration operation should timeout.
+var defaultVaultObservabilityTimeout = time.Minute * 20
+
+// observabilityDestinations are the blocks of the observability resources,
+// exactly one of which must be configured.
+var observabilityDestinations = []string{"grafana", "splunk", "datadog", "cloudwatch", "elasticsearch", "http", "newrelic"}
+
+// observabilitySecrets are the secrets of each destination. Each can be set
+// through a write-only variant, suffixed with _wo, along with a version
+// suffixed with _wo_version.
+var observabilitySecrets = map[string][]string{
+	"grafana":       {"password"},
+	"splunk":        {"token"},
+	"datadog":       {"api_key"},
+	"cloudwatch":    {"secret_access_key"},
+	"elasticsearch": {"password"},
+	"http":          {"basic_password", "bearer_token"},
+	"newrelic":      {"license_key"},
+}
+
+// vaultObservabilityKind describes what a Vault cluster observability
+// resource streams.
+type vaultObservabilityKind struct {
+	// resourceType is the link type used in the ID of the resource.
+	resourceType string
+
+	// name is used in descriptions and messages, e.g. "audit logs".
+	name string
+
+	// clusterAttribute is the deprecated hcp_vault_cluster block that also
+	// manages the configuration.
+	clusterAttribute string
+
+	// get returns the configuration of the cluster streamed by the
+	// resource.
+	get func(*vaultmodels.HashicorpCloudVault20201125ClusterConfig) *vaultmodels.HashicorpCloudVault20201125ObservabilityConfig
+
+	// update sends the configuration streamed by the resource.
+	update func(context.Context, *clients.Client, *sharedmodels.HashicorpCloudLocationLocation, string, *vaultmodels.HashicorpCloudVault20201125ObservabilityConfig) (*vaultmodels.HashicorpCloudVault20201125UpdateResponse, error)
+
+	// cloudwatch returns the computed CloudWatch attributes that only apply
+	// to the configuration streamed by the resource.
+	cloudwatch func(*vaultmodels.HashicorpCloudVault20201125CloudWatch) map[string]interface{}
+}
+
+var (
+	vaultMetricsKind = vaultObservabilityKind{
+		resourceType:     VaultClusterMetricsConfigResourceType,
+		name:             "metrics",
+		clusterAttribute: "metrics_config",
+		get: func(c *vaultmodels.HashicorpCloudVault20201125ClusterConfig) *vaultmodels.HashicorpCloudVault20201125ObservabilityConfig {
+			return c.MetricsConfig
+		},
+		update: func(ctx context.Context, client *clients.Client, loc *sharedmodels.HashicorpCloudLocationLocation, clusterID string, config *vaultmodels.HashicorpCloudVault20201125ObservabilityConfig) (*vaultmodels.HashicorpCloudVault20201125UpdateResponse, error) {
+			return clients.UpdateVaultClusterConfig(ctx, client, loc, clusterID, nil, nil, nil, config, nil, nil)
+		},
+		cloudwatch: func(c *vaultmodels.HashicorpCloudVault20201125CloudWatch) map[string]interface{} {
+			return map[string]interface{}{"namespace": c.Namespace}
+		},
+	}
+
+	vaultAuditLogKind = vaultObservabilityKind{
+		resourceType:     VaultClusterAuditLogConfigResourceType,
+		name:             "audit logs",
+		clusterAttribute: "audit_log_config",
+		get: func(c *vaultmodels.HashicorpCloudVault20201125ClusterConfig) *vaultmodels.HashicorpCloudVault20201125ObservabilityConfig {
+			return c.AuditLogExportConfig
+		},
+		update: func(ctx context.Context, client *clients.Client, loc *sharedmodels.HashicorpCloudLocationLocation, clusterID string, config *vaultmodels.HashicorpCloudVault20201125ObservabilityConfig) (*vaultmodels.HashicorpCloudVault20201125UpdateResponse, error) {
+			return clients.UpdateVaultClusterConfig(ctx, client, loc, clusterID, nil, nil, nil, nil, config, nil)
+		},
+		cloudwatch: func(c *vaultmodels.HashicorpCloudVault20201125CloudWatch) map[string]interface{} {
+			return map[string]interface{}{"stream_name": c.StreamName, "group_name": c.GroupName}
+		},
+	}
+)
+
+func resourceVaultClusterMetricsConfig() *schema.Resource {
+	return resourceVaultClusterObservability(vaultMetricsKind,
+		"The Vault cluster metrics config resource manages where an HCP Vault cluster streams its metrics. "+
+			"It replaces the deprecated `metrics_config` block of the `hcp_vault_cluster` resource, which must not be used together with it.",
+		map[string]*schema.Schema{
+			"namespace": {
+				Description: "The CloudWatch namespace the metrics are streamed to.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		})
+}
+
+func resourceVaultClusterAuditLogConfig() *schema.Resource {
+	return resourceVaultClusterObservability(vaultAuditLogKind,
+		"The Vault cluster audit log config resource manages where an HCP Vault cluster streams its audit logs. "+
+			"It replaces the deprecated `audit_log_config` block of the `hcp_vault_cluster` resource, which must not be used together with it.",
+		map[string]*schema.Schema{
+			"stream_name": {
+				Description: "The name of the CloudWatch log stream the audit logs are streamed to.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"group_name": {
+				Description: "The name of the CloudWatch log group the audit logs are streamed to.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		})
+}
+
+// resourceVaultClusterObservability returns the schema of a resource managing
+// one of the observability configurations of a Vault cluster. cloudwatch holds
+// the computed CloudWatch attributes that only apply to that configuration.
+func resourceVaultClusterObservability(kind vaultObservabilityKind, description string, cloudwatch map[string]*schema.Schema) *schema.Resource {
+	secretNote := "HCP never returns it, so changes made outside of Terraform are not detected."
+
+	destination := func(name, title string, attrs map[string]*schema.Schema) *schema.Schema {
+		// Secrets are given by attributes named after them, which the
+		// secret and its write-only variant are added for.
+		for _, key := range observabilitySecrets[name] {
+			path := name + ".0." + key
+			description := attrs[key].Description
+
+			attrs[key].Description = description + " " + secretNote
+			attrs[key].Sensitive = true
+			attrs[key+"_wo"] = &schema.Schema{
+				Description: description + fmt.Sprintf(" Unlike `%s`, it is never saved in the Terraform state. Requires Terraform 1.11 or later.", key),
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+			}
+			attrs[key+"_wo_version"] = &schema.Schema{
+				Description:  fmt.Sprintf("The version of `%[1]s_wo`. As Terraform does not keep `%[1]s_wo`, it is only sent to HCP on creation and when this version changes.", key),
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{path + "_wo"},
+			}
+
+			if attrs[key].Required {
+				attrs[key].Required = false
+				attrs[key].Optional = true
+				attrs[key].ExactlyOneOf = []string{path, path + "_wo"}
+				attrs[key+"_wo"].ExactlyOneOf = []string{path, path + "_wo"}
+			} else {
+				// An optional secret has the same constraints as its write-only
+				// variant, which it conflicts with.
+				attrs[key+"_wo"].ConflictsWith = append([]string{path}, attrs[key].ConflictsWith...)
+				attrs[key+"_wo"].RequiredWith = attrs[key].RequiredWith
+				attrs[key].ConflictsWith = append(attrs[key].ConflictsWith, path+"_wo")
+			}
+		}
+
+		return &schema.Schema{
+			Description:  fmt.Sprintf("Streams %s to %s. Exactly one destination must be configured.", kind.name, title),
+			Type:         schema.TypeList,
+			MaxItems:     1,
+			Optional:     true,
+			ExactlyOneOf: observabilityDestinations,
+			Elem:         &schema.Resource{Schema: attrs},
+		}
+	}
+
+	cloudwatchAttrs := map[string]*schema.Schema{
+		"access_key_id": {
+			Description: "The AWS access key ID.",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"secret_access_key": {
+			Description: "The AWS secret access key.",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"region": {
+			Description: "The AWS region.",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+	}
+	for k, v := range cloudwatch {
+		cloudwatchAttrs[k] = v
+	}
+
+	return &schema.Resource{
+		Description:   description,
+		CreateContext: vaultClusterObservabilityCreate(kind),
+		ReadContext:   vaultClusterObservabilityRead(kind),
+		UpdateContext: vaultClusterObservabilityUpdate(kind),
+		DeleteContext: vaultClusterObservabilityDelete(kind),
+		CustomizeDiff: vaultClusterObservabilityCustomizeDiff(kind),
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			validateVaultObservabilityBasicAuth,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: &defaultVaultObservabilityTimeout,
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: vaultClusterObservabilityImport(kind),
+		},
+		Schema: map[string]*schema.Schema{
+			// Required inputs
+			"cluster_id": {
+				Description:      "The ID of the HCP Vault cluster.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateSlugID,
+			},
+			// Optional inputs
+			"project_id": {
+				Description: `
+The ID of the HCP project where the HCP Vault cluster is located.
+If not specified, the project specified in the HCP Provider config block will be used, if configured.
+If a project is not configured in the HCP Provider config block, the oldest project in the organization will be used.`,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+				Computed:     true,
+			},
+			"grafana": destination("grafana", "Grafana", map[string]*schema.Schema{
+				"endpoint": {
+					Description: "The Grafana endpoint.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"user": {
+					Description: "The Grafana user.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"password": {
+					Description: "The Grafana password.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+			}),
+			"splunk": destination("splunk", "Splunk", map[string]*schema.Schema{
+				"hec_endpoint": {
+					Description: "The Splunk HTTP Event Collector endpoint.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"token": {
+					Description: "The Splunk token.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+			}),
+			"datadog": destination("datadog", "Datadog", map[string]*schema.Schema{
+				"api_key": {
+					Description: "The Datadog API key.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"region": {
+					Description: "The Datadog region.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+			}),
+			"cloudwatch": destination("cloudwatch", "CloudWatch", cloudwatchAttrs),
+			"elasticsearch": destination("elasticsearch", "Elasticsearch", map[string]*schema.Schema{
+				"endpoint": {
+					Description: "The Elasticsearch endpoint.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"user": {
+					Description: "The Elasticsearch user.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"password": {
+					Description: "The Elasticsearch password.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"dataset": {
+					Description: "The Elasticsearch dataset.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+			}),
+			"http": destination("http", "an HTTP endpoint", map[string]*schema.Schema{
+				"uri": {
+					Description: "The URI of the HTTP endpoint.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"method": {
+					Description:  "The HTTP method. Valid options are `POST`, `PUT` and `PATCH`.",
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice([]string{"POST", "PUT", "PATCH"}, false),
+				},
+				"codec": {
+					Description:  "The encoding of the payload. Valid options are `JSON` and `NDJSON`.",
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice([]string{"JSON", "NDJSON"}, false),
+				},
+				"compression": {
+					Description: "Whether the payload is compressed.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+				},
+				"headers": {
+					Description: "The HTTP headers sent with the payload.",
+					Type:        schema.TypeMap,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"payload_prefix": {
+					Description: "A prefix added to the payload.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"payload_suffix": {
+					Description: "A suffix added to the payload.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"basic_user": {
+					Description:   "The HTTP basic authentication user. Requires `basic_password` or `basic_password_wo`.",
+					Type:          schema.TypeString,
+					Optional:      true,
+					ConflictsWith: []string{"http.0.bearer_token", "http.0.bearer_token_wo"},
+				},
+				"basic_password": {
+					Description:   "The HTTP basic authentication password. Requires `basic_user`.",
+					Type:          schema.TypeString,
+					Optional:      true,
+					RequiredWith:  []string{"http.0.basic_user"},
+					ConflictsWith: []string{"http.0.bearer_token", "http.0.bearer_token_wo"},
+				},
+				"bearer_token": {
+					Description: "The HTTP bearer authentication token. Conflicts with `basic_user` and `basic_password`.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+			}),
+			"newrelic": destination("newrelic", "New Relic", map[string]*schema.Schema{
+				"account_id": {
+					Description: "The New Relic account ID.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"license_key": {
+					Description: "The New Relic license key.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"region": {
+					Description:  "The New Relic region. Valid options are `US` and `EU`.",
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice([]string{"US", "EU"}, false),
+				},
+			}),
+			// Computed outputs
+			"organization_id": {
+				Description: "The ID of the HCP organization where the HCP Vault cluster is located.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func vaultClusterObservabilityCreate(kind vaultObservabilityKind) schema.CreateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		client := meta.(*clients.Client)
+
+		clusterID := d.Get("cluster_id").(string)
+
+		projectID, err := GetProjectID(d.Get("project_id").(string), client.Config.ProjectID)
+		if err != nil {
+			return diag.Errorf("unable to retrieve project ID: %v", err)
+		}
+
+		loc := &sharedmodels.HashicorpCloudLocationLocation{
+			OrganizationID: client.Config.OrganizationID,
+			ProjectID:      projectID,
+		}
+
+		cluster, err := clients.GetVaultClusterByID(ctx, client, loc, clusterID)
+		if err != nil {
+			return diag.Errorf("unable to fetch Vault cluster (%s): %v", clusterID, err)
+		}
+
+		// The cluster may have been created by the same apply, with the
+		// deprecated block, after the resource was planned.
+		if err := checkVaultClusterObservabilityUnmanaged(kind, cluster); err != nil {
+			return diag.FromErr(err)
+		}
+
+		config, diags := expandVaultObservabilityConfig(d)
+		if diags != nil {
+			return diags
+		}
+		if diags := updateVaultClusterObservability(ctx, client, kind, cluster, config); diags != nil {
+			return diags
+		}
+
+		link := newLink(loc, kind.resourceType, clusterID)
+		url, err := linkURL(link)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		d.SetId(url)
+
+		return vaultClusterObservabilityRead(kind)(ctx, d, meta)
+	}
+}
+
+// validateVaultObservabilityBasicAuth checks that the HTTP basic
+// authentication user comes with a password, which can be given by either
+// basic_password or basic_password_wo.
+func validateVaultObservabilityBasicAuth(_ context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse) {
+	if !req.RawConfig.IsKnown() || req.RawConfig.IsNull() {
+		return
+	}
+
+	blocks := req.RawConfig.GetAttr("http")
+	if !blocks.IsKnown() || blocks.IsNull() || blocks.LengthInt() == 0 {
+		return
+	}
+
+	http := blocks.Index(cty.NumberIntVal(0))
+	if http.GetAttr("basic_user").IsNull() {
+		return
+	}
+	if !http.GetAttr("basic_password").IsNull() || !http.GetAttr("basic_password_wo").IsNull() {
+		return
+	}
+
+	resp.Diagnostics = append(resp.Diagnostics, diag.Diagnostic{
+		Severity:      diag.Error,
+		Summary:       "Missing HTTP basic authentication password",
+		Detail:        "basic_user requires basic_password or basic_password_wo to be set.",
+		AttributePath: cty.GetAttrPath("http").IndexInt(0).GetAttr("basic_user"),
+	})
+}
+
+// vaultClusterObservabilityCustomizeDiff refuses to plan the creation of the
+// resource for a cluster that already streams to a destination, as that
+// configuration is managed elsewhere, most likely by the deprecated block of
+// the hcp_vault_cluster resource. Both would overwrite each other on every
+// apply.
+func vaultClusterObservabilityCustomizeDiff(kind vaultObservabilityKind) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if d.Id() != "" || !d.NewValueKnown("cluster_id") {
+			return nil
+		}
+
+		// A project only known once applied is left to the checks done on create.
+		if config := d.GetRawConfig(); !config.IsNull() && !config.GetAttr("project_id").IsKnown() {
+			return nil
+		}
+
+		client := meta.(*clients.Client)
+
+		clusterID := d.Get("cluster_id").(string)
+
+		projectID, err := GetProjectID(d.Get("project_id").(string), client.Config.ProjectID)
+		if err != nil {
+			return fmt.Errorf("unable to retrieve project ID: %v", err)
+		}
+
+		loc := &sharedmodels.HashicorpCloudLocationLocation{
+			OrganizationID: client.Config.OrganizationID,
+			ProjectID:      projectID,
+		}
+
+		// A cluster that does not exist yet is checked again on create.
+		cluster, err := clients.GetVaultClusterByID(ctx, client, loc, clusterID)
+		if err != nil {
+			if clients.IsResponseCodeNotFound(err) {
+				return nil
+			}
+
+			return fmt.Errorf("unable to fetch Vault cluster (%s): %v", clusterID, err)
+		}
+
+		return checkVaultClusterObservabilityUnmanaged(kind, cluster)
+	}
+}
+
+// checkVaultClusterObservabilityUnmanaged returns an error if the cluster
+// already streams the observability data of the resource.
+func checkVaultClusterObservabilityUnmanaged(kind vaultObservabilityKind, cluster *vaultmodels.HashicorpCloudVault20201125Cluster) error {
+	if cluster.Config == nil {
+		return nil
+	}
+	if destination, _ := flattenVaultObservabilityConfig(kind, kind.get(cluster.Config), nil); destination == "" {
+		return nil
+	}
+
+	return fmt.Errorf("Vault cluster (%s) already streams %s, most likely through the %s block of an hcp_vault_cluster resource; "+
+		"remove the block from that resource, then import this resource instead of creating it",
+		cluster.ID, kind.name, kind.clusterAttribute)
+}
+
+func vaultClusterObservabilityRead(kind vaultObservabilityKind) schema.ReadContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		client := meta.(*clients.Client)
+
+		link, err := buildLinkFromURL(d.Id(), kind.resourceType, client.Config.OrganizationID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		clusterID := link.ID
+		loc := link.Location
+
+		cluster, err := clients.GetVaultClusterByID(ctx, client, loc, clusterID)
+		if err != nil {
+			if clients.IsResponseCodeNotFound(err) {
+				log.Printf("[WARN] Vault cluster (%s) not found, removing %s config from state", clusterID, kind.name)
+				d.SetId("")
+				return nil
+			}
+
+			return diag.Errorf("unable to fetch Vault cluster (%s): %v", clusterID, err)
+		}
+
+		var config *vaultmodels.HashicorpCloudVault20201125ObservabilityConfig
+		if cluster.Config != nil {
+			config = kind.get(cluster.Config)
+		}
+		destination, values := flattenVaultObservabilityConfig(kind, config, d)
+		if destination == "" {
+			log.Printf("[WARN] Vault cluster (%s) does not stream %s, removing from state", clusterID, kind.name)
+			d.SetId("")
+			return nil
+		}
+
+		if err := d.Set("cluster_id", clusterID); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("project_id", loc.ProjectID); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("organization_id", cluster.Location.OrganizationID); err != nil {
+			return diag.FromErr(err)
+		}
+		// The versions of the write-only secrets are only known from the
+		// configuration.
+		for _, key := range observabilitySecrets[destination] {
+			values[key+"_wo_version"] = d.Get(destination + ".0." + key + "_wo_version")
+		}
+		for _, name := range observabilityDestinations {
+			var v []interface{}
+			if name == destination {
+				v = []interface{}{values}
+			}
+			if err := d.Set(name, v); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+
+		return nil
+	}
+}
+
+func vaultClusterObservabilityUpdate(kind vaultObservabilityKind) schema.UpdateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		client := meta.(*clients.Client)
+
+		link, err := buildLinkFromURL(d.Id(), kind.resourceType, client.Config.OrganizationID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		cluster, err := clients.GetVaultClusterByID(ctx, client, link.Location, link.ID)
+		if err != nil {
+			return diag.Errorf("unable to fetch Vault cluster (%s): %v", link.ID, err)
+		}
+
+		config, diags := expandVaultObservabilityConfig(d)
+		if diags != nil {
+			return diags
+		}
+		if diags := updateVaultClusterObservability(ctx, client, kind, cluster, config); diags != nil {
+			return diags
+		}
+
+		return vaultClusterObservabilityRead(kind)(ctx, d, meta)
+	}
+}
+
+func vaultClusterObservabilityDelete(kind vaultObservabilityKind) schema.DeleteContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		client := meta.(*clients.Client)
+
+		link, err := buildLinkFromURL(d.Id(), kind.resourceType, client.Config.OrganizationID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		cluster, err := clients.GetVaultClusterByID(ctx, client, link.Location, link.ID)
+		if err != nil {
+			if clients.IsResponseCodeNotFound(err) {
+				log.Printf("[WARN] Vault cluster (%s) not found, so no action was taken", link.ID)
+				return nil
+			}
+
+			return diag.Errorf("unable to fetch Vault cluster (%s): %v", link.ID, err)
+		}
+
+		return updateVaultClusterObservability(ctx, client, kind, cluster, emptyObservabilityConfig())
+	}
+}
+
+func vaultClusterObservabilityImport(kind vaultObservabilityKind) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		// import with an explicit project ID:
+		//   terraform import hcp_vault_cluster_metrics_config.test f709ec73-55d4-46d8-897d-816ebba28778:test-vault-cluster
+		// or with the default project ID from the provider:
+		//   terraform import hcp_vault_cluster_metrics_config.test test-vault-cluster
+		client := meta.(*clients.Client)
+
+		projectID, clusterID, found := strings.Cut(d.Id(), ":")
+		if !found {
+			clusterID = d.Id()
+
+			var err error
+			projectID, err = GetProjectID("", client.Config.ProjectID)
+			if err != nil {
+				return nil, fmt.Errorf("unable to retrieve project ID: %v", err)
+			}
+		}
+
+		loc := &sharedmodels.HashicorpCloudLocationLocation{
+			ProjectID: projectID,
+		}
+
+		link := newLink(loc, kind.resourceType, clusterID)
+		url, err := linkURL(link)
+		if err != nil {
+			return nil, err
+		}
+
+		d.SetId(url)
+
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+// updateVaultClusterObservability sends the given observability configuration
+// of the cluster and waits for the update to complete.
+func updateVaultClusterObservability(ctx context.Context, client *clients.Client, kind vaultObservabilityKind,
+	cluster *vaultmodels.HashicorpCloudVault20201125Cluster, config *vaultmodels.HashicorpCloudVault20201125ObservabilityConfig) diag.Diagnostics {
+
+	clusterID := cluster.ID
+
+	// The update is addressed to the region of the cluster.
+	clusterSharedLoc := &sharedmodels.HashicorpCloudLocationLocation{
+		OrganizationID: cluster.Location.OrganizationID,
+		ProjectID:      cluster.Location.ProjectID,
+		Region: &sharedmodels.HashicorpCloudLocationRegion{
+			Provider: cluster.Location.Region.Provider,
+			Region:   cluster.Location.Region.Region,
+		},
+	}
+
+	log.Printf("[INFO] Updating %s config of Vault cluster (%s)", kind.name, clusterID)
+
+	updateResp, err := kind.update(ctx, client, clusterSharedLoc, clusterID, config)
+	if err != nil {
+		return diag.Errorf("error updating %s config of Vault cluster (%s): %v", kind.name, clusterID, err)
+	}
+
+	if err := clients.WaitForOperation(ctx, client, "update Vault cluster "+kind.name+" config", clusterSharedLoc, updateResp.Operation.ID); err != nil {
+		return diag.Errorf("unable to update %s config of Vault cluster (%s): %v", kind.name, clusterID, err)
+	}
+
+	return nil
+}
+
+// expandVaultObservabilityConfig builds the observability configuration from
+// the destination block set in the resource data. Secrets set through their
+// write-only variant are only found in the configuration.
+func expandVaultObservabilityConfig(d *schema.ResourceData) (*vaultmodels.HashicorpCloudVault20201125ObservabilityConfig, diag.Diagnostics) {
+	writeOnly := func(name, key string) string {
+		if d.GetRawConfig().IsNull() {
+			return ""
+		}
+		v, diags := d.GetRawConfigAt(cty.GetAttrPath(name).IndexInt(0).GetAttr(key + "_wo"))
+		if diags.HasError() || !v.IsKnown() || v.IsNull() || !v.Type().Equals(cty.String) {
+			return ""
+		}
+		return v.AsString()
+	}
+
+	block := func(name string) map[string]interface{} {
+		v, ok := d.GetOk(name)
+		if !ok || len(v.([]interface{})) == 0 || v.([]interface{})[0] == nil {
+			return nil
+		}
+		b := v.([]interface{})[0].(map[string]interface{})
+		for _, key := range observabilitySecrets[name] {
+			if b[key].(string) == "" {
+				b[key] = writeOnly(name, key)
+			}
+		}
+		return b
+	}
+
+	config := &vaultmodels.HashicorpCloudVault20201125ObservabilityConfig{}
+
+	if b := block("grafana"); b != nil {
+		config.Grafana = &vaultmodels.HashicorpCloudVault20201125Grafana{
+			Endpoint: b["endpoint"].(string),
+			User:     b["user"].(string),
+			Password: b["password"].(string),
+		}
+	}
+
+	if b := block("splunk"); b != nil {
+		config.Splunk = &vaultmodels.HashicorpCloudVault20201125Splunk{
+			HecEndpoint: b["hec_endpoint"].(string),
+			Token:       b["token"].(string),
+		}
+	}
+
+	if b := block("datadog"); b != nil {
+		config.Datadog = &vaultmodels.HashicorpCloudVault20201125Datadog{
+			APIKey: b["api_key"].(string),
+			Region: b["region"].(string),
+		}
+	}
+
+	if b := block("cloudwatch"); b != nil {
+		config.Cloudwatch = &vaultmodels.HashicorpCloudVault20201125CloudWatch{
+			AccessKeyID:     b["access_key_id"].(string),
+			SecretAccessKey: b["secret_access_key"].(string),
+			Region:          b["region"].(string),
+			// other fields are only set by the external provider
+		}
+	}
+
+	if b := block("elasticsearch"); b != nil {
+		config.Elasticsearch = &vaultmodels.HashicorpCloudVault20201125Elasticsearch{
+			Endpoint: b["endpoint"].(string),
+			User:     b["user"].(string),
+			Password: b["password"].(string),
+		}
+	}
+
+	if b := block("http"); b != nil {
+		codec := vaultmodels.HashicorpCloudVault20201125HTTPEncodingCodec(b["codec"].(string))
+		http := &vaultmodels.HashicorpCloudVault20201125HTTP{
+			URI:           b["uri"].(string),
+			Method:        b["method"].(string),
+			Codec:         &codec,
+			Compression:   b["compression"].(bool),
+			PayloadPrefix: b["payload_prefix"].(string),
+			PayloadSuffix: b["payload_suffix"].(string),
+		}
+		if headers := b["headers"].(map[string]interface{}); len(headers) > 0 {
+			http.Headers = headers
+		}
+		if token := b["bearer_token"].(string); token != "" {
+			http.Bearer = &vaultmodels.HashicorpCloudVault20201125HTTPBearerAuth{Token: token}
+		}
+		if user := b["basic_user"].(string); user != "" {
+			if b["basic_password"].(string) == "" {
+				return nil, diag.Errorf("basic_user requires basic_password or basic_password_wo to be set")
+			}
+			http.Basic = &vaultmodels.HashicorpCloudVault20201125HTTPBasicAuth{
+				User:     user,
+				Password: b["basic_password"].(string),
+			}
+		}
+		config.HTTP = http
+	}
+
+	if b := block("newrelic"); b != nil {
+		region := vaultmodels.HashicorpCloudVault20201125NewRelicRegion(b["region"].(string))
+		config.Newrelic = &vaultmodels.HashicorpCloudVault20201125NewRelic{
+			AccountID:  b["account_id"].(string),
+			LicenseKey: b["license_key"].(string),
+			Region:     &region,
+		}
+	}
+
+	return config, nil
+}
+
+// flattenVaultObservabilityConfig returns the destination block configured
+// in the observability configuration, or an empty string if there is none,
+// along with its attributes. HCP returns secrets redacted, so they are kept
+// from the resource data instead.
+func flattenVaultObservabilityConfig(kind vaultObservabilityKind, config *vaultmodels.HashicorpCloudVault20201125ObservabilityConfig, d *schema.ResourceData) (string, map[string]interface{}) {
+	if config == nil {
+		return "", nil
+	}
+
+	secret := func(destination, key, value string) string {
+		if value != "redacted" || d == nil {
+			return value
+		}
+		v, _ := d.Get(destination + ".0." + key).(string)
+		return v
+	}
+
+	switch {
+	case config.Grafana != nil && config.Grafana.Endpoint != "":
+		return "grafana", map[string]interface{}{
+			"endpoint": config.Grafana.Endpoint,
+			"user":     config.Grafana.User,
+			"password": secret("grafana", "password", config.Grafana.Password),
+		}
+	case config.Splunk != nil && config.Splunk.HecEndpoint != "":
+		return "splunk", map[string]interface{}{
+			"hec_endpoint": config.Splunk.HecEndpoint,
+			"token":        secret("splunk", "token", config.Splunk.Token),
+		}
+	case config.Datadog != nil && config.Datadog.Region != "":
+		return "datadog", map[string]interface{}{
+			"api_key": secret("datadog", "api_key", config.Datadog.APIKey),
+			"region":  config.Datadog.Region,
+		}
+	case config.Cloudwatch != nil && config.Cloudwatch.AccessKeyID != "":
+		values := kind.cloudwatch(config.Cloudwatch)
+		values["access_key_id"] = config.Cloudwatch.AccessKeyID
+		values["secret_access_key"] = secret("cloudwatch", "secret_access_key", config.Cloudwatch.SecretAccessKey)
+		values["region"] = config.Cloudwatch.Region
+		return "cloudwatch", values
+	case config.Elasticsearch != nil && config.Elasticsearch.Endpoint != "":
+		return "elasticsearch", map[string]interface{}{
+			"endpoint": config.Elasticsearch.Endpoint,
+			"user":     config.Elasticsearch.User,
+			"password": secret("elasticsearch", "password", config.Elasticsearch.Password),
+			"dataset":  config.Elasticsearch.Dataset,
+		}
+	case config.HTTP != nil && config.HTTP.URI != "":
+		values := map[string]interface{}{
+			"uri":            config.HTTP.URI,
+			"method":         config.HTTP.Method,
+			"compression":    config.HTTP.Compression,
+			"headers":        config.HTTP.Headers,
+			"payload_prefix": config.HTTP.PayloadPrefix,
+			"payload_suffix": config.HTTP.PayloadSuffix,
+		}
+		if config.HTTP.Codec != nil {
+			values["codec"] = string(*config.HTTP.Codec)
+		}
+		if config.HTTP.Basic != nil {
+			values["basic_user"] = config.HTTP.Basic.User
+			values["basic_password"] = secret("http", "basic_password", config.HTTP.Basic.Password)
+		}
+		if config.HTTP.Bearer != nil {
+			values["bearer_token"] = secret("http", "bearer_token", config.HTTP.Bearer.Token)
+		}
+		return "http", values
+	case config.Newrelic != nil && config.Newrelic.AccountID != "":
+		values := map[string]interface{}{
+			"account_id":  config.Newrelic.AccountID,
+			"license_key": secret("newrelic", "license_key", config.Newrelic.LicenseKey),
+		}
+		if config.Newrelic.Region != nil {
+			values["region"] = string(*config.Newrelic.Region)
+		}
+		return "newrelic", values
+	default:
+		return "", nil
+	}
+}
@@ -0,0 +1,295 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providersdkv2
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+)
+
+func TestVaultClusterObservability_validate(t *testing.T) {
+	datadog := []interface{}{map[string]interface{}{"api_key": "key", "region": "us1"}}
+	splunk := []interface{}{map[string]interface{}{"hec_endpoint": "https://splunk.example.com", "token": "token"}}
+	http := func(extra map[string]interface{}) []interface{} {
+		block := map[string]interface{}{"uri": "https://logs.example.com", "method": "POST", "codec": "JSON"}
+		for k, v := range extra {
+			block[k] = v
+		}
+		return []interface{}{block}
+	}
+
+	tcs := map[string]struct {
+		config  map[string]interface{}
+		wantErr string
+	}{
+		"one destination": {
+			config: map[string]interface{}{"datadog": datadog},
+		},
+		"no destination": {
+			config:  map[string]interface{}{},
+			wantErr: "one of",
+		},
+		"two destinations": {
+			config:  map[string]interface{}{"datadog": datadog, "splunk": splunk},
+			wantErr: "only one of",
+		},
+		"missing attribute": {
+			config:  map[string]interface{}{"grafana": []interface{}{map[string]interface{}{"endpoint": "https://grafana.example.com"}}},
+			wantErr: "required",
+		},
+		"http basic auth": {
+			config: map[string]interface{}{"http": http(map[string]interface{}{"basic_user": "user", "basic_password": "password"})},
+		},
+		"write-only secret": {
+			config: map[string]interface{}{"datadog": []interface{}{map[string]interface{}{"api_key_wo": "key", "api_key_wo_version": 1, "region": "us1"}}},
+		},
+		"secret and write-only secret": {
+			config:  map[string]interface{}{"datadog": []interface{}{map[string]interface{}{"api_key": "key", "api_key_wo": "key", "region": "us1"}}},
+			wantErr: "only one of",
+		},
+		"write-only secret version without secret": {
+			config:  map[string]interface{}{"datadog": []interface{}{map[string]interface{}{"api_key": "key", "api_key_wo_version": 1, "region": "us1"}}},
+			wantErr: "api_key_wo",
+		},
+		"http basic auth with write-only password": {
+			config: map[string]interface{}{"http": http(map[string]interface{}{"basic_user": "user", "basic_password_wo": "password"})},
+		},
+		"http basic and write-only bearer auth": {
+			config:  map[string]interface{}{"http": http(map[string]interface{}{"basic_user": "user", "basic_password": "password", "bearer_token_wo": "token"})},
+			wantErr: "conflicts with",
+		},
+		"http basic and bearer auth": {
+			config:  map[string]interface{}{"http": http(map[string]interface{}{"basic_user": "user", "basic_password": "password", "bearer_token": "token"})},
+			wantErr: "conflicts with",
+		},
+		"http invalid method": {
+			config:  map[string]interface{}{"http": http(map[string]interface{}{"method": "GET"})},
+			wantErr: "method",
+		},
+		"http lowercase method": {
+			config:  map[string]interface{}{"http": http(map[string]interface{}{"method": "post"})},
+			wantErr: "method",
+		},
+		"http lowercase codec": {
+			config:  map[string]interface{}{"http": http(map[string]interface{}{"codec": "json"})},
+			wantErr: "codec",
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			tc.config["cluster_id"] = "test-vault"
+
+			for name, r := range map[string]*schema.Resource{
+				"metrics":   resourceVaultClusterMetricsConfig(),
+				"audit log": resourceVaultClusterAuditLogConfig(),
+			} {
+				diags := r.Validate(terraform.NewResourceConfigRaw(tc.config))
+				if tc.wantErr == "" {
+					if diags.HasError() {
+						t.Errorf("%s: unexpected error: %v", name, diags)
+					}
+					continue
+				}
+
+				var found bool
+				for _, d := range diags {
+					found = found || strings.Contains(d.Summary+d.Detail, tc.wantErr)
+				}
+				if !found {
+					t.Errorf("%s: expected an error containing %q, got %v", name, tc.wantErr, diags)
+				}
+			}
+		})
+	}
+}
+
+func TestVaultClusterObservability_basicAuth(t *testing.T) {
+	http := func(attrs map[string]cty.Value) cty.Value {
+		block := map[string]cty.Value{
+			"basic_user":        cty.NullVal(cty.String),
+			"basic_password":    cty.NullVal(cty.String),
+			"basic_password_wo": cty.NullVal(cty.String),
+		}
+		for k, v := range attrs {
+			block[k] = v
+		}
+		return cty.ObjectVal(map[string]cty.Value{"http": cty.ListVal([]cty.Value{cty.ObjectVal(block)})})
+	}
+
+	tcs := map[string]struct {
+		config  cty.Value
+		wantErr bool
+	}{
+		"no http destination": {
+			config: cty.ObjectVal(map[string]cty.Value{"http": cty.ListValEmpty(cty.EmptyObject)}),
+		},
+		"no basic auth": {
+			config: http(nil),
+		},
+		"password": {
+			config: http(map[string]cty.Value{"basic_user": cty.StringVal("user"), "basic_password": cty.StringVal("password")}),
+		},
+		"write-only password": {
+			config: http(map[string]cty.Value{"basic_user": cty.StringVal("user"), "basic_password_wo": cty.StringVal("password")}),
+		},
+		"no password": {
+			config:  http(map[string]cty.Value{"basic_user": cty.StringVal("user")}),
+			wantErr: true,
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			resp := &schema.ValidateResourceConfigFuncResponse{}
+			validateVaultObservabilityBasicAuth(context.Background(), schema.ValidateResourceConfigFuncRequest{RawConfig: tc.config}, resp)
+			if got := resp.Diagnostics.HasError(); got != tc.wantErr {
+				t.Errorf("expected error %t, got %v", tc.wantErr, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+// TestVaultClusterMetricsConfig runs the metrics config lifecycle against the
+// in-process fake HCP server, which redacts secrets like HCP does.
+func TestVaultClusterMetricsConfig(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := context.Background()
+
+	createTestHVN(t, ctx, client)
+	cluster := createTestVaultCluster(t, ctx, client, "dev", nil)
+
+	r := resourceVaultClusterMetricsConfig()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"cluster_id": "test-vault",
+		"datadog":    []interface{}{map[string]interface{}{"api_key": "secret-key", "region": "us1"}},
+	})
+	if diags := r.CreateContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("unexpected error creating metrics config: %v", diags)
+	}
+	if got := d.Get("datadog.0.api_key").(string); got != "secret-key" {
+		t.Errorf("expected the redacted API key to be kept from the configuration, got %q", got)
+	}
+	if got := d.Get("datadog.0.region").(string); got != "us1" {
+		t.Errorf("expected region us1, got %q", got)
+	}
+
+	// The cluster resource neither reads nor removes the configuration when
+	// its deprecated metrics_config block is not set.
+	if diags := resourceVaultClusterRead(ctx, cluster, client); diags.HasError() {
+		t.Fatalf("unexpected error reading Vault cluster: %v", diags)
+	}
+	clusterResource := resourceVaultCluster()
+	diff, err := clusterResource.Diff(ctx, cluster.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"cluster_id": "test-vault",
+		"hvn_id":     "test-hvn",
+		"tier":       "dev",
+		"locked":     true,
+	}), client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	state, diags := clusterResource.Apply(ctx, cluster.State(), diff, client)
+	if diags.HasError() {
+		t.Fatalf("unexpected error updating Vault cluster: %v", diags)
+	}
+	if got := state.Attributes["metrics_config.#"]; got != "" && got != "0" {
+		t.Errorf("expected the cluster not to report the metrics config, got %s blocks", got)
+	}
+	if diags := r.ReadContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("unexpected error reading metrics config: %v", diags)
+	}
+	if d.Id() == "" || d.Get("datadog.0.region").(string) != "us1" {
+		t.Errorf("expected the cluster to keep streaming to datadog")
+	}
+
+	// A second resource for the same cluster is rejected at plan time.
+	_, err = r.Diff(ctx, nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"cluster_id": "test-vault",
+		"splunk":     []interface{}{map[string]interface{}{"hec_endpoint": "https://splunk.example.com", "token": "secret-token"}},
+	}), client)
+	if err == nil || !strings.Contains(err.Error(), "already streams metrics") {
+		t.Errorf("expected the metrics config to be rejected, got %v", err)
+	}
+
+	if err := d.Set("datadog", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := d.Set("splunk", []interface{}{map[string]interface{}{"hec_endpoint": "https://splunk.example.com", "token": "secret-token"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diags := r.UpdateContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("unexpected error updating metrics config: %v", diags)
+	}
+	if got := d.Get("datadog.#").(int); got != 0 {
+		t.Errorf("expected the datadog destination to be replaced, got %d blocks", got)
+	}
+	if got := d.Get("splunk.0.hec_endpoint").(string); got != "https://splunk.example.com" {
+		t.Errorf("expected the splunk destination, got endpoint %q", got)
+	}
+
+	if diags := r.DeleteContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("unexpected error deleting metrics config: %v", diags)
+	}
+	if diags := r.ReadContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("unexpected error reading deleted metrics config: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("expected the deleted metrics config to be removed from the state, got ID %q", d.Id())
+	}
+}
+
+// TestVaultClusterMetricsConfig_writeOnly checks that a write-only secret is
+// sent to HCP without being saved in the state.
+func TestVaultClusterMetricsConfig_writeOnly(t *testing.T) {
+	client, srv := newTestClient(t)
+	ctx := context.Background()
+
+	createTestHVN(t, ctx, client)
+	createTestVaultCluster(t, ctx, client, "dev", nil)
+
+	r := resourceVaultClusterMetricsConfig()
+	diff, err := r.Diff(ctx, nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"cluster_id": "test-vault",
+		"datadog":    []interface{}{map[string]interface{}{"api_key_wo_version": 1, "region": "us1"}},
+	}), client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Write-only values are only found in the configuration.
+	diff.RawConfig = cty.ObjectVal(map[string]cty.Value{
+		"cluster_id": cty.StringVal("test-vault"),
+		"datadog": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			"api_key_wo":         cty.StringVal("secret-key"),
+			"api_key_wo_version": cty.NumberIntVal(1),
+			"region":             cty.StringVal("us1"),
+		})}),
+	})
+
+	state, diags := r.Apply(ctx, nil, diff, client)
+	if diags.HasError() {
+		t.Fatalf("unexpected error creating metrics config: %v", diags)
+	}
+	if got := state.Attributes["datadog.0.api_key"]; got != "" {
+		t.Errorf("expected the API key to be left out of the state, got %q", got)
+	}
+	if got := state.Attributes["datadog.0.api_key_wo_version"]; got != "1" {
+		t.Errorf("expected the API key version to be kept, got %q", got)
+	}
+
+	cluster, err := clients.GetVaultClusterByID(ctx, client, srv.Location(), "test-vault")
+	if err != nil {
+		t.Fatalf("unexpected error reading Vault cluster: %v", err)
+	}
+	if got := cluster.Config.MetricsConfig.Datadog.APIKey; got != "redacted" {
+		t.Errorf("expected the API key to be sent to HCP, got %q", got)
+	}
+}
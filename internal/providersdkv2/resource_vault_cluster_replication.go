@@ -0,0 +1,378 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providersdkv2
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
+	vaultmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-vault-service/stable/2020-11-25/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+)
+
+func resourceVaultClusterReplication() *schema.Resource {
+	return &schema.Resource{
+		Description: "The Vault cluster replication resource manages the performance replication between a primary and a secondary HCP Vault Plus tier cluster. " +
+			"HCP establishes performance replication when the secondary cluster is created with `primary_link` set, and ends it when the secondary cluster is deleted: " +
+			"this resource adopts the replication and manages its paths filter.",
+		CreateContext: resourceVaultClusterReplicationCreate,
+		ReadContext:   resourceVaultClusterReplicationRead,
+		UpdateContext: resourceVaultClusterReplicationUpdate,
+		DeleteContext: resourceVaultClusterReplicationDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Default: &defaultVaultClusterTimeout,
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVaultClusterReplicationImport,
+		},
+		Schema: map[string]*schema.Schema{
+			// Required inputs
+			"primary_link": {
+				Description: "The `self_link` of the primary HCP Vault Plus tier cluster.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"secondary_link": {
+				Description: "The `self_link` of the secondary HCP Vault Plus tier cluster. It must have been created with `primary_link` set to the primary cluster.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			// Optional inputs
+			"paths_filter": {
+				Description: "The performance replication [paths filter](https://developer.hashicorp.com/vault/tutorials/cloud-ops/vault-replication-terraform) of the secondary cluster. " +
+					"Operates in \"deny\" mode only. It replaces the deprecated `paths_filter` of the secondary `hcp_vault_cluster` resource, which must not be set together with it.",
+				Type:     schema.TypeList,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validateVaultPathsFilter,
+				},
+				Optional: true,
+			},
+		},
+	}
+}
+
+func resourceVaultClusterReplicationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client)
+
+	primary, diags := getPrimaryClusterFromLink(ctx, client, d.Get("primary_link").(string))
+	if diags != nil {
+		return diags
+	}
+
+	secondaryLink, err := buildLinkFromURL(d.Get("secondary_link").(string), VaultClusterResourceType, client.Config.OrganizationID)
+	if err != nil {
+		return diag.Errorf("invalid secondary_link supplied %v", err)
+	}
+
+	secondary, err := clients.GetVaultClusterByID(ctx, client, secondaryLink.Location, secondaryLink.ID)
+	if err != nil {
+		if clients.IsResponseCodeNotFound(err) {
+			return diag.Errorf("secondary cluster (%s) does not exist", secondaryLink.ID)
+		}
+		return diag.Errorf("unable to check for presence of an existing secondary Vault cluster (%s): %v", secondaryLink.ID, err)
+	}
+
+	if !replicatesFrom(secondary, primary) {
+		return diag.Errorf("Vault cluster (%s) is not a performance replication secondary of Vault cluster (%s); "+
+			"performance replication is established by creating the secondary cluster with primary_link set to the `self_link` of the primary cluster",
+			secondary.ID, primary.ID)
+	}
+
+	// A paths filter set on the secondary may be managed by the paths_filter
+	// of its hcp_vault_cluster resource, which would revert the changes made by
+	// this one. An existing paths filter has to be imported instead.
+	if prInfo := secondary.PerformanceReplicationInfo; prInfo != nil && prInfo.PathsFilter != nil && len(prInfo.PathsFilter.Paths) > 0 {
+		return diag.Errorf("Vault cluster (%s) already has a paths filter, most likely through the paths_filter of its hcp_vault_cluster resource; "+
+			"remove paths_filter from that resource, then import this resource instead of creating it",
+			secondary.ID)
+	}
+
+	link := newLink(secondaryLink.Location, VaultClusterReplicationResourceType, secondary.ID)
+	url, err := linkURL(link)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if paths, ok := d.GetOk("paths_filter"); ok {
+		if diags := updateVaultClusterReplicationPathsFilter(ctx, client, secondary, getPathStrings(paths)); diags != nil {
+			return diags
+		}
+	}
+
+	d.SetId(url)
+
+	return resourceVaultClusterReplicationRead(ctx, d, meta)
+}
+
+func resourceVaultClusterReplicationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client)
+
+	link, err := buildLinkFromURL(d.Id(), VaultClusterReplicationResourceType, client.Config.OrganizationID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	clusterID := link.ID
+	loc := link.Location
+
+	secondary, err := clients.GetVaultClusterByID(ctx, client, loc, clusterID)
+	if err != nil {
+		if clients.IsResponseCodeNotFound(err) {
+			log.Printf("[WARN] Vault cluster (%s) not found, removing replication from state", clusterID)
+			d.SetId("")
+			return nil
+		}
+
+		return diag.Errorf("unable to fetch Vault cluster (%s): %v", clusterID, err)
+	}
+
+	info := secondary.PerformanceReplicationInfo
+	if info == nil || info.PrimaryClusterLink == nil {
+		log.Printf("[WARN] Vault cluster (%s) is no longer a performance replication secondary, removing replication from state", clusterID)
+		d.SetId("")
+		return nil
+	}
+
+	if err := setVaultClusterReplicationResourceData(d, secondary); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceVaultClusterReplicationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client)
+
+	if !d.HasChange("paths_filter") {
+		return nil
+	}
+
+	secondary, diags := getVaultClusterReplicationSecondary(ctx, client, d)
+	if diags != nil {
+		return diags
+	}
+
+	if paths, ok := d.GetOk("paths_filter"); ok {
+		diags = updateVaultClusterReplicationPathsFilter(ctx, client, secondary, getPathStrings(paths))
+	} else {
+		diags = deleteVaultClusterReplicationPathsFilter(ctx, client, secondary)
+	}
+	if diags != nil {
+		return diags
+	}
+
+	return resourceVaultClusterReplicationRead(ctx, d, meta)
+}
+
+func resourceVaultClusterReplicationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client)
+
+	// The replication itself ends when the secondary cluster is deleted, so
+	// only the paths filter managed by this resource is removed.
+	if _, ok := d.GetOk("paths_filter"); !ok {
+		return nil
+	}
+
+	secondary, diags := getVaultClusterReplicationSecondary(ctx, client, d)
+	if diags != nil {
+		return diags
+	}
+	if secondary == nil {
+		return nil
+	}
+
+	return deleteVaultClusterReplicationPathsFilter(ctx, client, secondary)
+}
+
+func resourceVaultClusterReplicationImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	// with multi-projects, import arguments must become dynamic:
+	// use explicit project ID with terraform import:
+	//   terraform import hcp_vault_cluster_replication.test f709ec73-55d4-46d8-897d-816ebba28778:test-secondary-cluster
+	// use default project ID from provider:
+	//   terraform import hcp_vault_cluster_replication.test test-secondary-cluster
+
+	client := meta.(*clients.Client)
+	projectID := ""
+	clusterID := ""
+	var err error
+
+	if strings.Contains(d.Id(), ":") { // {project_id}:{secondary_cluster_id}
+		idParts := strings.SplitN(d.Id(), ":", 2)
+		clusterID = idParts[1]
+		projectID = idParts[0]
+	} else { // {secondary_cluster_id}
+		clusterID = d.Id()
+		projectID, err = GetProjectID(projectID, client.Config.ProjectID)
+		if err != nil {
+			return nil, fmt.Errorf("unable to retrieve project ID: %v", err)
+		}
+	}
+
+	loc := &sharedmodels.HashicorpCloudLocationLocation{
+		ProjectID: projectID,
+	}
+
+	link := newLink(loc, VaultClusterReplicationResourceType, clusterID)
+	url, err := linkURL(link)
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(url)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// getVaultClusterReplicationSecondary fetches the secondary cluster of the
+// replication. It returns a nil cluster if the secondary no longer exists.
+func getVaultClusterReplicationSecondary(ctx context.Context, client *clients.Client, d *schema.ResourceData) (*vaultmodels.HashicorpCloudVault20201125Cluster, diag.Diagnostics) {
+	link, err := buildLinkFromURL(d.Id(), VaultClusterReplicationResourceType, client.Config.OrganizationID)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	secondary, err := clients.GetVaultClusterByID(ctx, client, link.Location, link.ID)
+	if err != nil {
+		if clients.IsResponseCodeNotFound(err) {
+			log.Printf("[WARN] Vault cluster (%s) not found, so no action was taken", link.ID)
+			return nil, nil
+		}
+		return nil, diag.Errorf("unable to fetch Vault cluster (%s): %v", link.ID, err)
+	}
+
+	return secondary, nil
+}
+
+// updateVaultClusterReplicationPathsFilter sets the paths filter of the
+// secondary cluster and waits for the update to complete.
+func updateVaultClusterReplicationPathsFilter(ctx context.Context, client *clients.Client, secondary *vaultmodels.HashicorpCloudVault20201125Cluster, paths []string) diag.Diagnostics {
+	if secondary == nil {
+		return diag.Errorf("unable to update Vault cluster paths filter: secondary cluster no longer exists")
+	}
+
+	loc := vaultClusterSharedLocation(secondary)
+	mode := vaultmodels.HashicorpCloudVault20201125ClusterPerformanceReplicationPathsFilterModeDENY
+
+	log.Printf("[INFO] Updating paths filter of Vault cluster (%s)", secondary.ID)
+
+	updateResp, err := clients.UpdateVaultPathsFilter(ctx, client, loc, secondary.ID, vaultmodels.HashicorpCloudVault20201125ClusterPerformanceReplicationPathsFilter{
+		Mode:  &mode,
+		Paths: paths,
+	})
+	if err != nil {
+		return diag.Errorf("error updating Vault cluster paths filter (%s): %v", secondary.ID, err)
+	}
+
+	if err := clients.WaitForOperation(ctx, client, "update Vault cluster paths filter", loc, updateResp.Operation.ID); err != nil {
+		return diag.Errorf("unable to update Vault cluster paths filter (%s): %v", secondary.ID, err)
+	}
+
+	return nil
+}
+
+// deleteVaultClusterReplicationPathsFilter removes the paths filter of the
+// secondary cluster and waits for the deletion to complete.
+func deleteVaultClusterReplicationPathsFilter(ctx context.Context, client *clients.Client, secondary *vaultmodels.HashicorpCloudVault20201125Cluster) diag.Diagnostics {
+	if secondary == nil {
+		return nil
+	}
+
+	loc := vaultClusterSharedLocation(secondary)
+
+	log.Printf("[INFO] Deleting paths filter of Vault cluster (%s)", secondary.ID)
+
+	deleteResp, err := clients.DeleteVaultPathsFilter(ctx, client, loc, secondary.ID)
+	if err != nil {
+		return diag.Errorf("error deleting Vault cluster paths filter (%s): %v", secondary.ID, err)
+	}
+
+	if err := clients.WaitForOperation(ctx, client, "delete Vault cluster paths filter", loc, deleteResp.Operation.ID); err != nil {
+		return diag.Errorf("unable to delete Vault cluster paths filter (%s): %v", secondary.ID, err)
+	}
+
+	return nil
+}
+
+func setVaultClusterReplicationResourceData(d *schema.ResourceData, secondary *vaultmodels.HashicorpCloudVault20201125Cluster) error {
+	secondaryLink, err := linkURL(newLink(vaultClusterSharedLocation(secondary), VaultClusterResourceType, secondary.ID))
+	if err != nil {
+		return err
+	}
+	if err := d.Set("secondary_link", secondaryLink); err != nil {
+		return err
+	}
+
+	info := secondary.PerformanceReplicationInfo
+
+	primaryLink, err := vaultPrimaryLinkURL(info)
+	if err != nil {
+		return err
+	}
+	if err := d.Set("primary_link", primaryLink); err != nil {
+		return err
+	}
+
+	var paths []string
+	if info.PathsFilter != nil {
+		paths = info.PathsFilter.Paths
+	}
+	if err := d.Set("paths_filter", paths); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// replicatesFrom reports whether secondary is a performance replication
+// secondary of primary.
+func replicatesFrom(secondary, primary *vaultmodels.HashicorpCloudVault20201125Cluster) bool {
+	info := secondary.PerformanceReplicationInfo
+	if info == nil || info.PrimaryClusterLink == nil || info.PrimaryClusterLink.Location == nil {
+		return false
+	}
+
+	return info.PrimaryClusterLink.ID == primary.ID &&
+		info.PrimaryClusterLink.Location.ProjectID == primary.Location.ProjectID
+}
+
+// vaultPrimaryLinkURL returns the self link of the primary cluster a
+// secondary replicates from.
+func vaultPrimaryLinkURL(info *vaultmodels.HashicorpCloudVault20201125ClusterPerformanceReplicationInfo) (string, error) {
+	primary := info.PrimaryClusterLink
+	if primary == nil || primary.Location == nil {
+		return "", fmt.Errorf("missing primary cluster link")
+	}
+
+	loc := &sharedmodels.HashicorpCloudLocationLocation{
+		OrganizationID: primary.Location.OrganizationID,
+		ProjectID:      primary.Location.ProjectID,
+	}
+
+	return linkURL(newLink(loc, VaultClusterResourceType, primary.ID))
+}
+
+// vaultClusterSharedLocation returns the location of the cluster, including
+// its region.
+func vaultClusterSharedLocation(cluster *vaultmodels.HashicorpCloudVault20201125Cluster) *sharedmodels.HashicorpCloudLocationLocation {
+	loc := &sharedmodels.HashicorpCloudLocationLocation{
+		OrganizationID: cluster.Location.OrganizationID,
+		ProjectID:      cluster.Location.ProjectID,
+	}
+	if cluster.Location.Region != nil {
+		loc.Region = &sharedmodels.HashicorpCloudLocationRegion{
+			Provider: cluster.Location.Region.Provider,
+			Region:   cluster.Location.Region.Region,
+		}
+	}
+	return loc
+}
@@ -0,0 +1,179 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providersdkv2
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestVaultClusterReplication runs the replication lifecycle against the
+// in-process fake HCP server, inspecting it with the status data source.
+func TestVaultClusterReplication(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := context.Background()
+
+	createTestHVN(t, ctx, client)
+
+	createCluster := func(id string, extra map[string]interface{}) *schema.ResourceData {
+		raw := map[string]interface{}{"cluster_id": id}
+		for k, v := range extra {
+			raw[k] = v
+		}
+		return createTestVaultCluster(t, ctx, client, "plus_small", raw)
+	}
+	primary := createCluster("test-primary", nil)
+	standalone := createCluster("test-standalone", nil)
+	secondary := createCluster("test-secondary", map[string]interface{}{
+		"primary_link": primary.Get("self_link").(string),
+	})
+
+	// Only an existing secondary of the primary can be adopted.
+	invalid := schema.TestResourceDataRaw(t, resourceVaultClusterReplication().Schema, map[string]interface{}{
+		"primary_link":   primary.Get("self_link").(string),
+		"secondary_link": standalone.Get("self_link").(string),
+	})
+	diags := resourceVaultClusterReplicationCreate(ctx, invalid, client)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "is not a performance replication secondary") {
+		t.Fatalf("expected an error adopting a standalone cluster, got %v", diags)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceVaultClusterReplication().Schema, map[string]interface{}{
+		"primary_link":   primary.Get("self_link").(string),
+		"secondary_link": secondary.Get("self_link").(string),
+		"paths_filter":   []interface{}{"path/a", "path/b"},
+	})
+	if diags := resourceVaultClusterReplicationCreate(ctx, d, client); diags.HasError() {
+		t.Fatalf("unexpected error creating replication: %v", diags)
+	}
+	if got := d.Get("paths_filter.#").(int); got != 2 {
+		t.Fatalf("expected 2 filtered paths, got %d", got)
+	}
+
+	// The secondary cluster resource still reports the paths filter, which
+	// its deprecated paths_filter would otherwise overwrite.
+	if diags := resourceVaultClusterRead(ctx, secondary, client); diags.HasError() {
+		t.Fatalf("unexpected error reading secondary: %v", diags)
+	}
+	if got := secondary.Get("paths_filter.#").(int); got != 2 {
+		t.Errorf("expected the secondary to report 2 filtered paths, got %d", got)
+	}
+
+	// Updating the secondary cluster resource without a paths_filter leaves
+	// the paths filter of the replication in place.
+	r := resourceVaultCluster()
+	raw := map[string]interface{}{
+		"cluster_id":   "test-secondary",
+		"hvn_id":       "test-hvn",
+		"tier":         "plus_small",
+		"primary_link": primary.Get("self_link").(string),
+		"locked":       true,
+	}
+	diff, err := r.Diff(ctx, secondary.State(), sdkterraform.NewResourceConfigRaw(raw), client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := diff.Attributes["paths_filter.#"]; ok {
+		t.Errorf("expected no paths_filter change, got %v", diff.Attributes)
+	}
+	state, diags := r.Apply(ctx, secondary.State(), diff, client)
+	if diags.HasError() {
+		t.Fatalf("unexpected error updating secondary: %v", diags)
+	}
+	if got := state.Attributes["paths_filter.#"]; got != "2" {
+		t.Errorf("expected the secondary to keep 2 filtered paths, got %s", got)
+	}
+
+	readStatus := func(clusterID string) *schema.ResourceData {
+		status := schema.TestResourceDataRaw(t, dataSourceVaultClusterReplicationStatus().Schema, map[string]interface{}{
+			"cluster_id": clusterID,
+		})
+		if diags := dataSourceVaultClusterReplicationStatusRead(ctx, status, client); diags.HasError() {
+			t.Fatalf("unexpected error reading replication status of %q: %v", clusterID, diags)
+		}
+		return status
+	}
+
+	status := readStatus("test-secondary")
+	if got := status.Get("mode").(string); got != "SECONDARY" {
+		t.Errorf("expected mode SECONDARY, got %q", got)
+	}
+	if got := status.Get("primary_link").(string); got != primary.Get("self_link").(string) {
+		t.Errorf("expected primary_link %q, got %q", primary.Get("self_link"), got)
+	}
+	if got := status.Get("paths_filter.1").(string); got != "path/b" {
+		t.Errorf("expected the paths filter to be reported, got %q", got)
+	}
+	if got := status.Get("connection_status").(string); got != "CONNECTED" {
+		t.Errorf("expected connection_status CONNECTED, got %q", got)
+	}
+
+	status = readStatus("test-primary")
+	if got := status.Get("mode").(string); got != "PRIMARY" {
+		t.Errorf("expected mode PRIMARY, got %q", got)
+	}
+	if got := status.Get("secondary_links.0").(string); got != secondary.Get("self_link").(string) {
+		t.Errorf("expected secondary_links to contain %q, got %q", secondary.Get("self_link"), got)
+	}
+
+	if got := readStatus("test-standalone").Get("mode").(string); got != "" {
+		t.Errorf("expected no mode for a standalone cluster, got %q", got)
+	}
+
+	updated := schema.TestResourceDataRaw(t, resourceVaultClusterReplication().Schema, map[string]interface{}{
+		"primary_link":   primary.Get("self_link").(string),
+		"secondary_link": secondary.Get("self_link").(string),
+		"paths_filter":   []interface{}{"path/a"},
+	})
+	updated.SetId(d.Id())
+	if diags := resourceVaultClusterReplicationUpdate(ctx, updated, client); diags.HasError() {
+		t.Fatalf("unexpected error updating replication: %v", diags)
+	}
+	if got := readStatus("test-secondary").Get("paths_filter.#").(int); got != 1 {
+		t.Fatalf("expected 1 filtered path after the update, got %d", got)
+	}
+
+	// Deleting the replication removes the paths filter from the secondary.
+	if diags := resourceVaultClusterReplicationDelete(ctx, updated, client); diags.HasError() {
+		t.Fatalf("unexpected error deleting replication: %v", diags)
+	}
+	if got := readStatus("test-secondary").Get("paths_filter.#").(int); got != 0 {
+		t.Fatalf("expected the paths filter to be removed, got %d paths", got)
+	}
+
+	// A paths filter set through the secondary cluster resource is not adopted.
+	filtered := createCluster("test-filtered", map[string]interface{}{
+		"primary_link": primary.Get("self_link").(string),
+		"paths_filter": []interface{}{"path/c"},
+	})
+	conflicting := schema.TestResourceDataRaw(t, resourceVaultClusterReplication().Schema, map[string]interface{}{
+		"primary_link":   primary.Get("self_link").(string),
+		"secondary_link": filtered.Get("self_link").(string),
+	})
+	diags = resourceVaultClusterReplicationCreate(ctx, conflicting, client)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "already has a paths filter") {
+		t.Fatalf("expected an error adopting a secondary with a paths filter, got %v", diags)
+	}
+	if diags := resourceVaultClusterDelete(ctx, filtered, client); diags.HasError() {
+		t.Fatalf("unexpected error deleting Vault cluster: %v", diags)
+	}
+
+	// Deleting the secondary ends the replication.
+	if diags := resourceVaultClusterDelete(ctx, secondary, client); diags.HasError() {
+		t.Fatalf("unexpected error deleting secondary: %v", diags)
+	}
+	if diags := resourceVaultClusterReplicationRead(ctx, d, client); diags.HasError() {
+		t.Fatalf("unexpected error reading replication: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Error("expected the replication to be removed from state")
+	}
+	if got := readStatus("test-primary").Get("mode").(string); got != "" {
+		t.Errorf("expected the primary to stop replicating, got mode %q", got)
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providersdkv2
+
+import (
+	"context"
+	"log"
+
+	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
+	vaultmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-vault-service/stable/2020-11-25/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+)
+
+func resourceVaultClusterSnapshot() *schema.Resource {
+	return &schema.Resource{
+		Description:   "The Vault cluster snapshot resource allows users to take on-demand snapshots of an HCP Vault cluster.",
+		CreateContext: resourceVaultClusterSnapshotCreate,
+		ReadContext:   resourceVaultClusterSnapshotRead,
+		DeleteContext: resourceVaultClusterSnapshotDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create:  &snapshotCreateUpdateDeleteTimeoutDuration,
+			Delete:  &snapshotCreateUpdateDeleteTimeoutDuration,
+			Default: &defaultSnapshotTimeoutDuration,
+		},
+		Schema: map[string]*schema.Schema{
+			// Required inputs
+			"cluster_id": {
+				Description:      "The ID of the HCP Vault cluster.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateSlugID,
+			},
+			"snapshot_name": {
+				Description:      "The name of the snapshot.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateStringNotEmpty,
+			},
+			// Optional inputs
+			"project_id": {
+				Description: `
+The ID of the HCP project where the HCP Vault cluster is located.
+If not specified, the project specified in the HCP Provider config block will be used, if configured.
+If a project is not configured in the HCP Provider config block, the oldest project in the organization will be used.`,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+				Computed:     true,
+			},
+			// Computed outputs
+			"snapshot_id": {
+				Description: "The ID of the Vault snapshot.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"organization_id": {
+				Description: "The ID of the HCP organization where the HCP Vault cluster is located.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"state": {
+				Description: "The state of the Vault snapshot.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"vault_version": {
+				Description: "The version of Vault at the time of snapshot creation.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"created_at": {
+				Description: "The time that the snapshot was requested.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"finished_at": {
+				Description: "The time that the snapshot finished. Blank while the snapshot is being created.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceVaultClusterSnapshotCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client)
+
+	clusterID := d.Get("cluster_id").(string)
+
+	projectID, err := GetProjectID(d.Get("project_id").(string), client.Config.ProjectID)
+	if err != nil {
+		return diag.Errorf("unable to retrieve project ID: %v", err)
+	}
+
+	loc := &sharedmodels.HashicorpCloudLocationLocation{
+		OrganizationID: client.Config.OrganizationID,
+		ProjectID:      projectID,
+	}
+
+	// Check for an existing Vault cluster
+	cluster, err := clients.GetVaultClusterByID(ctx, client, loc, clusterID)
+	if err != nil {
+		if !clients.IsResponseCodeNotFound(err) {
+			return diag.Errorf("unable to check for presence of an existing Vault cluster (%s): %v", clusterID, err)
+		}
+
+		// a 404 indicates a Vault cluster was not found
+		return diag.Errorf("unable to create snapshot; no HCP Vault cluster found for Vault cluster (%s)", clusterID)
+	}
+
+	name := d.Get("snapshot_name").(string)
+
+	log.Printf("[INFO] Creating Vault snapshot (%s)", name)
+
+	createResp, err := clients.CreateVaultSnapshot(ctx, client, cluster, name)
+	if err != nil {
+		return diag.Errorf("unable to create Vault snapshot (%s): %v", clusterID, err)
+	}
+
+	log.Printf("[INFO] Created Vault snapshot name:%q; id:%q", name, createResp.SnapshotID)
+
+	link := newLink(loc, VaultSnapshotResourceType, createResp.SnapshotID)
+	url, err := linkURL(link)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(url)
+
+	// wait for the Vault snapshot to be created
+	if err := clients.WaitForOperation(ctx, client, VaultSnapshotResourceType+".create", loc, createResp.Operation.ID); err != nil {
+		return diag.Errorf("unable to create Vault snapshot (%s): %v", createResp.SnapshotID, err)
+	}
+
+	return resourceVaultClusterSnapshotRead(ctx, d, meta)
+}
+
+func resourceVaultClusterSnapshotRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client)
+
+	link, err := buildLinkFromURL(d.Id(), VaultSnapshotResourceType, client.Config.OrganizationID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	snapshotID := link.ID
+	loc := link.Location
+
+	snapshot, err := clients.GetVaultSnapshotByID(ctx, client, loc, snapshotID)
+	if err != nil {
+		if clients.IsResponseCodeNotFound(err) {
+			log.Printf("[WARN] Vault snapshot (%s) not found, removing from state", snapshotID)
+			d.SetId("")
+			return nil
+		}
+
+		return diag.Errorf("unable to fetch Vault snapshot (%s): %v", snapshotID, err)
+	}
+
+	if err := setVaultClusterSnapshotResourceData(d, snapshot); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceVaultClusterSnapshotDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client)
+
+	link, err := buildLinkFromURL(d.Id(), VaultSnapshotResourceType, client.Config.OrganizationID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	snapshotID := link.ID
+	loc := link.Location
+
+	log.Printf("[INFO] Deleting Vault snapshot (%s)", snapshotID)
+
+	deleteResp, err := clients.DeleteVaultSnapshotByID(ctx, client, loc, snapshotID)
+	if err != nil {
+		if clients.IsResponseCodeNotFound(err) {
+			log.Printf("[WARN] Vault snapshot (%s) not found, so no action was taken", snapshotID)
+			return nil
+		}
+
+		return diag.Errorf("unable to delete Vault snapshot (%s): %v", snapshotID, err)
+	}
+
+	// Wait for the delete snapshot operation
+	if err := clients.WaitForOperation(ctx, client, VaultSnapshotResourceType+".delete", loc, deleteResp.Operation.ID); err != nil {
+		return diag.Errorf("unable to delete Vault snapshot (%s): %v", snapshotID, err)
+	}
+
+	log.Printf("[INFO] Vault snapshot (%s) deleted, removing from state", snapshotID)
+
+	return nil
+}
+
+func setVaultClusterSnapshotResourceData(d *schema.ResourceData, snapshot *vaultmodels.HashicorpCloudVault20201125Snapshot) error {
+	if err := d.Set("cluster_id", snapshot.ClusterID); err != nil {
+		return err
+	}
+
+	if err := d.Set("organization_id", snapshot.Location.OrganizationID); err != nil {
+		return err
+	}
+
+	if err := d.Set("project_id", snapshot.Location.ProjectID); err != nil {
+		return err
+	}
+
+	for k, v := range flattenVaultSnapshot(snapshot) {
+		if err := d.Set(k, v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// flattenVaultSnapshot returns the attributes shared by the Vault cluster
+// snapshot resource and the snapshots data source.
+func flattenVaultSnapshot(snapshot *vaultmodels.HashicorpCloudVault20201125Snapshot) map[string]interface{} {
+	var state string
+	if snapshot.State != nil {
+		state = string(*snapshot.State)
+	}
+
+	var finishedAt string
+	if !snapshot.FinishedAt.IsZero() {
+		finishedAt = snapshot.FinishedAt.String()
+	}
+
+	return map[string]interface{}{
+		"snapshot_id":   snapshot.SnapshotID,
+		"snapshot_name": snapshot.Name,
+		"state":         state,
+		"vault_version": snapshot.VaultVersion,
+		"created_at":    snapshot.RequestedAt.String(),
+		"finished_at":   finishedAt,
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providersdkv2
+
+import (
+	"context"
+	"log"
+
+	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+)
+
+func resourceVaultClusterSnapshotRestore() *schema.Resource {
+	return &schema.Resource{
+		Description: "The Vault cluster snapshot restore resource restores a Vault snapshot on an HCP Vault cluster. " +
+			"The restore runs when the resource is created; destroying the resource does not revert it.",
+		CreateContext: resourceVaultClusterSnapshotRestoreCreate,
+		ReadContext:   resourceVaultClusterSnapshotRestoreRead,
+		DeleteContext: resourceVaultClusterSnapshotRestoreDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create:  &snapshotCreateUpdateDeleteTimeoutDuration,
+			Default: &defaultSnapshotTimeoutDuration,
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: snapshotRestoreImport(VaultSnapshotRestoreResourceType),
+		},
+		Schema: map[string]*schema.Schema{
+			// Required inputs
+			"cluster_id": {
+				Description:      "The ID of the HCP Vault cluster to restore the snapshot on.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateSlugID,
+			},
+			"snapshot_id": {
+				Description:      "The ID of the Vault snapshot to restore.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateStringNotEmpty,
+			},
+			// Optional inputs
+			"project_id": {
+				Description: `
+The ID of the HCP project where the HCP Vault cluster and the snapshot are located.
+If not specified, the project specified in the HCP Provider config block will be used, if configured.
+If a project is not configured in the HCP Provider config block, the oldest project in the organization will be used.`,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+				Computed:     true,
+			},
+			// Computed outputs
+			"organization_id": {
+				Description: "The ID of the HCP organization where the HCP Vault cluster is located.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceVaultClusterSnapshotRestoreCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client)
+
+	clusterID := d.Get("cluster_id").(string)
+	snapshotID := d.Get("snapshot_id").(string)
+
+	projectID, err := GetProjectID(d.Get("project_id").(string), client.Config.ProjectID)
+	if err != nil {
+		return diag.Errorf("unable to retrieve project ID: %v", err)
+	}
+
+	loc := &sharedmodels.HashicorpCloudLocationLocation{
+		OrganizationID: client.Config.OrganizationID,
+		ProjectID:      projectID,
+	}
+
+	// Check for an existing Vault cluster
+	cluster, err := clients.GetVaultClusterByID(ctx, client, loc, clusterID)
+	if err != nil {
+		if !clients.IsResponseCodeNotFound(err) {
+			return diag.Errorf("unable to check for presence of an existing Vault cluster (%s): %v", clusterID, err)
+		}
+
+		// a 404 indicates a Vault cluster was not found
+		return diag.Errorf("unable to restore snapshot; no HCP Vault cluster found for Vault cluster (%s)", clusterID)
+	}
+
+	if _, err := clients.GetVaultSnapshotByID(ctx, client, loc, snapshotID); err != nil {
+		if !clients.IsResponseCodeNotFound(err) {
+			return diag.Errorf("unable to fetch Vault snapshot (%s): %v", snapshotID, err)
+		}
+
+		return diag.Errorf("unable to restore snapshot; no Vault snapshot found with ID (%s)", snapshotID)
+	}
+
+	log.Printf("[INFO] Restoring Vault snapshot (%s) on Vault cluster (%s)", snapshotID, clusterID)
+
+	restoreResp, err := clients.RestoreVaultSnapshot(ctx, client, cluster, snapshotID)
+	if err != nil {
+		return diag.Errorf("unable to restore Vault snapshot (%s) on Vault cluster (%s): %v", snapshotID, clusterID, err)
+	}
+
+	link := newLink(loc, VaultSnapshotRestoreResourceType, snapshotRestoreLinkID(clusterID, snapshotID))
+	url, err := linkURL(link)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(url)
+
+	// wait for the Vault snapshot to be restored
+	if err := clients.WaitForOperation(ctx, client, VaultSnapshotRestoreResourceType, loc, restoreResp.Operation.ID); err != nil {
+		return diag.Errorf("unable to restore Vault snapshot (%s) on Vault cluster (%s): %v", snapshotID, clusterID, err)
+	}
+
+	log.Printf("[INFO] Restored Vault snapshot (%s) on Vault cluster (%s)", snapshotID, clusterID)
+
+	return resourceVaultClusterSnapshotRestoreRead(ctx, d, meta)
+}
+
+func resourceVaultClusterSnapshotRestoreRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client)
+
+	link, err := buildLinkFromURL(d.Id(), VaultSnapshotRestoreResourceType, client.Config.OrganizationID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	clusterID, snapshotID, err := parseSnapshotRestoreLinkID(link.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	loc := link.Location
+
+	// The restore stays recorded once the snapshot is deleted, so that it is
+	// not attempted again. It only goes away with the cluster.
+	if _, err := clients.GetVaultClusterByID(ctx, client, loc, clusterID); err != nil {
+		if clients.IsResponseCodeNotFound(err) {
+			log.Printf("[WARN] Vault cluster (%s) not found, removing snapshot restore from state", clusterID)
+			d.SetId("")
+			return nil
+		}
+
+		return diag.Errorf("unable to fetch Vault cluster (%s): %v", clusterID, err)
+	}
+
+	attrs := map[string]interface{}{
+		"cluster_id":      clusterID,
+		"snapshot_id":     snapshotID,
+		"project_id":      loc.ProjectID,
+		"organization_id": loc.OrganizationID,
+	}
+	for k, v := range attrs {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func resourceVaultClusterSnapshotRestoreDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// A restore cannot be undone, so the cluster is left as is.
+	log.Printf("[INFO] Removing restore of Vault snapshot (%s) from state, the Vault cluster (%s) is unchanged",
+		d.Get("snapshot_id").(string), d.Get("cluster_id").(string))
+
+	return nil
+}
@@ -0,0 +1,111 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providersdkv2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	vaultmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-vault-service/stable/2020-11-25/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients/hcptest"
+)
+
+// TestVaultClusterSnapshot runs the snapshot lifecycle against the in-process
+// fake HCP server, listing the snapshots with the data source and restoring
+// one along the way.
+func TestVaultClusterSnapshot(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := context.Background()
+
+	createTestHVN(t, ctx, client)
+	createTestVaultCluster(t, ctx, client, "dev", nil)
+
+	var snapshots []*schema.ResourceData
+	for _, name := range []string{"before-upgrade", "after-upgrade"} {
+		d := schema.TestResourceDataRaw(t, resourceVaultClusterSnapshot().Schema, map[string]interface{}{
+			"cluster_id":    "test-vault",
+			"snapshot_name": name,
+		})
+		if diags := resourceVaultClusterSnapshotCreate(ctx, d, client); diags.HasError() {
+			t.Fatalf("unexpected error creating snapshot: %v", diags)
+		}
+		snapshots = append(snapshots, d)
+
+		// The fake server reports creation times with millisecond precision.
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	first := snapshots[0]
+	if got := first.Get("state").(string); got != string(vaultmodels.HashicorpCloudVault20201125SnapshotStateSTORED) {
+		t.Errorf("expected snapshot to be STORED, got %q", got)
+	}
+	if got := first.Get("vault_version").(string); got != hcptest.DefaultVaultVersion {
+		t.Errorf("expected vault_version %q, got %q", hcptest.DefaultVaultVersion, got)
+	}
+	if first.Get("created_at").(string) == "" || first.Get("finished_at").(string) == "" {
+		t.Error("expected created_at and finished_at to be set")
+	}
+
+	list := schema.TestResourceDataRaw(t, dataSourceVaultClusterSnapshots().Schema, map[string]interface{}{
+		"cluster_id": "test-vault",
+	})
+	if diags := dataSourceVaultClusterSnapshotsRead(ctx, list, client); diags.HasError() {
+		t.Fatalf("unexpected error listing snapshots: %v", diags)
+	}
+	if got := list.Get("snapshots.#").(int); got != 2 {
+		t.Fatalf("expected 2 snapshots, got %d", got)
+	}
+	if got := list.Get("snapshots.0.snapshot_id").(string); got != first.Get("snapshot_id").(string) {
+		t.Errorf("expected the oldest snapshot first, got %q", got)
+	}
+	if got := list.Get("snapshots.0.type").(string); got != string(vaultmodels.HashicorpCloudVault20201125SnapshotTypeMANUAL) {
+		t.Errorf("expected a MANUAL snapshot, got %q", got)
+	}
+
+	// The same snapshot restored on two clusters gives two restores.
+	createTestVaultCluster(t, ctx, client, "dev", map[string]interface{}{"cluster_id": "other-vault"})
+	restores := map[string]string{}
+	for _, clusterID := range []string{"test-vault", "other-vault"} {
+		restore := schema.TestResourceDataRaw(t, resourceVaultClusterSnapshotRestore().Schema, map[string]interface{}{
+			"cluster_id":  clusterID,
+			"snapshot_id": first.Get("snapshot_id").(string),
+		})
+		if diags := resourceVaultClusterSnapshotRestoreCreate(ctx, restore, client); diags.HasError() {
+			t.Fatalf("unexpected error restoring snapshot on %s: %v", clusterID, diags)
+		}
+		restores[restore.Id()] = clusterID
+	}
+	if len(restores) != 2 {
+		t.Errorf("expected the restores to have different IDs, got %v", restores)
+	}
+
+	r := resourceVaultClusterSnapshotRestore()
+	imported, err := r.Importer.StateContext(ctx, r.Data(&sdkterraform.InstanceState{ID: "other-vault:" + first.Get("snapshot_id").(string)}), client)
+	if err != nil {
+		t.Fatalf("unexpected error importing snapshot restore: %v", err)
+	}
+	restore := imported[0]
+	if diags := resourceVaultClusterSnapshotRestoreRead(ctx, restore, client); diags.HasError() {
+		t.Fatalf("unexpected error reading snapshot restore: %v", diags)
+	}
+	if got := restores[restore.Id()]; got != "other-vault" {
+		t.Errorf("expected the imported restore to match the other-vault one, got ID %q", restore.Id())
+	}
+	if got := restore.Get("cluster_id").(string); got != "other-vault" {
+		t.Errorf("expected cluster_id other-vault, got %q", got)
+	}
+
+	if diags := resourceVaultClusterSnapshotDelete(ctx, first, client); diags.HasError() {
+		t.Fatalf("unexpected error deleting snapshot: %v", diags)
+	}
+	if diags := resourceVaultClusterSnapshotRead(ctx, first, client); diags.HasError() {
+		t.Fatalf("unexpected error reading deleted snapshot: %v", diags)
+	}
+	if first.Id() != "" {
+		t.Errorf("expected the deleted snapshot to be removed from the state, got ID %q", first.Id())
+	}
+}
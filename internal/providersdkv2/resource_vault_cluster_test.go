@@ -6,10 +6,14 @@ package providersdkv2
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	vaultmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-vault-service/stable/2020-11-25/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
@@ -365,3 +369,122 @@ func updateTierNetworkAndRemoveObservability(t *testing.T, in *inputT) resource.
 func addTimestampSuffix(in string) string {
 	return in + time.Now().Format("200601021504")
 }
+
+// TestVaultClusterCreate_resume checks that a create interrupted while waiting
+// for the operation keeps the cluster in the state, and that the next refresh
+// reads the finished cluster. A create that times out still fails.
+func TestVaultClusterCreate_resume(t *testing.T) {
+	client, srv := newTestClient(t)
+	createTestHVN(t, context.Background(), client)
+
+	raw := map[string]interface{}{
+		"cluster_id": "test-vault",
+		"hvn_id":     "test-hvn",
+		"tier":       "dev",
+	}
+
+	// Interrupt the create while the operation is still running.
+	srv.PendingPolls = 1
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(time.Second, cancel)
+
+	d := schema.TestResourceDataRaw(t, resourceVaultCluster().Schema, raw)
+	diags := resourceVaultClusterCreate(ctx, d, client)
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Fatalf("expected a warning for the interrupted create, got %v", diags)
+	}
+	if d.Id() == "" {
+		t.Fatal("expected the interrupted cluster to be kept in the state")
+	}
+
+	// The operation finishes before the next refresh.
+	srv.CompleteOperations()
+	if diags := resourceVaultClusterRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error reading Vault cluster: %v", diags)
+	}
+	if got := d.Get("state").(string); got != string(vaultmodels.HashicorpCloudVault20201125ClusterStateRUNNING) {
+		t.Errorf("expected cluster to be RUNNING, got %q", got)
+	}
+
+	// A cluster that is not being created is still reported as existing.
+	d = schema.TestResourceDataRaw(t, resourceVaultCluster().Schema, raw)
+	diags = resourceVaultClusterCreate(context.Background(), d, client)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "already exists") {
+		t.Fatalf("expected an already exists error, got %v", diags)
+	}
+
+	// A create that runs out of time fails, and keeps the cluster in the state
+	// for Terraform to taint.
+	raw["cluster_id"] = "test-vault-timeout"
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	d = schema.TestResourceDataRaw(t, resourceVaultCluster().Schema, raw)
+	if diags := resourceVaultClusterCreate(ctx, d, client); !diags.HasError() {
+		t.Fatal("expected the timed out create to fail")
+	}
+	if d.Id() == "" {
+		t.Fatal("expected the timed out cluster to be kept in the state")
+	}
+}
+
+// TestVaultClusterLock checks that a cluster can be created locked, and then
+// unlocked and locked again through updates, and that omitting locked leaves
+// the lock in place.
+func TestVaultClusterLock(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := context.Background()
+
+	createTestHVN(t, ctx, client)
+
+	raw := map[string]interface{}{
+		"cluster_id": "test-vault",
+		"hvn_id":     "test-hvn",
+		"tier":       "dev",
+		"locked":     true,
+	}
+	r := resourceVaultCluster()
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	if diags := resourceVaultClusterCreate(ctx, d, client); diags.HasError() {
+		t.Fatalf("unexpected error creating Vault cluster: %v", diags)
+	}
+	if got := d.Get("state").(string); got != string(vaultmodels.HashicorpCloudVault20201125ClusterStateLOCKED) {
+		t.Fatalf("expected cluster to be LOCKED, got %q", got)
+	}
+
+	state := d.State()
+	for _, locked := range []bool{false, true} {
+		raw["locked"] = locked
+		diff, err := r.Diff(ctx, state, sdkterraform.NewResourceConfigRaw(raw), client)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var diags diag.Diagnostics
+		state, diags = r.Apply(ctx, state, diff, client)
+		if diags.HasError() {
+			t.Fatalf("unexpected error setting locked to %t: %v", locked, diags)
+		}
+
+		ds := schema.TestResourceDataRaw(t, dataSourceVaultCluster().Schema, map[string]interface{}{
+			"cluster_id": "test-vault",
+		})
+		if diags := dataSourceVaultClusterRead(ctx, ds, client); diags.HasError() {
+			t.Fatalf("unexpected error reading Vault cluster: %v", diags)
+		}
+		if got := ds.Get("locked").(bool); got != locked {
+			t.Errorf("expected the data source to report locked %t, got %t", locked, got)
+		}
+	}
+
+	// A lock applied outside of Terraform is left in place when locked is
+	// omitted.
+	delete(raw, "locked")
+	diff, err := r.Diff(ctx, state, sdkterraform.NewResourceConfigRaw(raw), client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Errorf("expected no changes with locked omitted, got %v", diff.Attributes)
+	}
+}
@@ -0,0 +1,338 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providersdkv2
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-version"
+	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
+	vaultmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-vault-service/stable/2020-11-25/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+	"github.com/hashicorp/terraform-provider-hcp/internal/input"
+)
+
+func resourceVaultClusterUpgrade() *schema.Resource {
+	return &schema.Resource{
+		Description: "The Vault cluster upgrade resource upgrades an HCP Vault cluster to a given Vault version, " +
+			"for instance a cluster whose `major_version_upgrade_config` has `upgrade_type` set to `MANUAL`. " +
+			"Destroying it leaves the cluster on its current version.",
+		CreateContext: resourceVaultClusterUpgradeCreate,
+		ReadContext:   resourceVaultClusterUpgradeRead,
+		UpdateContext: resourceVaultClusterUpgradeUpdate,
+		DeleteContext: resourceVaultClusterUpgradeDelete,
+		CustomizeDiff: resourceVaultClusterUpgradeCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create:  &createUpdateVaultClusterTimeout,
+			Update:  &createUpdateVaultClusterTimeout,
+			Default: &defaultVaultClusterTimeout,
+		},
+		Schema: map[string]*schema.Schema{
+			// Required inputs
+			"cluster_id": {
+				Description:      "The ID of the HCP Vault cluster.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateSlugID,
+			},
+			"vault_version": {
+				Description:      "The Vault version to upgrade the cluster to. A version lower than the cluster's `current_version` is refused.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateSemVer,
+				DiffSuppressFunc: func(_, old, new string, _ *schema.ResourceData) bool {
+					return input.NormalizeVersion(old) == input.NormalizeVersion(new)
+				},
+			},
+			// Optional inputs
+			"project_id": {
+				Description: `
+The ID of the HCP project where the HCP Vault cluster is located.
+If not specified, the project specified in the HCP Provider config block will be used, if configured.
+If a project is not configured in the HCP Provider config block, the oldest project in the organization will be used.`,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+				Computed:     true,
+			},
+			"maintenance_window_day": {
+				Description:      "The maintenance day of the week the upgrade is expected to run in. If set, the cluster's `major_version_upgrade_config` must be `SCHEDULED` in the same maintenance window. Valid options for maintenance window day - `MONDAY`, `TUESDAY`, `WEDNESDAY`, `THURSDAY`, `FRIDAY`, `SATURDAY`, `SUNDAY`",
+				Type:             schema.TypeString,
+				Optional:         true,
+				RequiredWith:     []string{"maintenance_window_time"},
+				ValidateDiagFunc: validateVaultUpgradeWindowDay,
+				DiffSuppressFunc: func(_, old, new string, _ *schema.ResourceData) bool {
+					return strings.EqualFold(old, new)
+				},
+			},
+			"maintenance_window_time": {
+				Description:      "The maintenance time frame the upgrade is expected to run in. If set, the cluster's `major_version_upgrade_config` must be `SCHEDULED` in the same maintenance window. Valid options for maintenance window time - `WINDOW_12AM_4AM`, `WINDOW_6AM_10AM`, `WINDOW_12PM_4PM`, `WINDOW_6PM_10PM`",
+				Type:             schema.TypeString,
+				Optional:         true,
+				RequiredWith:     []string{"maintenance_window_day"},
+				ValidateDiagFunc: validateVaultUpgradeWindowTime,
+				DiffSuppressFunc: func(_, old, new string, _ *schema.ResourceData) bool {
+					return strings.EqualFold(old, new)
+				},
+			},
+			// Computed outputs
+			"organization_id": {
+				Description: "The ID of the HCP organization where the HCP Vault cluster is located.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"current_version": {
+				Description: "The Vault version the cluster currently runs.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// resourceVaultClusterUpgradeCustomizeDiff refuses to plan a lower target
+// version than the one the cluster currently runs, or a maintenance window
+// that does not match the cluster's upgrade config.
+func resourceVaultClusterUpgradeCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && !d.HasChanges("vault_version", "maintenance_window_day", "maintenance_window_time") {
+		return nil
+	}
+	for _, key := range []string{"cluster_id", "vault_version", "maintenance_window_day", "maintenance_window_time"} {
+		if !d.NewValueKnown(key) {
+			return nil
+		}
+	}
+
+	// A project only known once applied is left to the checks done on create.
+	if config := d.GetRawConfig(); !config.IsNull() && !config.GetAttr("project_id").IsKnown() {
+		return nil
+	}
+
+	client := meta.(*clients.Client)
+
+	clusterID := d.Get("cluster_id").(string)
+
+	projectID, err := GetProjectID(d.Get("project_id").(string), client.Config.ProjectID)
+	if err != nil {
+		return fmt.Errorf("unable to retrieve project ID: %v", err)
+	}
+
+	loc := &sharedmodels.HashicorpCloudLocationLocation{
+		OrganizationID: client.Config.OrganizationID,
+		ProjectID:      projectID,
+	}
+
+	// A cluster that does not exist yet is checked again on create.
+	cluster, err := clients.GetVaultClusterByID(ctx, client, loc, clusterID)
+	if err != nil {
+		if clients.IsResponseCodeNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("unable to fetch Vault cluster (%s): %v", clusterID, err)
+	}
+
+	if err := validateVaultUpgradeVersion(cluster.CurrentVersion, input.NormalizeVersion(d.Get("vault_version").(string))); err != nil {
+		return cty.GetAttrPath("vault_version").NewError(err)
+	}
+
+	if cluster.Config != nil {
+		if err := validateVaultUpgradeMaintenanceWindow(cluster.Config.MajorVersionUpgradeConfig, d.Get("maintenance_window_day").(string), d.Get("maintenance_window_time").(string)); err != nil {
+			return cty.GetAttrPath("maintenance_window_day").NewError(err)
+		}
+	}
+
+	return nil
+}
+
+func resourceVaultClusterUpgradeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client)
+
+	projectID, err := GetProjectID(d.Get("project_id").(string), client.Config.ProjectID)
+	if err != nil {
+		return diag.Errorf("unable to retrieve project ID: %v", err)
+	}
+
+	loc := &sharedmodels.HashicorpCloudLocationLocation{
+		OrganizationID: client.Config.OrganizationID,
+		ProjectID:      projectID,
+	}
+
+	clusterID := d.Get("cluster_id").(string)
+
+	if diags := upgradeVaultCluster(ctx, client, d, loc, clusterID); diags != nil {
+		return diags
+	}
+
+	link := newLink(loc, VaultClusterUpgradeResourceType, clusterID)
+	url, err := linkURL(link)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(url)
+
+	return resourceVaultClusterUpgradeRead(ctx, d, meta)
+}
+
+func resourceVaultClusterUpgradeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client)
+
+	link, err := buildLinkFromURL(d.Id(), VaultClusterUpgradeResourceType, client.Config.OrganizationID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	clusterID := link.ID
+	loc := link.Location
+
+	cluster, err := clients.GetVaultClusterByID(ctx, client, loc, clusterID)
+	if err != nil {
+		if clients.IsResponseCodeNotFound(err) {
+			log.Printf("[WARN] Vault cluster (%s) not found, removing upgrade from state", clusterID)
+			d.SetId("")
+			return nil
+		}
+
+		return diag.Errorf("unable to fetch Vault cluster (%s): %v", clusterID, err)
+	}
+
+	if err := d.Set("cluster_id", cluster.ID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("project_id", cluster.Location.ProjectID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("organization_id", cluster.Location.OrganizationID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("current_version", cluster.CurrentVersion); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceVaultClusterUpgradeUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client)
+
+	link, err := buildLinkFromURL(d.Id(), VaultClusterUpgradeResourceType, client.Config.OrganizationID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if diags := upgradeVaultCluster(ctx, client, d, link.Location, link.ID); diags != nil {
+		return diags
+	}
+
+	return resourceVaultClusterUpgradeRead(ctx, d, meta)
+}
+
+func resourceVaultClusterUpgradeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// An upgrade cannot be undone, so the cluster is left on its current version.
+	log.Printf("[INFO] Removing Vault cluster upgrade (%s) from state, the cluster keeps its current version", d.Get("cluster_id").(string))
+
+	return nil
+}
+
+// upgradeVaultCluster upgrades the cluster to the configured Vault version
+// unless it already runs it, and waits for the upgrade to complete.
+func upgradeVaultCluster(ctx context.Context, client *clients.Client, d *schema.ResourceData, loc *sharedmodels.HashicorpCloudLocationLocation, clusterID string) diag.Diagnostics {
+	cluster, err := clients.GetVaultClusterByID(ctx, client, loc, clusterID)
+	if err != nil {
+		if !clients.IsResponseCodeNotFound(err) {
+			return diag.Errorf("unable to check for presence of an existing Vault cluster (%s): %v", clusterID, err)
+		}
+
+		// a 404 indicates a Vault cluster was not found
+		return diag.Errorf("unable to upgrade Vault cluster; no HCP Vault cluster found for Vault cluster (%s)", clusterID)
+	}
+
+	if err := validateVaultUpgradeMaintenanceWindow(cluster.Config.MajorVersionUpgradeConfig, d.Get("maintenance_window_day").(string), d.Get("maintenance_window_time").(string)); err != nil {
+		return diag.Errorf("unable to upgrade Vault cluster (%s): %v", clusterID, err)
+	}
+
+	targetVersion := input.NormalizeVersion(d.Get("vault_version").(string))
+	if err := validateVaultUpgradeVersion(cluster.CurrentVersion, targetVersion); err != nil {
+		return diag.Errorf("unable to upgrade Vault cluster (%s): %v", clusterID, err)
+	}
+	if input.NormalizeVersion(cluster.CurrentVersion) == targetVersion {
+		log.Printf("[INFO] Vault cluster (%s) already runs version %s", clusterID, targetVersion)
+		return nil
+	}
+
+	clusterSharedLoc := vaultClusterSharedLocation(cluster)
+
+	log.Printf("[INFO] Upgrading Vault cluster (%s) from version %s to %s", clusterID, cluster.CurrentVersion, targetVersion)
+
+	updateResp, err := clients.UpdateVaultClusterVersion(ctx, client, clusterSharedLoc, clusterID, targetVersion)
+	if err != nil {
+		return diag.Errorf("error upgrading Vault cluster (%s): %v", clusterID, err)
+	}
+
+	if err := clients.WaitForOperation(ctx, client, "upgrade Vault cluster", clusterSharedLoc, updateResp.Operation.ID); err != nil {
+		return diag.Errorf("unable to upgrade Vault cluster (%s): %v", clusterID, err)
+	}
+
+	return nil
+}
+
+// validateVaultUpgradeVersion checks that upgrading from one Vault version to
+// another is not a downgrade.
+func validateVaultUpgradeVersion(from, to string) error {
+	fromVersion, err := version.NewSemver(from)
+	if err != nil {
+		return fmt.Errorf("invalid current version %q: %v", from, err)
+	}
+	toVersion, err := version.NewSemver(to)
+	if err != nil {
+		return fmt.Errorf("invalid target version %q: %v", to, err)
+	}
+
+	if toVersion.LessThan(fromVersion) {
+		return fmt.Errorf("cannot downgrade from version %s to %s", from, to)
+	}
+
+	return nil
+}
+
+// validateVaultUpgradeMaintenanceWindow checks that the maintenance window the
+// upgrade is expected to run in matches the upgrade config of the cluster.
+func validateVaultUpgradeMaintenanceWindow(config *vaultmodels.HashicorpCloudVault20201125MajorVersionUpgradeConfig, day, window string) error {
+	if day == "" && window == "" {
+		return nil
+	}
+
+	if config == nil || config.UpgradeType == nil || *config.UpgradeType != vaultmodels.HashicorpCloudVault20201125MajorVersionUpgradeConfigUpgradeTypeSCHEDULED {
+		return fmt.Errorf("maintenance_window_day and maintenance_window_time are only allowed when the cluster's major_version_upgrade_config is SCHEDULED")
+	}
+
+	var currentDay, currentWindow string
+	if config.MaintenanceWindow != nil {
+		if config.MaintenanceWindow.DayOfWeek != nil {
+			currentDay = string(*config.MaintenanceWindow.DayOfWeek)
+		}
+		if config.MaintenanceWindow.TimeWindowUtc != nil {
+			currentWindow = string(*config.MaintenanceWindow.TimeWindowUtc)
+		}
+	}
+
+	if !strings.EqualFold(day, currentDay) || !strings.EqualFold(window, currentWindow) {
+		return fmt.Errorf("maintenance window %s %s does not match the cluster's maintenance window %s %s", day, window, currentDay, currentWindow)
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providersdkv2
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestVaultClusterUpgrade upgrades a Vault cluster running on the in-process
+// fake HCP server and checks downgrades and mismatched maintenance windows are
+// refused.
+func TestVaultClusterUpgrade(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := context.Background()
+
+	createTestHVN(t, ctx, client)
+	cluster := createTestVaultCluster(t, ctx, client, "standard_small", map[string]interface{}{
+		"major_version_upgrade_config": []interface{}{
+			map[string]interface{}{
+				"upgrade_type":            "SCHEDULED",
+				"maintenance_window_day":  "MONDAY",
+				"maintenance_window_time": "WINDOW_12AM_4AM",
+			},
+		},
+	})
+
+	r := resourceVaultClusterUpgrade()
+
+	tcs := map[string]struct {
+		raw         map[string]interface{}
+		expectedErr string
+		// planned is set if the error is already reported at plan time.
+		planned bool
+	}{
+		"downgrade": {
+			raw: map[string]interface{}{
+				"cluster_id":    "test-vault",
+				"vault_version": "1.14.0",
+			},
+			expectedErr: "cannot downgrade",
+			planned:     true,
+		},
+		"mismatched maintenance window": {
+			raw: map[string]interface{}{
+				"cluster_id":              "test-vault",
+				"vault_version":           "1.16.0",
+				"maintenance_window_day":  "TUESDAY",
+				"maintenance_window_time": "WINDOW_12AM_4AM",
+			},
+			expectedErr: "does not match",
+			planned:     true,
+		},
+		"missing cluster": {
+			raw: map[string]interface{}{
+				"cluster_id":    "missing-vault",
+				"vault_version": "1.16.0",
+			},
+			expectedErr: "no HCP Vault cluster found",
+		},
+	}
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			_, err := r.Diff(ctx, nil, sdkterraform.NewResourceConfigRaw(tc.raw), client)
+			if tc.planned && (err == nil || !strings.Contains(err.Error(), tc.expectedErr)) {
+				t.Errorf("expected plan error to contain %q, got %v", tc.expectedErr, err)
+			}
+			if !tc.planned && err != nil {
+				t.Errorf("unexpected plan error: %v", err)
+			}
+
+			d := schema.TestResourceDataRaw(t, r.Schema, tc.raw)
+			diags := resourceVaultClusterUpgradeCreate(ctx, d, client)
+			if !diags.HasError() {
+				t.Fatal("expected an error, got none")
+			}
+			if !strings.Contains(diags[0].Summary, tc.expectedErr) {
+				t.Errorf("expected error to contain %q, got %q", tc.expectedErr, diags[0].Summary)
+			}
+		})
+	}
+
+	raw := map[string]interface{}{
+		"cluster_id":              "test-vault",
+		"vault_version":           "1.16.0",
+		"maintenance_window_day":  "monday",
+		"maintenance_window_time": "WINDOW_12AM_4AM",
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	if diags := resourceVaultClusterUpgradeCreate(ctx, d, client); diags.HasError() {
+		t.Fatalf("unexpected error upgrading Vault cluster: %v", diags)
+	}
+	if got := d.Get("current_version").(string); got != "v1.16.0" {
+		t.Errorf("expected current_version v1.16.0, got %q", got)
+	}
+
+	// Planning a lower version than the one the cluster runs is refused up
+	// front, for an existing upgrade as well as for a new one.
+	raw["vault_version"] = "1.15.4"
+	if _, err := r.Diff(ctx, d.State(), sdkterraform.NewResourceConfigRaw(raw), client); err == nil || !strings.Contains(err.Error(), "cannot downgrade") {
+		t.Errorf("expected a downgrade error, got %v", err)
+	}
+	if _, err := r.Diff(ctx, nil, sdkterraform.NewResourceConfigRaw(raw), client); err == nil || !strings.Contains(err.Error(), "cannot downgrade") {
+		t.Errorf("expected a downgrade error for a new upgrade, got %v", err)
+	}
+
+	// Destroying the upgrade leaves the cluster on its new version.
+	if diags := resourceVaultClusterUpgradeDelete(ctx, d, client); diags.HasError() {
+		t.Fatalf("unexpected error deleting upgrade: %v", diags)
+	}
+	if diags := resourceVaultClusterRead(ctx, cluster, client); diags.HasError() {
+		t.Fatalf("unexpected error reading Vault cluster: %v", diags)
+	}
+	if got := cluster.Get("vault_version").(string); got != "v1.16.0" {
+		t.Errorf("expected the cluster to keep version v1.16.0, got %q", got)
+	}
+}
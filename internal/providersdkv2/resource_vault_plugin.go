@@ -73,6 +73,17 @@ If a project is not configured in the HCP Provider config block, the oldest proj
 				ValidateFunc: validation.IsUUID,
 				Computed:     true,
 			},
+			"version": {
+				Description: "The version of the plugin. HCP registers the plugin version it ships for the cluster: set this to the version you expect, " +
+					"so that a new version is planned as an in-place update that re-registers the plugin. The apply fails if HCP does not register that version. " +
+					"The plugin cannot be pinned to a checksum, as the plugin registration API has no checksum field.",
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				DiffSuppressFunc: func(_, old, new string, _ *schema.ResourceData) bool {
+					return vaultPluginVersionsEqual(old, new)
+				},
+			},
 		},
 	}
 }
@@ -103,11 +114,7 @@ func resourceVaultPluginCreate(ctx context.Context, d *schema.ResourceData, meta
 
 	d.SetId(vaultPluginResourceID(projectID, clusterID, pluginType, pluginName))
 
-	if err := setVaultPluginResourceData(d, projectID, clusterID, pluginName, pluginType); err != nil {
-		return diag.FromErr(err)
-	}
-
-	return nil
+	return checkVaultPluginRegistration(ctx, client, d, loc, clusterID, pluginName, pluginType)
 }
 
 func resourceVaultPluginRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -119,29 +126,23 @@ func resourceVaultPluginRead(ctx context.Context, d *schema.ResourceData, meta i
 	projectID := idParts[2]
 	pluginName := idParts[7]
 	pluginTypeString := idParts[6]
-	pluginType := vaultmodels.HashicorpCloudVault20201125PluginType(pluginTypeString)
 
 	loc := &sharedmodels.HashicorpCloudLocationLocation{
 		OrganizationID: client.Config.OrganizationID,
 		ProjectID:      projectID,
 	}
 
-	log.Printf("[INFO] Listing plugins for Vault cluster (%s) [project_id=%s, organization_id=%s]", clusterID, loc.ProjectID, loc.OrganizationID)
-
-	pluginsResp, err := clients.ListPlugins(ctx, client, loc, clusterID)
+	plugin, err := getVaultPluginRegistration(ctx, client, loc, clusterID, pluginName, pluginTypeString)
 	if err != nil {
-		log.Printf("[ERROR] Vault cluster (%s) failed to list plugins", clusterID)
 		return diag.FromErr(err)
 	}
 
-	for _, plugin := range pluginsResp.Plugins {
-		if strings.EqualFold(pluginName, plugin.PluginName) && pluginType == *plugin.PluginType && plugin.IsRegistered {
-			// Cluster found, update resource data.
-			if err := setVaultPluginResourceData(d, loc.ProjectID, clusterID, pluginName, pluginTypeString); err != nil {
-				return diag.FromErr(err)
-			}
-			return nil
+	if plugin != nil {
+		// Plugin found, update resource data.
+		if err := setVaultPluginResourceData(d, loc.ProjectID, clusterID, pluginName, pluginTypeString, plugin.PluginVersion); err != nil {
+			return diag.FromErr(err)
 		}
+		return nil
 	}
 
 	// if plugin is not registered, remove from state
@@ -159,41 +160,24 @@ func resourceVaultPluginUpdate(ctx context.Context, d *schema.ResourceData, meta
 		return diag.Errorf("unable to retrieve project ID: %v", err)
 	}
 
-	if d.HasChanges("cluster_id", "project_id", "plugin_name", "plugin_type") {
-
-		oldPlugin, _ := d.GetChange("vault_plugin")
-
-		config, ok := oldPlugin.(map[string]interface{})
-		if !ok {
-			return diag.Errorf("could not parse old plugin config: %v", err)
-		}
-
-		oldPluginName := config["plugin_name"].(string)
-		oldPluginType := config["plugin_type"].(string)
-		oldProjectID, err := GetProjectID(config["project_id"].(string), client.Config.ProjectID)
-		if err != nil {
-			return diag.Errorf("unable to retrieve project ID: %v", err)
-		}
-		oldClusterID := config["cluster_id"].(string)
+	loc := &sharedmodels.HashicorpCloudLocationLocation{
+		OrganizationID: client.Config.OrganizationID,
+		ProjectID:      projectID,
+	}
 
-		oldLoc := &sharedmodels.HashicorpCloudLocationLocation{
-			OrganizationID: client.Config.OrganizationID,
-			ProjectID:      oldProjectID,
-		}
+	if d.HasChanges("plugin_name", "plugin_type") {
+		oldPluginName, _ := d.GetChange("plugin_name")
+		oldPluginType, _ := d.GetChange("plugin_type")
 
-		log.Printf("[INFO] Deleting Vault Plugin (%s) on Vault Cluster (%s)", pluginName, clusterID)
-		req := &vaultmodels.HashicorpCloudVault20201125DeletePluginRequest{PluginName: oldPluginName, PluginType: oldPluginType}
-		_, err = clients.DeletePlugin(ctx, client, oldLoc, clusterID, req)
+		log.Printf("[INFO] Deleting Vault Plugin (%s) on Vault Cluster (%s)", oldPluginName, clusterID)
+		req := &vaultmodels.HashicorpCloudVault20201125DeletePluginRequest{PluginName: oldPluginName.(string), PluginType: oldPluginType.(string)}
+		_, err = clients.DeletePlugin(ctx, client, loc, clusterID, req)
 		if err != nil {
-			return diag.Errorf("error deleting plugin (%s) on Vault cluster (%s): %v", oldPluginName, oldClusterID, err)
+			return diag.Errorf("error deleting plugin (%s) on Vault cluster (%s): %v", oldPluginName, clusterID, err)
 		}
 	}
 
-	loc := &sharedmodels.HashicorpCloudLocationLocation{
-		OrganizationID: client.Config.OrganizationID,
-		ProjectID:      projectID,
-	}
-
+	// Adding a plugin again registers the version HCP currently ships for it.
 	log.Printf("[INFO] Adding Vault Plugin (%s) on Vault Cluster (%s)", pluginName, clusterID)
 	req := &vaultmodels.HashicorpCloudVault20201125AddPluginRequest{PluginName: pluginName, PluginType: pluginType}
 	_, err = clients.AddPlugin(ctx, client, loc, clusterID, req)
@@ -201,10 +185,9 @@ func resourceVaultPluginUpdate(ctx context.Context, d *schema.ResourceData, meta
 		return diag.Errorf("error adding plugin (%s) to Vault cluster (%s): %v", pluginName, clusterID, err)
 	}
 
-	if err := setVaultPluginResourceData(d, projectID, clusterID, pluginName, pluginType); err != nil {
-		return diag.FromErr(err)
-	}
-	return nil
+	d.SetId(vaultPluginResourceID(projectID, clusterID, pluginType, pluginName))
+
+	return checkVaultPluginRegistration(ctx, client, d, loc, clusterID, pluginName, pluginType)
 }
 
 func resourceVaultPluginDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -234,8 +217,60 @@ func resourceVaultPluginDelete(ctx context.Context, d *schema.ResourceData, meta
 	return nil
 }
 
+// getVaultPluginRegistration returns the registration status of the plugin, or nil if it is not registered.
+func getVaultPluginRegistration(ctx context.Context, client *clients.Client, loc *sharedmodels.HashicorpCloudLocationLocation,
+	clusterID string, pluginName string, pluginType string) (*vaultmodels.HashicorpCloudVault20201125PluginRegistrationStatus, error) {
+
+	log.Printf("[INFO] Listing plugins for Vault cluster (%s) [project_id=%s, organization_id=%s]", clusterID, loc.ProjectID, loc.OrganizationID)
+
+	pluginsResp, err := clients.ListPlugins(ctx, client, loc, clusterID)
+	if err != nil {
+		log.Printf("[ERROR] Vault cluster (%s) failed to list plugins", clusterID)
+		return nil, err
+	}
+
+	for _, plugin := range pluginsResp.Plugins {
+		if strings.EqualFold(pluginName, plugin.PluginName) && plugin.PluginType != nil &&
+			strings.EqualFold(pluginType, string(*plugin.PluginType)) && plugin.IsRegistered {
+			return plugin, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// checkVaultPluginRegistration verifies the plugin got registered at the configured version, if any, and sets the
+// resource data.
+func checkVaultPluginRegistration(ctx context.Context, client *clients.Client, d *schema.ResourceData, loc *sharedmodels.HashicorpCloudLocationLocation,
+	clusterID string, pluginName string, pluginType string) diag.Diagnostics {
+
+	plugin, err := getVaultPluginRegistration(ctx, client, loc, clusterID, pluginName, pluginType)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if plugin == nil {
+		return diag.Errorf("plugin (%s) is not registered on Vault cluster (%s)", pluginName, clusterID)
+	}
+
+	if version := d.Get("version").(string); version != "" && !vaultPluginVersionsEqual(version, plugin.PluginVersion) {
+		return diag.Errorf("plugin (%s) was registered on Vault cluster (%s) at version %s, not the configured version %s",
+			pluginName, clusterID, plugin.PluginVersion, version)
+	}
+
+	if err := setVaultPluginResourceData(d, loc.ProjectID, clusterID, pluginName, pluginType, plugin.PluginVersion); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// vaultPluginVersionsEqual compares two plugin versions, ignoring the "v" prefix.
+func vaultPluginVersionsEqual(a, b string) bool {
+	return strings.TrimPrefix(a, "v") == strings.TrimPrefix(b, "v")
+}
+
 // setVaultPluginResourceData sets the KV pairs of the Vault cluster resource schema.
-func setVaultPluginResourceData(d *schema.ResourceData, projectID string, clusterID string, pluginName string, pluginType string) error {
+func setVaultPluginResourceData(d *schema.ResourceData, projectID string, clusterID string, pluginName string, pluginType string, version string) error {
 	if err := d.Set("cluster_id", clusterID); err != nil {
 		return err
 	}
@@ -252,6 +287,10 @@ func setVaultPluginResourceData(d *schema.ResourceData, projectID string, cluste
 		return err
 	}
 
+	if err := d.Set("version", version); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -11,9 +11,12 @@ import (
 
 	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
 	vaultmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-vault-service/stable/2020-11-25/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients/hcptest"
 	grpcstatus "google.golang.org/grpc/status"
 )
 
@@ -187,3 +190,89 @@ func isPluginRegistered(client *clients.Client, id string) (bool, error) {
 
 	return false, nil
 }
+
+// TestVaultPluginVersion checks plugin upgrades are planned and applied in
+// place against the in-process fake HCP server, and listed by the plugins data
+// source.
+func TestVaultPluginVersion(t *testing.T) {
+	client, srv := newTestClient(t)
+	ctx := context.Background()
+
+	createTestHVN(t, ctx, client)
+	createTestVaultCluster(t, ctx, client, "dev", nil)
+
+	raw := map[string]interface{}{
+		"cluster_id":  "test-vault",
+		"plugin_name": "venafi-pki-backend",
+		"plugin_type": "SECRET",
+		"version":     strings.TrimPrefix(hcptest.DefaultVaultPluginVersion, "v"),
+	}
+	r := resourceVaultPlugin()
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	if diags := resourceVaultPluginCreate(ctx, d, client); diags.HasError() {
+		t.Fatalf("unexpected error adding plugin: %v", diags)
+	}
+	if got := d.Get("version").(string); got != hcptest.DefaultVaultPluginVersion {
+		t.Errorf("expected version %s, got %q", hcptest.DefaultVaultPluginVersion, got)
+	}
+
+	// HCP ships a new version of the plugin.
+	srv.VaultPluginVersion = "v0.14.0"
+
+	state := d.State()
+	tcs := []struct {
+		version     string
+		expectedErr string
+	}{
+		{version: "v0.14.0"},
+		{version: "v0.15.0", expectedErr: "not the configured version v0.15.0"},
+	}
+	for _, tc := range tcs {
+		raw["version"] = tc.version
+		diff, err := r.Diff(ctx, state, sdkterraform.NewResourceConfigRaw(raw), client)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if diff.RequiresNew() {
+			t.Errorf("expected an in-place update to version %s", tc.version)
+		}
+
+		newState, diags := r.Apply(ctx, state, diff, client)
+		if tc.expectedErr != "" {
+			if !diags.HasError() || !strings.Contains(diags[0].Summary, tc.expectedErr) {
+				t.Errorf("expected error %q, got %v", tc.expectedErr, diags)
+			}
+			continue
+		}
+		if diags.HasError() {
+			t.Fatalf("unexpected error updating plugin to version %s: %v", tc.version, diags)
+		}
+		state = newState
+		if got := state.Attributes["version"]; got != tc.version {
+			t.Errorf("expected version %s, got %q", tc.version, got)
+		}
+	}
+
+	ds := schema.TestResourceDataRaw(t, dataSourceVaultPlugins().Schema, map[string]interface{}{
+		"cluster_id": "test-vault",
+	})
+	if diags := dataSourceVaultPluginsRead(ctx, ds, client); diags.HasError() {
+		t.Fatalf("unexpected error listing plugins: %v", diags)
+	}
+	plugins := ds.Get("plugins").([]interface{})
+	if len(plugins) != 1 {
+		t.Fatalf("expected 1 plugin, got %d", len(plugins))
+	}
+	expected := map[string]interface{}{
+		"plugin_name":   "venafi-pki-backend",
+		"plugin_type":   "SECRET",
+		"version":       "v0.14.0",
+		"is_registered": true,
+		"status":        string(vaultmodels.HashicorpCloudVault20201125OptInStateCUSTOMERENABLED),
+	}
+	for k, v := range expected {
+		if got := plugins[0].(map[string]interface{})[k]; got != v {
+			t.Errorf("expected plugin %s %v, got %v", k, v, got)
+		}
+	}
+}
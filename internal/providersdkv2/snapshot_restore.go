@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providersdkv2
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+)
+
+// snapshotRestoreLinkID returns the ID of the link of a snapshot restore. It
+// includes the cluster, as the same snapshot can be restored on several
+// clusters.
+func snapshotRestoreLinkID(clusterID, snapshotID string) string {
+	return clusterID + ":" + snapshotID
+}
+
+// parseSnapshotRestoreLinkID splits the ID of the link of a snapshot restore
+// into the cluster ID and the snapshot ID.
+func parseSnapshotRestoreLinkID(id string) (string, string, error) {
+	clusterID, snapshotID, ok := strings.Cut(id, ":")
+	if !ok || clusterID == "" || snapshotID == "" {
+		return "", "", fmt.Errorf("unexpected format of snapshot restore ID (%q), expected {cluster_id}:{snapshot_id}", id)
+	}
+	return clusterID, snapshotID, nil
+}
+
+// snapshotRestoreImport returns the importer of a snapshot restore resource of
+// the given link resource type.
+func snapshotRestoreImport(resourceType string) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		// with multi-projects, import arguments must become dynamic:
+		// use explicit project ID with terraform import:
+		//   terraform import {resource}.test {project_id}:{cluster_id}:{snapshot_id}
+		// use default project ID from provider:
+		//   terraform import {resource}.test {cluster_id}:{snapshot_id}
+
+		client := meta.(*clients.Client)
+		projectID := ""
+		clusterID := ""
+		snapshotID := ""
+		var err error
+
+		idParts := strings.Split(d.Id(), ":")
+		if len(idParts) == 3 { // {project_id}:{cluster_id}:{snapshot_id}
+			projectID = idParts[0]
+			clusterID = idParts[1]
+			snapshotID = idParts[2]
+		} else if len(idParts) == 2 { // {cluster_id}:{snapshot_id}
+			projectID, err = GetProjectID(projectID, client.Config.ProjectID)
+			if err != nil {
+				return nil, fmt.Errorf("unable to retrieve project ID: %v", err)
+			}
+			clusterID = idParts[0]
+			snapshotID = idParts[1]
+		}
+		if projectID == "" || clusterID == "" || snapshotID == "" {
+			return nil, fmt.Errorf("unexpected format of ID (%q), expected {cluster_id}:{snapshot_id} or {project_id}:{cluster_id}:{snapshot_id}", d.Id())
+		}
+
+		loc := &sharedmodels.HashicorpCloudLocationLocation{
+			ProjectID: projectID,
+		}
+
+		link := newLink(loc, resourceType, snapshotRestoreLinkID(clusterID, snapshotID))
+		url, err := linkURL(link)
+		if err != nil {
+			return nil, err
+		}
+
+		d.SetId(url)
+
+		return []*schema.ResourceData{d}, nil
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providersdkv2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients/hcptest"
+)
+
+// newTestClient starts an in-process fake HCP server and returns a client
+// configured to use it, along with the server.
+func newTestClient(t *testing.T) (*clients.Client, *hcptest.Server) {
+	t.Helper()
+
+	srv := hcptest.NewServer(t)
+	srv.Setenv(t)
+
+	client, err := clients.NewClient(srv.ClientConfig())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return client, srv
+}
+
+// createTestHVN creates the test-hvn HVN in AWS us-west-2.
+func createTestHVN(t *testing.T, ctx context.Context, client *clients.Client) *schema.ResourceData {
+	t.Helper()
+
+	d := schema.TestResourceDataRaw(t, resourceHvn().Schema, map[string]interface{}{
+		"hvn_id":         "test-hvn",
+		"cloud_provider": "aws",
+		"region":         "us-west-2",
+	})
+	if diags := resourceHvnCreate(ctx, d, client); diags.HasError() {
+		t.Fatalf("unexpected error creating HVN: %v", diags)
+	}
+	return d
+}
+
+// createTestVaultCluster creates the test-vault Vault cluster of the given tier
+// in the test-hvn HVN. Attributes in extra are added to, or override, the
+// configuration.
+func createTestVaultCluster(t *testing.T, ctx context.Context, client *clients.Client, tier string, extra map[string]interface{}) *schema.ResourceData {
+	t.Helper()
+
+	raw := map[string]interface{}{
+		"cluster_id": "test-vault",
+		"hvn_id":     "test-hvn",
+		"tier":       tier,
+	}
+	for k, v := range extra {
+		raw[k] = v
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceVaultCluster().Schema, raw)
+	if diags := resourceVaultClusterCreate(ctx, d, client); diags.HasError() {
+		t.Fatalf("unexpected error creating Vault cluster: %v", diags)
+	}
+	return d
+}
+
+// createTestConsulCluster creates the test-consul development Consul cluster
+// in the test-hvn HVN. Attributes in extra are added to, or override, the
+// configuration.
+func createTestConsulCluster(t *testing.T, ctx context.Context, client *clients.Client, extra map[string]interface{}) *schema.ResourceData {
+	t.Helper()
+
+	raw := map[string]interface{}{
+		"cluster_id": "test-consul",
+		"hvn_id":     "test-hvn",
+		"tier":       "development",
+	}
+	for k, v := range extra {
+		raw[k] = v
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceConsulCluster().Schema, raw)
+	if diags := resourceConsulClusterCreate(ctx, d, client); diags.HasError() {
+		t.Fatalf("unexpected error creating Consul cluster: %v", diags)
+	}
+	return d
+}
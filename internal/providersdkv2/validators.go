@@ -111,6 +111,23 @@ func validateSemVer(v interface{}, path cty.Path) diag.Diagnostics {
 	return diagnostics
 }
 
+// validateSemVerOrConstraint validates that the string value is either a valid semver or a
+// version constraint, such as "~> 1.16".
+func validateSemVerOrConstraint(v interface{}, path cty.Path) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+	if _, err := version.NewConstraint(v.(string)); err != nil {
+		msg := "must be a valid semver or version constraint"
+		diagnostics = append(diagnostics, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       msg,
+			Detail:        msg,
+			AttributePath: path,
+		})
+	}
+
+	return diagnostics
+}
+
 // matchesID matches /project/11eabb9f-d2ee-9c80-9483-0242ac110013/hashicorp.consul.cluster/example
 func matchesID(id string) bool {
 	return regexp.MustCompile(`/project/\b[0-9a-f]{8}\b-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-\b[0-9a-f]{12}\b/hashicorp\.consul\.cluster/.*`).MatchString(id)
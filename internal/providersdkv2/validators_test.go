@@ -128,6 +128,61 @@ func Test_validateSemVer(t *testing.T) {
 	}
 }
 
+func Test_validateSemVerOrConstraint(t *testing.T) {
+	tcs := map[string]struct {
+		expected diag.Diagnostics
+		input    string
+	}{
+		"valid semver with prefixed v": {
+			input:    "v1.2.3",
+			expected: nil,
+		},
+		"valid semver without prefixed v": {
+			input:    "1.2.3",
+			expected: nil,
+		},
+		"valid pessimistic constraint": {
+			input:    "~> 1.2",
+			expected: nil,
+		},
+		"valid range constraint": {
+			input:    ">= 1.2, < 1.4",
+			expected: nil,
+		},
+		"invalid semver": {
+			input: "v1.2.3.beta",
+			expected: diag.Diagnostics{
+				diag.Diagnostic{
+					Severity:      diag.Error,
+					Summary:       "must be a valid semver or version constraint",
+					Detail:        "must be a valid semver or version constraint",
+					AttributePath: nil,
+				},
+			},
+		},
+		"invalid constraint": {
+			input: "~> latest",
+			expected: diag.Diagnostics{
+				diag.Diagnostic{
+					Severity:      diag.Error,
+					Summary:       "must be a valid semver or version constraint",
+					Detail:        "must be a valid semver or version constraint",
+					AttributePath: nil,
+				},
+			},
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			result := validateSemVerOrConstraint(tc.input, nil)
+			r.Equal(tc.expected, result)
+		})
+	}
+}
+
 func Test_validateSlugID(t *testing.T) {
 	tcs := map[string]struct {
 		expected diag.Diagnostics
@@ -78,5 +78,7 @@ func New() (func() tfprotov6.ProviderServer, error) {
 	if err != nil {
 		return nil, err
 	}
-	return muxServer.ProviderServer, nil
+	return func() tfprotov6.ProviderServer {
+		return provider.NewPlanGuardServer(muxServer.ProviderServer())
+	}, nil
 }